@@ -0,0 +1,141 @@
+// rwreplay reads the draw recording written by raywin.RecordingProxy and either
+// replays it in the desktop window, renders the frames to PNG files or dumps them as text.
+//
+// Usage:
+//
+//	rwreplay [-text] [-png <dir>] [-fps <n>] [-loop] <recording file>
+package main
+
+import (
+	"flag"
+	"fmt"
+	"github.com/dspasibenko/raywin-go/pkg/golibs/context"
+	"github.com/dspasibenko/raywin-go/raywin"
+	rl "github.com/gen2brain/raylib-go/raylib"
+	"image/png"
+	"os"
+	"path/filepath"
+	"syscall"
+)
+
+// player is the component, which replays the recorded frames one by one
+type player struct {
+	raywin.BaseComponent
+	frames []raywin.DrawFrame
+	fps    int
+	loop   bool
+	start  int64
+	idx    int
+}
+
+// fontPainter draws the recorded text by the default raylib font, the recording doesn't
+// contain the fonts
+type fontPainter struct {
+	raywin.Painter
+}
+
+func main() {
+	text := flag.Bool("text", false, "dump the recorded frames as text")
+	pngDir := flag.String("png", "", "render the frames to PNG files in the directory")
+	fps := flag.Int("fps", 30, "the replay speed in the window, frames per second")
+	loop := flag.Bool("loop", false, "replay the frames in the window in the loop")
+	flag.Parse()
+	if flag.NArg() != 1 {
+		fmt.Fprintf(os.Stderr, "usage: %s [-text] [-png <dir>] [-fps <n>] [-loop] <recording file>\n", os.Args[0])
+		os.Exit(2)
+	}
+
+	frames, err := readFrames(flag.Arg(0))
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		if len(frames) == 0 {
+			os.Exit(1)
+		}
+	}
+	if *text {
+		for _, f := range frames {
+			fmt.Print(f.String())
+		}
+		return
+	}
+	if *pngDir != "" {
+		if err := renderPNGs(frames, *pngDir); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+		return
+	}
+	if len(frames) == 0 {
+		fmt.Fprintln(os.Stderr, "the recording contains no frames")
+		os.Exit(1)
+	}
+	cfg := raywin.DefaultConfig()
+	cfg.DisplayConfig.Width, cfg.DisplayConfig.Height = uint32(frames[0].Width), uint32(frames[0].Height)
+	if err := raywin.Init(cfg); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+	pl := &player{frames: frames, fps: max(1, *fps), loop: *loop, start: -1}
+	if err := pl.Init(raywin.RootContainer(), pl); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+	pl.SetBounds(rl.RectangleInt32{Width: frames[0].Width, Height: frames[0].Height})
+	ctx := context.NewSignalsContext(os.Interrupt, syscall.SIGTERM)
+	raywin.Run(ctx)
+}
+
+func readFrames(fileName string) ([]raywin.DrawFrame, error) {
+	f, err := os.Open(fileName)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return raywin.ReadDrawRecording(f)
+}
+
+func renderPNGs(frames []raywin.DrawFrame, dir string) error {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return err
+	}
+	for _, fr := range frames {
+		sp := raywin.NewSoftProxy()
+		cfg := raywin.DefaultDisplayConfig()
+		cfg.Width, cfg.Height = uint32(fr.Width), uint32(fr.Height)
+		sp.Init(cfg)
+		fr.Replay(sp)
+		fn := filepath.Join(dir, fmt.Sprintf("frame_%06d.png", fr.Number))
+		f, err := os.Create(fn)
+		if err != nil {
+			return err
+		}
+		err = png.Encode(f, sp.Image())
+		f.Close()
+		if err != nil {
+			return fmt.Errorf("could not write %s: %w", fn, err)
+		}
+	}
+	return nil
+}
+
+// OnNewFrame implements raywin.FrameListener, it selects the frame to be drawn
+func (pl *player) OnNewFrame(millis int64) {
+	if pl.start < 0 {
+		pl.start = millis
+	}
+	idx := int((millis - pl.start) * int64(pl.fps) / 1000)
+	if pl.loop {
+		idx %= len(pl.frames)
+	}
+	pl.idx = min(idx, len(pl.frames)-1)
+}
+
+// Draw implements raywin.Component
+func (pl *player) Draw(cc *raywin.CanvasContext) {
+	pl.frames[pl.idx].Replay(fontPainter{Painter: cc})
+}
+
+// DrawText implements raywin.Painter
+func (fp fontPainter) DrawText(_ rl.Font, text string, pos rl.Vector2, fontSize, spacing float32, color rl.Color) {
+	fp.Painter.DrawText(rl.GetFontDefault(), text, pos, fontSize, spacing, color)
+}
@@ -6,6 +6,7 @@ import (
 	"github.com/dspasibenko/raywin-go/raywin/components"
 	rl "github.com/gen2brain/raylib-go/raylib"
 	"os"
+	"regexp"
 	"syscall"
 )
 
@@ -19,21 +20,39 @@ func main() {
 	cfg.FrameListener = components.DefaultStyleOutlet(cfg.DisplayConfig)
 	raywin.Init(cfg)
 
-	eb, _ := components.NewEditBox(raywin.RootContainer())
-	eb.SetBounds(rl.RectangleInt32{X: 50, Y: 50, Width: 200, Height: 100})
+	lbl, _ := components.NewLabel(raywin.RootContainer(), "", components.DefaultLabelConfig().
+		Rectangle(rl.RectangleInt32{X: 500, Y: 50, Width: 400, Height: 70}))
 
-	components.NewButton(raywin.RootContainer(), rl.RectangleInt32{X: 50, Y: 200, Width: 60, Height: 60}, "H", components.DialogButtonStyle(), func() {
-		eb.SetText(eb.Text() + "H")
+	// the text field, type on the keyboard or use the buttons below
+	eb, _ := components.NewEditBox(raywin.RootContainer(), components.DefaultEditBoxConfig().
+		Rectangle(rl.RectangleInt32{X: 50, Y: 50, Width: 400, Height: 100}).
+		Text("Hello").
+		MaxLength(20).
+		OnSubmit(func(text string) { lbl.SetText(text) }))
+	raywin.SetFocus(eb)
+
+	// the numeric field with 3 digits max
+	components.NewEditBox(raywin.RootContainer(), components.DefaultEditBoxConfig().
+		Rectangle(rl.RectangleInt32{X: 50, Y: 150, Width: 200, Height: 100}).
+		Flags(components.EditBoxNumeric).
+		Mask(regexp.MustCompile(`^[0-9]{0,3}$`)))
+
+	// the password field
+	components.NewEditBox(raywin.RootContainer(), components.DefaultEditBoxConfig().
+		Rectangle(rl.RectangleInt32{X: 50, Y: 250, Width: 400, Height: 100}).
+		Flags(components.EditBoxPassword))
+
+	components.NewButton(raywin.RootContainer(), rl.RectangleInt32{X: 50, Y: 400, Width: 60, Height: 60}, "H", components.DialogButtonStyle(), func() {
+		eb.InsertText("H")
 	})
 
-	components.NewButton(raywin.RootContainer(), rl.RectangleInt32{X: 150, Y: 200, Width: 60, Height: 60}, "<-", components.DialogButtonStyle(), func() {
-		s := eb.Text()
-		if len(s) > 0 {
-			s = s[:len(s)-1]
-		}
-		eb.SetText(s)
+	components.NewButton(raywin.RootContainer(), rl.RectangleInt32{X: 150, Y: 400, Width: 60, Height: 60}, "<-", components.DialogButtonStyle(), func() {
+		eb.DeleteBackward()
 	})
 
+	// the on-screen keyboard shows up when an EditBox is focused. It is created last to be on top
+	components.NewVirtualKeyboard(raywin.RootContainer(), nil)
+
 	ctx := context.NewSignalsContext(os.Interrupt, syscall.SIGTERM) // allow to close the window by Ctrl+C in terminal
 	raywin.Run(ctx)
 }
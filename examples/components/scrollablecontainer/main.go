@@ -24,7 +24,9 @@ type myMovableWidget struct {
 
 // Draw just fills the whole drawing area by the component color
 func (mw *myMovableWidget) Draw(cc *raywin.CanvasContext) {
-	rl.DrawRectangle(0, 0, 1000, 1000, mw.col)
+	r := mw.Bounds()
+	x, y := cc.PhysicalPointXY(0, 0)
+	cc.DrawRectangle(rl.RectangleInt32{X: x, Y: y, Width: r.Width, Height: r.Height}, mw.col)
 }
 
 // OnTPState makes myMovableWidget implements Touchpadable interface. This is the component
@@ -51,8 +53,8 @@ func (ms *myScrollable) Draw(cc *raywin.CanvasContext) {
 	b := ms.Bounds()
 	off := ms.Offset()
 	x, y := cc.PhysicalPointXY(off.X, off.Y)
-	rl.DrawRectangle(x, y, b.Width, b.Height, rl.White)
-	rl.DrawText("Press mouse button and move it", x, y+200, 30, rl.Black)
+	cc.DrawRectangle(rl.RectangleInt32{X: x, Y: y, Width: b.Width, Height: b.Height}, rl.White)
+	cc.DrawText(rl.GetFontDefault(), "Press mouse button and move it", rl.Vector2{X: float32(x), Y: float32(y + 200)}, 30, 3, rl.Black)
 }
 
 func main() {
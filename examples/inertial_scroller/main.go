@@ -40,9 +40,11 @@ func (tp *topPanel) Init(owner raywin.Container, s raywin.Scrollable) error {
 
 func (tp *topPanel) Draw(cc *raywin.CanvasContext) {
 	r := tp.Bounds()
-	rl.DrawRectangle(0, 0, r.Width, r.Height, rl.White)
+	x, y := cc.PhysicalPointXY(0, 0)
+	cc.DrawRectangle(rl.RectangleInt32{X: x, Y: y, Width: r.Width, Height: r.Height}, rl.White)
 	p := tp.s.Offset()
-	rl.DrawText(fmt.Sprintf("Use mouse to move the grid below. Offset X=%d, Y=%d", p.X, p.Y), 15, 15, 20, rl.Color{255, 0, 0, 255})
+	cc.DrawText(rl.GetFontDefault(), fmt.Sprintf("Use mouse to move the grid below. Offset X=%d, Y=%d", p.X, p.Y),
+		rl.Vector2{X: float32(x + 15), Y: float32(y + 15)}, 20, 2, rl.Color{R: 255, A: 255})
 }
 
 func (ms *myScrollable) Init(owner raywin.Container) error {
@@ -61,7 +63,7 @@ func (ms *myScrollable) Init(owner raywin.Container) error {
 func (mw *myMovableWidget) Draw(cc *raywin.CanvasContext) {
 	r := mw.Bounds()
 	x, y := cc.PhysicalPointXY(0, 0)
-	rl.DrawRectangle(x, y, r.Width, r.Height, mw.col)
+	cc.DrawRectangle(rl.RectangleInt32{X: x, Y: y, Width: r.Width, Height: r.Height}, mw.col)
 }
 
 func main() {
@@ -0,0 +1,43 @@
+package main
+
+import (
+	"fmt"
+	"github.com/dspasibenko/raywin-go/pkg/golibs/context"
+	"github.com/dspasibenko/raywin-go/raywin"
+	"github.com/dspasibenko/raywin-go/raywin/components"
+	rl "github.com/gen2brain/raylib-go/raylib"
+	"os"
+	"syscall"
+)
+
+func main() {
+	cfg := raywin.DefaultConfig()
+	cfg.IconsDir = "resources/icons"
+	cfg.ResourceDir = "."
+	cfg.RegularFontFileName = "resources/fonts/Roboto/Roboto-Medium.ttf"
+	cfg.ItalicFontFileName = "resources/fonts/Roboto/Roboto-MediumItalic.ttf"
+	cfg.FrameListener = components.DefaultStyleOutlet(cfg.DisplayConfig)
+	raywin.Init(cfg)
+
+	// the screen is the column: the title, the grid of buttons, which takes all the
+	// free space, and the row of the dialog buttons. There are no magic numbers except
+	// the heights of the title and the dialog buttons row, so the screen fits any display
+	screen, _ := raywin.NewBoxLayout(raywin.RootContainer(), raywin.DefaultBoxConfig().Vertical(true).Spacing(10).
+		Padding(raywin.Insets{Left: 20, Top: 20, Right: 20, Bottom: 20}).Rectangle(raywin.RootContainer().(raywin.Component).Bounds()))
+
+	components.NewLabel(screen, "Layouts", components.DefaultLabelConfig().Rectangle(rl.RectangleInt32{Height: 40}))
+
+	grid, _ := raywin.NewGridLayout(screen, raywin.DefaultGridConfig().Columns(4).Spacing(10, 10))
+	screen.SetLayoutParams(grid, raywin.LayoutParams{Weight: 1})
+	for i := 0; i < 12; i++ {
+		components.NewButton(grid, rl.RectangleInt32{}, fmt.Sprintf("%d", i+1), components.DialogButtonStyle(), nil)
+	}
+
+	buttons, _ := raywin.NewBoxLayout(screen, raywin.DefaultBoxConfig().Spacing(20).Justify(raywin.LayoutAlignEnd).
+		Rectangle(rl.RectangleInt32{Height: 60}))
+	components.NewButton(buttons, rl.RectangleInt32{Width: 120}, "cancel", components.DialogButtonCancelStyle(), nil)
+	components.NewButton(buttons, rl.RectangleInt32{Width: 120}, "Ok", components.DialogButtonOkStyle(), nil)
+
+	ctx := context.NewSignalsContext(os.Interrupt, syscall.SIGTERM) // allow to close the window by Ctrl+C in terminal
+	raywin.Run(ctx)
+}
@@ -12,32 +12,36 @@ import (
 // by touchpad (or mouse). The Component is the Container, so it may contain other components
 type myMovableWidget struct {
 	raywin.BaseContainer
-	col  rl.Color
-	ppos raywin.TPState
+	// Gestures makes myMovableWidget implements Touchpadable interface. The component
+	// reaction on the touchpad (mouse) events is defined by the gesture recognizers
+	raywin.Gestures
+	col rl.Color
 }
 
-// Draw just fills the whole drawing area by the component color
-func (mw *myMovableWidget) Draw(cc *raywin.CanvasContext) {
-	rl.DrawRectangle(0, 0, 1000, 1000, mw.col)
-}
-
-// OnTPState makes myMovableWidget implements Touchpadable interface. This is the component
-// reaction on the touchpad (mouse) events
-func (mw *myMovableWidget) OnTPState(tps raywin.TPState) raywin.OnTPSResult {
-	if tps.State == raywin.TPStatePressed || tps.State == raywin.TPStateMoving {
-		// the touchpad is pressed or the point moving...
-		if tps.Sequence == mw.ppos.Sequence {
-			// the previous notification was the same State
+func newMyMovableWidget(owner raywin.Container, col rl.Color, bounds rl.RectangleInt32) *myMovableWidget {
+	mw := &myMovableWidget{col: col}
+	mw.Init(owner, mw)
+	mw.SetBounds(bounds)
+	mw.InitGestures(
+		// moves the box when it is dragged
+		raywin.NewDragRecognizer(5, nil, func(_, delta rl.Vector2) {
 			r := mw.Bounds()
-			r.X += int32(tps.Pos.X - mw.ppos.Pos.X)
-			r.Y += int32(tps.Pos.Y - mw.ppos.Pos.Y)
+			r.X += int32(delta.X)
+			r.Y += int32(delta.Y)
 			mw.SetBounds(r)
-		}
-		mw.ppos = tps
-		// keeps the container in the focus
-		return raywin.OnTPSResultLocked
-	}
-	return raywin.OnTPSResultNA
+		}, nil),
+		// the long press inverts the box color
+		raywin.NewLongPressRecognizer(5, 700, func(_ rl.Vector2) {
+			mw.col = rl.Color{R: 255 - mw.col.R, G: 255 - mw.col.G, B: 255 - mw.col.B, A: mw.col.A}
+		}))
+	return mw
+}
+
+// Draw just fills the whole drawing area by the component color
+func (mw *myMovableWidget) Draw(cc *raywin.CanvasContext) {
+	r := mw.Bounds()
+	x, y := cc.PhysicalPointXY(0, 0)
+	cc.DrawRectangle(rl.RectangleInt32{X: x, Y: y, Width: r.Width, Height: r.Height}, mw.col)
 }
 
 func main() {
@@ -45,19 +49,13 @@ func main() {
 	raywin.Init(cfg)
 
 	// the white box owned by the display
-	mw := &myMovableWidget{col: rl.White}
-	mw.Init(raywin.RootContainer(), mw)
-	mw.SetBounds(rl.RectangleInt32{X: 10, Y: 10, Width: 300, Height: 300})
+	mw := newMyMovableWidget(raywin.RootContainer(), rl.White, rl.RectangleInt32{X: 10, Y: 10, Width: 300, Height: 300})
 
 	// the blue box in the white one
-	mw2 := &myMovableWidget{col: rl.Blue}
-	mw2.Init(mw, mw2)
-	mw2.SetBounds(rl.RectangleInt32{X: 10, Y: 10, Width: 100, Height: 100})
+	newMyMovableWidget(mw, rl.Blue, rl.RectangleInt32{X: 10, Y: 10, Width: 100, Height: 100})
 
 	// the red box in the white one as well
-	mw3 := &myMovableWidget{col: rl.Red}
-	mw3.Init(mw, mw3)
-	mw3.SetBounds(rl.RectangleInt32{X: 50, Y: 50, Width: 100, Height: 100})
+	newMyMovableWidget(mw, rl.Red, rl.RectangleInt32{X: 50, Y: 50, Width: 100, Height: 100})
 
 	ctx := context.NewSignalsContext(os.Interrupt, syscall.SIGTERM) // allow to close the window by Ctrl+C in terminal
 	raywin.Run(ctx)
@@ -0,0 +1,408 @@
+package raywin
+
+// Copyright 2025 Dmitry Spasibenko
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+import (
+	"fmt"
+	"github.com/dspasibenko/raywin-go/pkg/golibs/errors"
+	rl "github.com/gen2brain/raylib-go/raylib"
+	"math"
+	"sync"
+	"sync/atomic"
+)
+
+type (
+	// Easing is the easing curve. It maps the normalized animation time t in [0..1] to
+	// the animation progress, which is 0 for t == 0 and 1 for t == 1.
+	Easing func(t float32) float32
+
+	// AnimationConfig allows to specify the animation timing settings
+	AnimationConfig struct {
+		duration    int64
+		delay       int64
+		easing      Easing
+		loops       int
+		autoReverse bool
+		onDone      func()
+	}
+
+	// Animation is an animation driven by the display frames timestamps (see FrameListener).
+	// An animation is either a tween, which calls its update function with the eased progress
+	// every frame, or a sequence of other animations played one after another.
+	//
+	// The animation is created by NewAnimation, NewSequence or one of the Tween functions, and
+	// it is played after Start() is called. Every animation may have the owner component, the
+	// animation is cancelled automatically when the owner is closed.
+	Animation struct {
+		owner    Component
+		cfg      AnimationConfig
+		update   func(k float32)
+		children []*Animation
+
+		startMillis int64
+		cycle       int64
+		cur         int
+		curOffs     int64
+		completed   bool
+		cancelled   atomic.Bool
+		running     atomic.Bool
+	}
+
+	// animator plays the started animations every frame
+	animator struct {
+		lock  sync.Mutex
+		anims []*Animation
+	}
+)
+
+// LoopForever is the number of loops for the animation, which is played until it is cancelled
+const LoopForever = -1
+
+// foreverLength is the length of an infinite animation within a sequence
+const foreverLength = math.MaxInt64 / 4
+
+// EaseLinear is the linear easing
+func EaseLinear(t float32) float32 {
+	return t
+}
+
+// EaseInQuad is the quadratic easing, which accelerates from zero velocity
+func EaseInQuad(t float32) float32 {
+	return t * t
+}
+
+// EaseOutQuad is the quadratic easing, which decelerates to zero velocity
+func EaseOutQuad(t float32) float32 {
+	return t * (2 - t)
+}
+
+// EaseInOutQuad is the quadratic easing, which accelerates until the half way, then decelerates
+func EaseInOutQuad(t float32) float32 {
+	if t < 0.5 {
+		return 2 * t * t
+	}
+	return -1 + (4-2*t)*t
+}
+
+// EaseOutCubic is the cubic easing, which decelerates to zero velocity
+func EaseOutCubic(t float32) float32 {
+	t--
+	return t*t*t + 1
+}
+
+// EaseInOutCubic is the cubic easing, which accelerates until the half way, then decelerates
+func EaseInOutCubic(t float32) float32 {
+	if t < 0.5 {
+		return 4 * t * t * t
+	}
+	t = 2*t - 2
+	return 1 + t*t*t/2
+}
+
+// EaseOutBack is the easing, which overshoots the target a bit and returns back
+func EaseOutBack(t float32) float32 {
+	const c1 = 1.70158
+	const c3 = c1 + 1
+	t--
+	return 1 + c3*t*t*t + c1*t*t
+}
+
+// DefaultAnimationConfig returns the config for the animation, which is played once for
+// 300ms with EaseInOutQuad easing
+func DefaultAnimationConfig() AnimationConfig {
+	return AnimationConfig{duration: 300, easing: EaseInOutQuad, loops: 1}
+}
+
+// Duration specifies the duration of one animation loop in milliseconds
+func (acfg AnimationConfig) Duration(millis int64) AnimationConfig {
+	acfg.duration = millis
+	return acfg
+}
+
+// Delay specifies the delay in milliseconds before the animation is started
+func (acfg AnimationConfig) Delay(millis int64) AnimationConfig {
+	acfg.delay = millis
+	return acfg
+}
+
+// Easing specifies the easing curve
+func (acfg AnimationConfig) Easing(easing Easing) AnimationConfig {
+	acfg.easing = easing
+	return acfg
+}
+
+// Loops specifies how many times the animation is played (LoopForever for the infinite animation)
+func (acfg AnimationConfig) Loops(loops int) AnimationConfig {
+	acfg.loops = loops
+	return acfg
+}
+
+// AutoReverse makes every even loop be played backward
+func (acfg AnimationConfig) AutoReverse(autoReverse bool) AnimationConfig {
+	acfg.autoReverse = autoReverse
+	return acfg
+}
+
+// OnDone specifies the function called when the animation is completed. The function is
+// not called if the animation is cancelled.
+func (acfg AnimationConfig) OnDone(onDone func()) AnimationConfig {
+	acfg.onDone = onDone
+	return acfg
+}
+
+// NewAnimation creates the new tween animation. The update function is called every
+// frame with the eased progress k. The owner may be nil.
+func NewAnimation(owner Component, cfg AnimationConfig, update func(k float32)) *Animation {
+	if cfg.easing == nil {
+		cfg.easing = EaseLinear
+	}
+	return &Animation{owner: owner, cfg: cfg, update: update}
+}
+
+// NewSequence creates the animation, which plays the anims one after another. The anims
+// must not be started. The cfg duration, easing and auto-reverse are ignored for the sequence.
+func NewSequence(owner Component, cfg AnimationConfig, anims ...*Animation) *Animation {
+	return &Animation{owner: owner, cfg: cfg, children: anims}
+}
+
+// TweenFloat creates the animation, which changes a float property from `from` to `to`
+func TweenFloat(owner Component, cfg AnimationConfig, from, to float32, set func(v float32)) *Animation {
+	return NewAnimation(owner, cfg, func(k float32) {
+		set(from + (to-from)*k)
+	})
+}
+
+// TweenColor creates the animation, which changes a color property from `from` to `to`
+func TweenColor(owner Component, cfg AnimationConfig, from, to rl.Color, set func(col rl.Color)) *Animation {
+	return NewAnimation(owner, cfg, func(k float32) {
+		set(LerpColor(from, to, k))
+	})
+}
+
+// TweenAlpha creates the animation, which changes the alpha property from `from` to `to`,
+// the alpha is in [0..1]
+func TweenAlpha(owner Component, cfg AnimationConfig, from, to float32, set func(alpha float32)) *Animation {
+	return NewAnimation(owner, cfg, func(k float32) {
+		set(min(max(from+(to-from)*k, 0), 1))
+	})
+}
+
+// TweenBounds creates the animation, which moves the component c from its current bounds
+// (when the animation is created) to the `to` bounds. The component is the animation owner.
+func TweenBounds(c Component, cfg AnimationConfig, to rl.RectangleInt32) *Animation {
+	from := c.Bounds()
+	return NewAnimation(c, cfg, func(k float32) {
+		c.SetBounds(LerpRectangle(from, to, k))
+	})
+}
+
+// LerpColor returns the color between c1 and c2 for k in [0..1]
+func LerpColor(c1, c2 rl.Color, k float32) rl.Color {
+	return rl.Color{R: lerpUint8(c1.R, c2.R, k), G: lerpUint8(c1.G, c2.G, k), B: lerpUint8(c1.B, c2.B, k), A: lerpUint8(c1.A, c2.A, k)}
+}
+
+// LerpRectangle returns the rectangle between r1 and r2 for k in [0..1]
+func LerpRectangle(r1, r2 rl.RectangleInt32, k float32) rl.RectangleInt32 {
+	return rl.RectangleInt32{X: lerpInt32(r1.X, r2.X, k), Y: lerpInt32(r1.Y, r2.Y, k),
+		Width: lerpInt32(r1.Width, r2.Width, k), Height: lerpInt32(r1.Height, r2.Height, k)}
+}
+
+// Start starts the animation in the App of the animation owner (see AppOf), or in the
+// default App if the owner is nil. It returns an error if the App is not initialized or
+// the animation is already started.
+func (a *Animation) Start() error {
+	app := AppOf(a.owner)
+	if app.disp == nil {
+		return fmt.Errorf("raywin is not initialized, Init() must be called first: %w", errors.ErrInvalid)
+	}
+	return app.disp.anim.start(a)
+}
+
+// Cancel stops the animation. The animation properties are not changed anymore, and
+// the OnDone function is not called.
+func (a *Animation) Cancel() {
+	a.cancelled.Store(true)
+}
+
+// IsRunning returns whether the animation is started and is not completed or cancelled yet
+func (a *Animation) IsRunning() bool {
+	return a.running.Load() && !a.cancelled.Load()
+}
+
+// length returns the whole animation length in millis, including the delay and all the loops
+func (a *Animation) length() int64 {
+	if a.cfg.loops <= 0 {
+		return foreverLength
+	}
+	return a.cfg.delay + a.cycleLength()*int64(a.cfg.loops)
+}
+
+func (a *Animation) cycleLength() int64 {
+	if a.children == nil {
+		return max(a.cfg.duration, 0)
+	}
+	var res int64
+	for _, ch := range a.children {
+		res = min(res+ch.length(), foreverLength)
+	}
+	return res
+}
+
+// apply sets the animation state for the elapsed time since the animation start. It
+// returns true, if the animation is completed
+func (a *Animation) apply(elapsed int64) bool {
+	elapsed -= a.cfg.delay
+	if elapsed < 0 {
+		return false
+	}
+	ln := a.cycleLength()
+	if ln == 0 {
+		a.applyCycle(0, 0)
+		return true
+	}
+	cycle := elapsed / ln
+	if a.cfg.loops > 0 && cycle >= int64(a.cfg.loops) {
+		a.applyCycle(int64(a.cfg.loops-1), ln)
+		return true
+	}
+	a.applyCycle(cycle, elapsed%ln)
+	return false
+}
+
+// applyCycle sets the animation state for the elapsed time e since the cycle start
+func (a *Animation) applyCycle(cycle, e int64) {
+	if a.children == nil {
+		t := float32(1)
+		if a.cfg.duration > 0 {
+			t = float32(e) / float32(a.cfg.duration)
+		}
+		if a.cfg.autoReverse && cycle%2 == 1 {
+			t = 1 - t
+		}
+		if a.update != nil {
+			a.update(a.cfg.easing(t))
+		}
+		return
+	}
+	if cycle != a.cycle {
+		// the new loop of the sequence, complete the previous one first
+		if a.cycle >= 0 {
+			for ; a.cur < len(a.children); a.cur++ {
+				ch := a.children[a.cur]
+				ch.apply(ch.length())
+				ch.complete()
+			}
+		}
+		a.cycle = cycle
+		a.cur = 0
+		a.curOffs = 0
+		for _, ch := range a.children {
+			ch.reset()
+		}
+	}
+	for a.cur < len(a.children) {
+		ch := a.children[a.cur]
+		ln := ch.length()
+		if e-a.curOffs < ln {
+			ch.apply(e - a.curOffs)
+			return
+		}
+		ch.apply(ln)
+		ch.complete()
+		a.curOffs += ln
+		a.cur++
+	}
+}
+
+// reset drops the animation play state, so it can be played from the beginning
+func (a *Animation) reset() {
+	a.cycle = -1
+	a.cur = 0
+	a.curOffs = 0
+	a.completed = false
+	for _, ch := range a.children {
+		ch.reset()
+	}
+}
+
+func (a *Animation) complete() {
+	if !a.completed {
+		a.completed = true
+		if a.cfg.onDone != nil {
+			a.cfg.onDone()
+		}
+	}
+}
+
+func (a *Animation) isDead() bool {
+	return a.cancelled.Load() || (a.owner != nil && a.owner.baseComponent().isClosed())
+}
+
+func (an *animator) start(a *Animation) error {
+	if a.running.Swap(true) {
+		return fmt.Errorf("the animation is already started: %w", errors.ErrInvalid)
+	}
+	a.startMillis = -1
+	a.reset()
+	an.lock.Lock()
+	defer an.lock.Unlock()
+	an.anims = append(an.anims, a)
+	return nil
+}
+
+// isBusy returns whether there are animations to be played
+func (an *animator) isBusy() bool {
+	an.lock.Lock()
+	defer an.lock.Unlock()
+	return len(an.anims) > 0
+}
+
+// onNewFrame plays the animations for the frame millis
+func (an *animator) onNewFrame(millis int64) {
+	an.lock.Lock()
+	anims := an.anims
+	an.anims = nil
+	an.lock.Unlock()
+
+	alive := anims[:0]
+	for _, a := range anims {
+		if a.isDead() {
+			a.running.Store(false)
+			continue
+		}
+		if a.startMillis < 0 {
+			a.startMillis = millis
+		}
+		if a.apply(millis - a.startMillis) {
+			a.running.Store(false)
+			a.complete()
+			continue
+		}
+		alive = append(alive, a)
+	}
+
+	// the animations could be started while the frame is played
+	an.lock.Lock()
+	an.anims = append(alive, an.anims...)
+	an.lock.Unlock()
+}
+
+func lerpUint8(v1, v2 uint8, k float32) uint8 {
+	return uint8(min(max(math.Round(float64(float32(v1)+(float32(v2)-float32(v1))*k)), 0), 255))
+}
+
+func lerpInt32(v1, v2 int32, k float32) int32 {
+	return int32(math.Round(float64(float32(v1) + float32(v2-v1)*k)))
+}
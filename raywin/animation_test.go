@@ -0,0 +1,136 @@
+package raywin
+
+// Copyright 2025 Dmitry Spasibenko
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+import (
+	"github.com/dspasibenko/raywin-go/pkg/golibs/errors"
+	rl "github.com/gen2brain/raylib-go/raylib"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestEasing(t *testing.T) {
+	for _, e := range []Easing{EaseLinear, EaseInQuad, EaseOutQuad, EaseInOutQuad, EaseOutCubic, EaseInOutCubic, EaseOutBack} {
+		assert.InDelta(t, 0.0, e(0), 0.0001)
+		assert.InDelta(t, 1.0, e(1), 0.0001)
+	}
+	assert.InDelta(t, 0.5, EaseInOutCubic(0.5), 0.0001)
+}
+
+func TestAnimation_Tween(t *testing.T) {
+	var an animator
+	var v []float32
+	done := 0
+	a := TweenFloat(nil, DefaultAnimationConfig().Duration(100).Delay(50).Easing(EaseLinear).OnDone(func() { done++ }),
+		10, 20, func(f float32) { v = append(v, f) })
+	assert.Nil(t, an.start(a))
+	assert.ErrorIs(t, an.start(a), errors.ErrInvalid)
+	assert.True(t, a.IsRunning())
+	an.onNewFrame(1000)
+	an.onNewFrame(1040)
+	assert.Nil(t, v)
+	an.onNewFrame(1050)
+	an.onNewFrame(1100)
+	an.onNewFrame(1200)
+	assert.Equal(t, []float32{10, 15, 20}, v)
+	assert.Equal(t, 1, done)
+	assert.False(t, a.IsRunning())
+	an.onNewFrame(1300)
+	assert.Equal(t, 3, len(v))
+	assert.Equal(t, 0, len(an.anims))
+
+	// restart
+	v = nil
+	assert.Nil(t, an.start(a))
+	an.onNewFrame(2000)
+	an.onNewFrame(2100)
+	assert.Equal(t, []float32{15}, v)
+}
+
+func TestAnimation_LoopsAndReverse(t *testing.T) {
+	var an animator
+	var v []float32
+	a := NewAnimation(nil, DefaultAnimationConfig().Duration(100).Easing(EaseLinear).Loops(2).AutoReverse(true),
+		func(k float32) { v = append(v, k) })
+	assert.Nil(t, an.start(a))
+	for _, m := range []int64{0, 50, 100, 150, 250} {
+		an.onNewFrame(m)
+	}
+	assert.Equal(t, []float32{0, 0.5, 1, 0.5, 0}, v)
+	assert.False(t, a.IsRunning())
+
+	v = nil
+	a = NewAnimation(nil, DefaultAnimationConfig().Duration(100).Easing(EaseLinear).Loops(LoopForever),
+		func(k float32) { v = append(v, k) })
+	assert.Nil(t, an.start(a))
+	for _, m := range []int64{0, 50, 1050, 100000} {
+		an.onNewFrame(m)
+	}
+	assert.Equal(t, []float32{0, 0.5, 0.5, 0}, v)
+	assert.True(t, a.IsRunning())
+	a.Cancel()
+	an.onNewFrame(100010)
+	assert.Equal(t, 4, len(v))
+	assert.False(t, a.IsRunning())
+	assert.Equal(t, 0, len(an.anims))
+}
+
+func TestAnimation_Sequence(t *testing.T) {
+	var an animator
+	var events []string
+	cfg := DefaultAnimationConfig().Duration(100).Easing(EaseLinear)
+	var x, y float32
+	s := NewSequence(nil, DefaultAnimationConfig().Loops(2).OnDone(func() { events = append(events, "seq") }),
+		TweenFloat(nil, cfg.OnDone(func() { events = append(events, "x") }), 0, 10, func(v float32) { x = v }),
+		TweenFloat(nil, cfg.Delay(50).OnDone(func() { events = append(events, "y") }), 0, 10, func(v float32) { y = v }))
+	assert.Nil(t, an.start(s))
+	an.onNewFrame(0)
+	an.onNewFrame(50)
+	assert.Equal(t, []float32{5, 0}, []float32{x, y})
+	an.onNewFrame(175)
+	assert.Equal(t, []float32{10, 2.5}, []float32{x, y})
+	assert.Equal(t, []string{"x"}, events)
+	// the second loop
+	an.onNewFrame(300)
+	assert.Equal(t, []float32{5, 10}, []float32{x, y})
+	assert.Equal(t, []string{"x", "y"}, events)
+	an.onNewFrame(1000)
+	assert.Equal(t, []float32{10, 10}, []float32{x, y})
+	assert.Equal(t, []string{"x", "y", "x", "y", "seq"}, events)
+	assert.False(t, s.IsRunning())
+}
+
+func TestAnimation_OwnerClosed(t *testing.T) {
+	d := newDisplay(DefaultDisplayConfig(), &testProxy{})
+	c := newTestChild(t, &d.root, 10, 10)
+	done := false
+	a := TweenBounds(c, DefaultAnimationConfig().Duration(100).Easing(EaseLinear).OnDone(func() { done = true }),
+		rl.RectangleInt32{X: 100, Y: 50, Width: 20, Height: 10})
+	assert.Nil(t, d.anim.start(a))
+	d.formFrame(0)
+	d.formFrame(50)
+	assert.Equal(t, rl.RectangleInt32{X: 50, Y: 25, Width: 15, Height: 10}, c.Bounds())
+	c.Close()
+	d.formFrame(100)
+	assert.Equal(t, rl.RectangleInt32{X: 50, Y: 25, Width: 15, Height: 10}, c.Bounds())
+	assert.False(t, a.IsRunning())
+	assert.False(t, done)
+}
+
+func TestLerp(t *testing.T) {
+	assert.Equal(t, rl.Color{R: 50, G: 100, B: 100, A: 255}, LerpColor(rl.Color{A: 255}, rl.Color{R: 100, G: 200, B: 200, A: 255}, 0.5))
+	assert.Equal(t, rl.RectangleInt32{X: -5, Y: 5, Width: 10, Height: 0}, LerpRectangle(rl.RectangleInt32{X: -10, Width: 10},
+		rl.RectangleInt32{Y: 10, Width: 10}, 0.5))
+}
@@ -0,0 +1,180 @@
+package raywin
+
+// Copyright 2025 Dmitry Spasibenko
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/dspasibenko/raywin-go/pkg/golibs/errors"
+	rl "github.com/gen2brain/raylib-go/raylib"
+	"math"
+	"os"
+)
+
+// TouchCalibration is the affine transformation of the raw touch points to the display
+// ones. Both are in the physical display coordinates (before the Rotation is applied):
+//
+//	x' = A*x + B*y + C
+//	y' = D*x + E*y + F
+//
+// The calibration compensates the offset, the scale and the skew of the resistive touch
+// panels. It may be computed by ComputeCalibration from the points the user tapped (see
+// components.CalibrationScreen) and stored by SaveCalibration.
+type TouchCalibration struct {
+	A, B, C float32
+	D, E, F float32
+}
+
+// IdentityCalibration returns the calibration, which doesn't change the points
+func IdentityCalibration() TouchCalibration {
+	return TouchCalibration{A: 1, E: 1}
+}
+
+// Apply returns the point p transformed by the calibration
+func (tc TouchCalibration) Apply(p rl.Vector2) rl.Vector2 {
+	return rl.Vector2{X: tc.A*p.X + tc.B*p.Y + tc.C, Y: tc.D*p.X + tc.E*p.Y + tc.F}
+}
+
+// ComputeCalibration returns the calibration, which transforms the raw points to the
+// target ones with the least squares error. The raw[i] is the point reported by the
+// touch panel, when the target[i] is tapped. At least 3 points, which are not on one
+// line, are needed, otherwise errors.ErrInvalid is returned.
+func ComputeCalibration(raw, target []rl.Vector2) (TouchCalibration, error) {
+	if len(raw) != len(target) {
+		return TouchCalibration{}, fmt.Errorf("ComputeCalibration: %d raw points, but %d target ones: %w", len(raw), len(target), errors.ErrInvalid)
+	}
+	if len(raw) < 3 {
+		return TouchCalibration{}, fmt.Errorf("ComputeCalibration: at least 3 points are needed, but %d provided: %w", len(raw), errors.ErrInvalid)
+	}
+	// the normal equations M*[a b c] = v, where M is the same for x' and y'
+	var m [3][3]float64
+	var vx, vy [3]float64
+	for i, r := range raw {
+		row := [3]float64{float64(r.X), float64(r.Y), 1}
+		for j := 0; j < 3; j++ {
+			for k := 0; k < 3; k++ {
+				m[j][k] += row[j] * row[k]
+			}
+			vx[j] += row[j] * float64(target[i].X)
+			vy[j] += row[j] * float64(target[i].Y)
+		}
+	}
+	det := det3(m)
+	// the determinant is relative to the points spread, so compare it with the scale of M
+	if math.Abs(det) <= 1e-9*math.Max(1, math.Abs(m[0][0]*m[1][1]*m[2][2])) {
+		return TouchCalibration{}, fmt.Errorf("ComputeCalibration: the raw points are on one line: %w", errors.ErrInvalid)
+	}
+	abc := solve3(m, vx, det)
+	def := solve3(m, vy, det)
+	return TouchCalibration{
+		A: float32(abc[0]), B: float32(abc[1]), C: float32(abc[2]),
+		D: float32(def[0]), E: float32(def[1]), F: float32(def[2]),
+	}, nil
+}
+
+// SaveCalibration writes the calibration tc to the file fileName in JSON
+func SaveCalibration(fileName string, tc TouchCalibration) error {
+	b, err := json.MarshalIndent(tc, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(fileName, b, 0644); err != nil {
+		return fmt.Errorf("SaveCalibration: could not write the file %s: %w", fileName, err)
+	}
+	return nil
+}
+
+// LoadCalibration reads the calibration from the file fileName written by SaveCalibration
+func LoadCalibration(fileName string) (TouchCalibration, error) {
+	b, err := os.ReadFile(fileName)
+	if err != nil {
+		return TouchCalibration{}, fmt.Errorf("LoadCalibration: could not read the file %s: %w", fileName, err)
+	}
+	var tc TouchCalibration
+	if err := json.Unmarshal(b, &tc); err != nil {
+		return TouchCalibration{}, fmt.Errorf("LoadCalibration: the file %s is not a calibration: %s: %w", fileName, err, errors.ErrInvalid)
+	}
+	return tc, nil
+}
+
+// SetTouchCalibration replaces the touch calibration of the default App (see App.SetTouchCalibration)
+func SetTouchCalibration(tc *TouchCalibration) {
+	c.SetTouchCalibration(tc)
+}
+
+// SetTouchCalibration makes tc to be applied to the touch points read since the next
+// frame. nil disables the calibration, the raw points are used then. The function
+// should be called from the raywin callbacks (OnTPState, OnNewFrame etc.) or before Run()
+func (c *App) SetTouchCalibration(tc *TouchCalibration) {
+	if tc != nil {
+		cp := *tc
+		tc = &cp
+	}
+	c.disp.tp.cfg.Calibration = tc
+}
+
+// TouchCalibration returns the calibration applied to the touch points, it is nil if
+// the calibration is disabled
+func (c *App) TouchCalibration() *TouchCalibration {
+	tc := c.disp.tp.cfg.Calibration
+	if tc == nil {
+		return nil
+	}
+	cp := *tc
+	return &cp
+}
+
+// toDisplay transforms the raw point p read from the touch panel to the logical display
+// coordinates: the calibration is applied first, and the Rotation then
+func (dc DisplayConfig) toDisplay(p rl.Vector2) rl.Vector2 {
+	if dc.Calibration != nil {
+		p = dc.Calibration.Apply(p)
+	}
+	return dc.toLogical(p)
+}
+
+// ToPhysical transforms the point p in the logical display coordinates to the physical
+// ones, it is the inverse of the Rotation (see LogicalSize)
+func (dc DisplayConfig) ToPhysical(p rl.Vector2) rl.Vector2 {
+	w, h := float32(dc.Width), float32(dc.Height)
+	switch dc.Rotation {
+	case Rotation90:
+		return rl.Vector2{X: w - p.Y, Y: p.X}
+	case Rotation180:
+		return rl.Vector2{X: w - p.X, Y: h - p.Y}
+	case Rotation270:
+		return rl.Vector2{X: p.Y, Y: h - p.X}
+	}
+	return p
+}
+
+func det3(m [3][3]float64) float64 {
+	return m[0][0]*(m[1][1]*m[2][2]-m[1][2]*m[2][1]) -
+		m[0][1]*(m[1][0]*m[2][2]-m[1][2]*m[2][0]) +
+		m[0][2]*(m[1][0]*m[2][1]-m[1][1]*m[2][0])
+}
+
+// solve3 solves m*x = v by the Cramer's rule, det is the m determinant
+func solve3(m [3][3]float64, v [3]float64, det float64) [3]float64 {
+	var res [3]float64
+	for i := 0; i < 3; i++ {
+		mi := m
+		for j := 0; j < 3; j++ {
+			mi[j][i] = v[j]
+		}
+		res[i] = det3(mi) / det
+	}
+	return res
+}
@@ -0,0 +1,116 @@
+package raywin
+
+// Copyright 2025 Dmitry Spasibenko
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+import (
+	"github.com/dspasibenko/raywin-go/pkg/golibs/errors"
+	rl "github.com/gen2brain/raylib-go/raylib"
+	"github.com/stretchr/testify/assert"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestComputeCalibration(t *testing.T) {
+	exp := TouchCalibration{A: 1.1, B: 0.05, C: -12, D: -0.02, E: 0.9, F: 7}
+	target := []rl.Vector2{{X: 100, Y: 60}, {X: 900, Y: 60}, {X: 900, Y: 540}, {X: 100, Y: 540}, {X: 500, Y: 300}}
+	raw := make([]rl.Vector2, len(target))
+	for i, p := range target {
+		raw[i] = invertCalibration(t, exp).Apply(p)
+	}
+	tc, err := ComputeCalibration(raw, target)
+	assert.Nil(t, err)
+	for i, p := range raw {
+		assert.InDelta(t, target[i].X, tc.Apply(p).X, 0.01)
+		assert.InDelta(t, target[i].Y, tc.Apply(p).Y, 0.01)
+	}
+	assert.InDelta(t, exp.A, tc.A, 1e-4)
+	assert.InDelta(t, exp.F, tc.F, 1e-2)
+
+	_, err = ComputeCalibration(raw[:2], target[:2])
+	assert.ErrorIs(t, err, errors.ErrInvalid)
+	_, err = ComputeCalibration(raw, target[:3])
+	assert.ErrorIs(t, err, errors.ErrInvalid)
+	line := []rl.Vector2{{X: 1, Y: 1}, {X: 2, Y: 2}, {X: 3, Y: 3}, {X: 4, Y: 4}}
+	_, err = ComputeCalibration(line, line)
+	assert.ErrorIs(t, err, errors.ErrInvalid)
+}
+
+func TestSaveLoadCalibration(t *testing.T) {
+	fn := filepath.Join(t.TempDir(), "calibration.json")
+	_, err := LoadCalibration(fn)
+	assert.ErrorIs(t, err, errors.ErrNotExist)
+
+	tc := TouchCalibration{A: 1.5, B: 0.25, C: -3, D: 0.125, E: 0.75, F: 10}
+	assert.Nil(t, SaveCalibration(fn, tc))
+	tc1, err := LoadCalibration(fn)
+	assert.Nil(t, err)
+	assert.Equal(t, tc, tc1)
+
+	assert.Nil(t, os.WriteFile(fn, []byte("not a json"), 0644))
+	_, err = LoadCalibration(fn)
+	assert.ErrorIs(t, err, errors.ErrInvalid)
+}
+
+func TestDisplayConfig_ToPhysical(t *testing.T) {
+	dc := DisplayConfig{Width: 40, Height: 20}
+	p := rl.Vector2{X: 3, Y: 7}
+	for _, r := range []Rotation{Rotation0, Rotation90, Rotation180, Rotation270} {
+		dc.Rotation = r
+		assert.Equal(t, p, dc.toLogical(dc.ToPhysical(p)), "rotation %d", r)
+		assert.Equal(t, p, dc.ToPhysical(dc.toLogical(p)), "rotation %d", r)
+	}
+}
+
+func TestApp_touchCalibration(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.DisplayConfig.Width, cfg.DisplayConfig.Height = 40, 20
+	cfg.DisplayConfig.Rotation = Rotation90
+	cfg.DisplayConfig.MinTouchTarget = 0
+	// the panel reports the points shifted by 10 pixels right
+	cfg.DisplayConfig.Calibration = &TouchCalibration{A: 1, C: -10, E: 1}
+	tp := &testProxy{}
+	cfg.Proxy = tp
+	app, err := NewApp(cfg)
+	assert.Nil(t, err)
+	rc := &_rotation_test_comp{}
+	assert.Nil(t, rc.Init(app.RootContainer(), rc))
+	rc.SetBounds(rl.RectangleInt32{Y: 20, Width: 20, Height: 20})
+
+	// the raw physical (20, 5) is the calibrated (10, 5), which is the logical (5, 30)
+	tp.touches = []testTouch{{id: 1, pos: rl.Vector2{X: 20, Y: 5}}}
+	assert.Nil(t, app.RunFrame(10))
+	assert.Equal(t, 1, len(rc.tps))
+	assert.Equal(t, rl.Vector2{X: 5, Y: 30}, rc.tps[0].Pos)
+	assert.Equal(t, cfg.DisplayConfig.Calibration, app.TouchCalibration())
+
+	tp.touches = nil
+	assert.Nil(t, app.RunFrame(20))
+	app.SetTouchCalibration(nil)
+	assert.Nil(t, app.TouchCalibration())
+	rc.tps = nil
+	// the raw (30, 5) is the logical (5, 10) now, which is out of the component
+	tp.touches = []testTouch{{id: 2, pos: rl.Vector2{X: 30, Y: 5}}}
+	assert.Nil(t, app.RunFrame(30))
+	assert.Nil(t, rc.tps)
+}
+
+// invertCalibration returns the calibration, which is the inverse of tc
+func invertCalibration(t *testing.T, tc TouchCalibration) TouchCalibration {
+	det := tc.A*tc.E - tc.B*tc.D
+	assert.NotZero(t, det)
+	a, b, d, e := tc.E/det, -tc.B/det, -tc.D/det, tc.A/det
+	return TouchCalibration{A: a, B: b, C: -(a*tc.C + b*tc.F), D: d, E: e, F: -(d*tc.C + e*tc.F)}
+}
@@ -21,11 +21,15 @@ import rl "github.com/gen2brain/raylib-go/raylib"
 // a Component pixel in the physical display coordinates)
 type CanvasContext struct {
 	stack []ctxStackElem
+	proxy RlProxy
 }
 
 type ctxStackElem struct {
 	p Vector2Int32
 	r rl.RectangleInt32
+	// transparency is 1 - the opacity the region is drawn with, so the zero value is the
+	// opaque region. It is inherited by the nested regions
+	transparency float32
 }
 
 // PhysicalPointXY returns the coordinates for a Component's point (x,y) on the
@@ -78,7 +82,26 @@ func (cc *CanvasContext) pushRelativeRegion(vp Vector2Int32, r rl.RectangleInt32
 	}
 	r.Width = max(0, min(r.Width, cse.r.Width-(r.X-cse.r.X)))
 	r.Height = max(0, min(r.Height, cse.r.Height-(r.Y-cse.r.Y)))
-	cc.stack = append(cc.stack, ctxStackElem{p: vp, r: r})
+	cc.stack = append(cc.stack, ctxStackElem{p: vp, r: r, transparency: cse.transparency})
+}
+
+// Alpha returns the opacity in [0..1] the current region is drawn with (see ChildrenFader).
+// The drawing functions apply it to the colors, so the components don't need to consider it
+func (cc *CanvasContext) Alpha() float32 {
+	return 1.0 - cc.stack[len(cc.stack)-1].transparency
+}
+
+// fadeTop multiplies the opacity of the top region by alpha
+func (cc *CanvasContext) fadeTop(alpha float32) {
+	cc.stack[len(cc.stack)-1].transparency = 1.0 - cc.Alpha()*max(0.0, min(1.0, alpha))
+}
+
+// fade returns the color col with the current opacity applied
+func (cc *CanvasContext) fade(col rl.Color) rl.Color {
+	if a := cc.Alpha(); a < 1.0 {
+		col.A = uint8(float32(col.A) * a)
+	}
+	return col
 }
 
 func (cc *CanvasContext) pop() {
@@ -94,6 +117,79 @@ func (cc *CanvasContext) relativePointXY(px, py int32) (int32, int32) {
 	return px - cse.r.X + cse.p.X, py - cse.r.Y + cse.p.Y
 }
 
+// The drawing functions below take the physical display coordinates (see PhysicalPointXY),
+// as the raylib functions do. The components should draw via the functions instead of
+// calling raylib directly, so the drawing goes through the display RlProxy, and the
+// opacity of the region (see Alpha) is applied.
+
+// DrawRectangle fills the rectangle r by the color col
+func (cc *CanvasContext) DrawRectangle(r rl.RectangleInt32, col rl.Color) {
+	cc.proxy.DrawRectangle(r, cc.fade(col))
+}
+
+// DrawRectangleRec fills the rectangle r by the color col
+func (cc *CanvasContext) DrawRectangleRec(r rl.Rectangle, col rl.Color) {
+	cc.proxy.DrawRectangleRec(r, cc.fade(col))
+}
+
+// DrawRectangleLines draws the rectangle r outline of the lineThick width inside r
+func (cc *CanvasContext) DrawRectangleLines(r rl.Rectangle, lineThick float32, col rl.Color) {
+	cc.proxy.DrawRectangleLines(r, lineThick, cc.fade(col))
+}
+
+// DrawRectangleRounded fills the rectangle r with rounded corners. The roundness is in [0..1],
+// it is the corner radius relative to the half of the shortest rectangle side
+func (cc *CanvasContext) DrawRectangleRounded(r rl.Rectangle, roundness float32, segments int32, col rl.Color) {
+	cc.proxy.DrawRectangleRounded(r, roundness, segments, cc.fade(col))
+}
+
+// DrawRectangleRoundedLines draws the outline of the rectangle r with rounded corners
+func (cc *CanvasContext) DrawRectangleRoundedLines(r rl.Rectangle, roundness float32, segments int32, lineThick float32, col rl.Color) {
+	cc.proxy.DrawRectangleRoundedLines(r, roundness, segments, lineThick, cc.fade(col))
+}
+
+// DrawCircle fills the circle
+func (cc *CanvasContext) DrawCircle(center rl.Vector2, radius float32, col rl.Color) {
+	cc.proxy.DrawCircle(center, radius, cc.fade(col))
+}
+
+// DrawCircleLines draws the circle outline
+func (cc *CanvasContext) DrawCircleLines(center rl.Vector2, radius float32, col rl.Color) {
+	cc.proxy.DrawCircleLines(center, radius, cc.fade(col))
+}
+
+// DrawCircleSector fills the circle sector between the angles (in degrees)
+func (cc *CanvasContext) DrawCircleSector(center rl.Vector2, radius, startAngle, endAngle float32, segments int32, col rl.Color) {
+	cc.proxy.DrawCircleSector(center, radius, startAngle, endAngle, segments, cc.fade(col))
+}
+
+// DrawText draws the text at the position pos (top left corner)
+func (cc *CanvasContext) DrawText(font rl.Font, text string, pos rl.Vector2, fontSize, spacing float32, col rl.Color) {
+	cc.proxy.DrawText(font, text, pos, fontSize, spacing, cc.fade(col))
+}
+
+// MeasureText returns the size of the text drawn by DrawText with the same parameters
+func (cc *CanvasContext) MeasureText(font rl.Font, text string, fontSize, spacing float32) rl.Vector2 {
+	return cc.proxy.MeasureText(font, text, fontSize, spacing)
+}
+
+// DrawTexture draws the texture at the position pos, the texture colors are multiplied by tint
+func (cc *CanvasContext) DrawTexture(texture rl.Texture2D, pos Vector2Int32, tint rl.Color) {
+	cc.proxy.DrawTexture(texture, pos, cc.fade(tint))
+}
+
+// BeginScissorMode limits the drawing area by the region r. Normally the drawing area is the
+// component PhysicalRegion(), but a component may need to draw outside of it (e.g. a pressed
+// button may be drawn bigger). EndScissorMode() must be called, when the drawing is done.
+func (cc *CanvasContext) BeginScissorMode(r rl.RectangleInt32) {
+	cc.proxy.BeginScissorMode(r)
+}
+
+// EndScissorMode restores the drawing area to the component PhysicalRegion()
+func (cc *CanvasContext) EndScissorMode() {
+	cc.proxy.BeginScissorMode(cc.PhysicalRegion())
+}
+
 func (cc *CanvasContext) isEmpty() bool {
 	return len(cc.stack) == 1
 }
@@ -148,3 +148,38 @@ func TestCanvasContext_PhysicalRegion(t *testing.T) {
 	cc.pushRelativeRegion(Vector2Int32{}, rl.RectangleInt32{X: -5, Y: -5, Width: 50, Height: 50})
 	assert.Equal(t, rl.RectangleInt32{X: 15, Y: 15, Width: 45, Height: 45}, cc.PhysicalRegion())
 }
+
+func TestCanvasContext_Scissor(t *testing.T) {
+	sp := newTestSoftProxy(20, 20)
+	cc := newCanvas(20, 20)
+	cc.proxy = sp
+	cc.pushRelativeRegion(Vector2Int32{}, rl.RectangleInt32{X: 5, Y: 5, Width: 5, Height: 5})
+	cc.BeginScissorMode(rl.RectangleInt32{X: 0, Y: 0, Width: 20, Height: 20})
+	cc.DrawRectangle(rl.RectangleInt32{X: 0, Y: 0, Width: 2, Height: 2}, rl.Red)
+	cc.EndScissorMode()
+	cc.DrawRectangle(rl.RectangleInt32{X: 0, Y: 0, Width: 20, Height: 20}, rl.Green)
+
+	img := sp.Image()
+	assert.Equal(t, rl.Red, img.RGBAAt(1, 1))
+	assert.Equal(t, rl.Green, img.RGBAAt(5, 5))
+	assert.Equal(t, rl.Green, img.RGBAAt(9, 9))
+	assert.NotEqual(t, rl.Green, img.RGBAAt(10, 10))
+}
+
+func TestCanvasContext_Alpha(t *testing.T) {
+	cc := newCanvas(100, 100)
+	assert.Equal(t, float32(1.0), cc.Alpha())
+	cc.pushRelativeRegion(Vector2Int32{}, rl.RectangleInt32{Width: 50, Height: 50})
+	cc.fadeTop(0.5)
+	assert.Equal(t, rl.Color{R: 10, A: 127}, cc.fade(rl.Color{R: 10, A: 255}))
+
+	// the nested region opacity is multiplied by the parent one
+	cc.pushRelativeRegion(Vector2Int32{}, rl.RectangleInt32{Width: 10, Height: 10})
+	assert.Equal(t, float32(0.5), cc.Alpha())
+	cc.fadeTop(0.5)
+	assert.Equal(t, float32(0.25), cc.Alpha())
+	cc.pop()
+	assert.Equal(t, float32(0.5), cc.Alpha())
+	cc.pop()
+	assert.Equal(t, float32(1.0), cc.Alpha())
+}
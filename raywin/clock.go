@@ -0,0 +1,119 @@
+package raywin
+
+// Copyright 2025 Dmitry Spasibenko
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+import (
+	"sync"
+	"time"
+)
+
+type (
+	// Clock is the source of the frames timestamps. The display calls Millis() once per
+	// frame, and the returned value is used as the frame millis everywhere: it is passed
+	// to the FrameListeners, it is in TPState.Millis and KeyEvent.Millis, and it is
+	// returned by Millis(). The clock may be provided via Config.Clock.
+	Clock interface {
+		// Millis returns the timestamp for the new frame. The values must not decrease
+		// from call to call.
+		Millis() int64
+	}
+
+	// ScaledClock is the monotonic clock, which runs scale times faster than the wall
+	// clock. The scale less than 1.0 makes the clock slower, which allows to watch the
+	// animations in the slow motion. The clock starts from 0 on the first Millis() call.
+	ScaledClock struct {
+		lock  sync.Mutex
+		start time.Time
+		base  int64
+		scale float64
+	}
+
+	// FixedStepClock is the simulated clock, which advances by the fixed step on every
+	// Millis() call independently of the wall clock. The first call returns 0. The clock
+	// makes the time-dependent behavior of the components deterministic, every frame
+	// is "step" milliseconds long independently of how long it takes to render it.
+	FixedStepClock struct {
+		lock   sync.Mutex
+		step   int64
+		millis int64
+	}
+)
+
+var _ Clock = (*ScaledClock)(nil)
+var _ Clock = (*FixedStepClock)(nil)
+
+// NewRealClock returns the monotonic clock, which follows the wall clock. It is the
+// default clock, if Config.Clock is not specified.
+func NewRealClock() Clock {
+	return NewScaledClock(1.0)
+}
+
+// NewScaledClock returns the new ScaledClock with the scale provided, non-positive
+// scale is considered as 1.0
+func NewScaledClock(scale float64) *ScaledClock {
+	sc := &ScaledClock{}
+	sc.SetScale(scale)
+	return sc
+}
+
+// SetScale changes the clock speed, the clock keeps counting from its current value
+func (sc *ScaledClock) SetScale(scale float64) {
+	if scale <= 0.0 {
+		scale = 1.0
+	}
+	sc.lock.Lock()
+	defer sc.lock.Unlock()
+	if !sc.start.IsZero() {
+		sc.base = sc.millis(time.Now())
+		sc.start = time.Now()
+	}
+	sc.scale = scale
+}
+
+// Scale returns the current clock scale
+func (sc *ScaledClock) Scale() float64 {
+	sc.lock.Lock()
+	defer sc.lock.Unlock()
+	return sc.scale
+}
+
+// Millis implements Clock
+func (sc *ScaledClock) Millis() int64 {
+	sc.lock.Lock()
+	defer sc.lock.Unlock()
+	now := time.Now()
+	if sc.start.IsZero() {
+		sc.start = now
+	}
+	return sc.millis(now)
+}
+
+func (sc *ScaledClock) millis(now time.Time) int64 {
+	return sc.base + int64(float64(now.Sub(sc.start).Microseconds())*sc.scale/1000.0)
+}
+
+// NewFixedStepClock returns the new FixedStepClock with the step in milliseconds, the
+// step must be positive, otherwise 1 is used
+func NewFixedStepClock(step int64) *FixedStepClock {
+	return &FixedStepClock{step: max(1, step), millis: -max(1, step)}
+}
+
+// Millis implements Clock
+func (fc *FixedStepClock) Millis() int64 {
+	fc.lock.Lock()
+	defer fc.lock.Unlock()
+	fc.millis += fc.step
+	return fc.millis
+}
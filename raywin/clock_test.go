@@ -0,0 +1,82 @@
+package raywin
+
+// Copyright 2025 Dmitry Spasibenko
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+import (
+	"context"
+	rl "github.com/gen2brain/raylib-go/raylib"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestFixedStepClock(t *testing.T) {
+	fc := NewFixedStepClock(10)
+	assert.Equal(t, int64(0), fc.Millis())
+	assert.Equal(t, int64(10), fc.Millis())
+	assert.Equal(t, int64(20), fc.Millis())
+
+	fc = NewFixedStepClock(-5)
+	assert.Equal(t, int64(0), fc.Millis())
+	assert.Equal(t, int64(1), fc.Millis())
+}
+
+func TestScaledClock(t *testing.T) {
+	sc := NewScaledClock(0)
+	assert.Equal(t, 1.0, sc.Scale())
+	sc.SetScale(1000.0)
+	assert.Equal(t, int64(0), sc.Millis())
+	time.Sleep(5 * time.Millisecond)
+	m := sc.Millis()
+	assert.True(t, m >= 5000, m)
+
+	sc.SetScale(0.001)
+	m1 := sc.Millis()
+	assert.True(t, m1 >= m && m1 < m+1000, m1)
+	time.Sleep(5 * time.Millisecond)
+	assert.True(t, sc.Millis()-m1 < 5)
+}
+
+type _clock_test_listener struct {
+	BaseComponent
+	millis []int64
+	tps    []int64
+	cancel context.CancelFunc
+}
+
+func (cl *_clock_test_listener) OnNewFrame(millis int64) {
+	cl.millis = append(cl.millis, millis)
+	if len(cl.millis) == 3 {
+		cl.cancel()
+	}
+}
+
+func (cl *_clock_test_listener) OnTPState(tps TPState) OnTPSResult {
+	cl.tps = append(cl.tps, tps.Millis)
+	return OnTPSResultNA
+}
+
+func Test_display_runClock(t *testing.T) {
+	d := newDisplay(DefaultDisplayConfig(), &testProxy{})
+	d.clock = NewFixedStepClock(25)
+	ctx, cancel := context.WithCancel(context.Background())
+	cl := &_clock_test_listener{cancel: cancel}
+	assert.Nil(t, cl.Init(&d.root, cl))
+	cl.SetBounds(rl.RectangleInt32{Width: 100, Height: 100})
+	d.run(ctx)
+	assert.Equal(t, []int64{0, 25, 50}, cl.millis)
+	assert.Equal(t, []int64{0, 25, 50}, cl.tps)
+	assert.Equal(t, int64(50), d.millis.Load())
+}
@@ -28,12 +28,12 @@ type (
 	// must have BaseComponent embedded into the structs (please see below)
 	Component interface {
 		// Draw renders the component within the specified physical region, as defined by the cc parameter.
-		// The implementation utilizes Raylib functions, such as rl.Rectangle(), to draw the component on
-		// the display. The cc parameter specifies the position of the component on the physical display.
+		// The implementation utilizes the cc drawing functions, such as cc.DrawRectangle(), to draw the
+		// component on the display. The cc parameter specifies the position of the component on the physical display.
 		//
 		// By default, Draw() is invoked for the physical region where the component is defined. Raywin
 		// uses scissors to constrain the drawing area. The implementation can adjust the drawing area
-		// by calling rl.BeginScissorMode() if the region need to be changed.
+		// by calling cc.BeginScissorMode() if the region need to be changed.
 		//
 		// Raywin invokes Draw() for all visible components in each frame. A component is considered visible
 		// if IsVisible() returns true and its Bounds() intersect with the visible region defined by its
@@ -115,6 +115,48 @@ type (
 		DrawAfter(cc *CanvasContext)
 	}
 
+	// Modal interface maybe implemented by a component to block the user input for the
+	// components placed below it (the siblings added before the modal one and their children).
+	// A visible modal component receives the touches within its bounds and consumes all
+	// touches outside of them, the focus is moved between the Focusable components of the
+	// topmost modal component only, and the key events are not delivered below it. The
+	// focused component below a visible modal one loses the focus and cannot be focused.
+	Modal interface {
+		// IsModal returns whether the component blocks the input for the components below
+		IsModal() bool
+	}
+
+	// TouchInterceptor interface maybe implemented by a Container, which must claim some touch
+	// sequences before its children, e.g. the back swipe started from the Navigator edge. The
+	// InterceptTPState is called before the touchpad state is offered to the children. If it
+	// returns OnTPSResultNA the children are walked as usual, otherwise they don't receive the
+	// state. OnTPSResultLocked makes the container the touchpad acceptor, so the rest of the
+	// touch sequence is sent to its OnTPState.
+	TouchInterceptor interface {
+		Touchpadable
+		// InterceptTPState is called for the touchpad state tps before the children of the container
+		InterceptTPState(tps TPState) OnTPSResult
+	}
+
+	// ChildrenFader interface maybe implemented by a Container to draw its children translucent,
+	// e.g. for the cross-fade transitions. The opacity is applied to the colors the child and
+	// its own children are drawn with (see CanvasContext.Alpha), so the overlapping parts of
+	// the child are blended with each other too.
+	ChildrenFader interface {
+		// ChildAlpha returns the opacity of the child c in [0..1], 1 means the child is opaque
+		// and 0 means it is not drawn. The opacity is multiplied by the container one.
+		ChildAlpha(c Component) float32
+	}
+
+	// Overlay interface maybe implemented by a component, which must stay above all its
+	// siblings regardless of the order they were added in, e.g. the on-screen keyboard. The
+	// overlay component is drawn after its siblings, it receives the touches and the key
+	// events before them and it is not blocked by the modal siblings (see Modal).
+	Overlay interface {
+		// IsOverlay returns whether the component is placed above its siblings
+		IsOverlay() bool
+	}
+
 	// BaseComponent provides the fundamental implementation of all components. It means that any component
 	// should embed the struct. So as the Component interface has the package private baseComponent() function,
 	// it is not possible to implement a Component without embedding the BaseComponent.
@@ -135,7 +177,15 @@ type (
 		// to the holder. See Init()
 		this   Component
 		tpName atomic.Value
+		// id is the optional component identifier (see SetID)
+		id     atomic.Value
 		closed atomic.Bool
+		// layoutDirty indicates that the Layouter container must re-arrange its children
+		layoutDirty atomic.Bool
+		// closeHooks are called when the component is closed (see addCloseHook), the
+		// hooks are identified by the lastHook counter values
+		closeHooks map[int64]func()
+		lastHook   int64
 	}
 
 	// BaseContainer struct offers a basic implementation of Container interface. Complex
@@ -156,9 +206,18 @@ func (bc *BaseContainer) Init(owner Container, this Component) error {
 		return err
 	}
 	bc.children.Store([]Component(nil))
+	bc.layoutDirty.Store(true)
 	return nil
 }
 
+// RequestLayout marks the container children must be re-arranged. If the container
+// implements Layouter, its Layout() will be called before the next frame is formed.
+// The layout is requested automatically when a child is added or removed, a child
+// visibility is changed, or the container bounds are changed.
+func (bc *BaseContainer) RequestLayout() {
+	bc.layoutDirty.Store(true)
+}
+
 // OnAddChild is the default implementation, please see Container interface
 func (bc *BaseContainer) OnAddChild(c Component, children []Component) ([]Component, error) {
 	idx := childIndex(children, c)
@@ -196,6 +255,7 @@ func (bc *BaseContainer) addChild(c Component) error {
 		return err
 	}
 	bc.children.Store(nv)
+	bc.layoutDirty.Store(true)
 	return nil
 }
 
@@ -217,6 +277,7 @@ func (bc *BaseContainer) removeChild(c Component) bool {
 		nv = append(nv, v[:idx]...)
 		nv = append(nv, v[idx+1:]...)
 		bc.children.Store(nv)
+		bc.layoutDirty.Store(true)
 		return true
 	}
 	return false
@@ -234,8 +295,9 @@ func (bc *BaseContainer) Close() {
 	}
 	children := bc.children.Load().([]Component)
 	bc.children.Store([]Component(nil))
-	bc.close()
+	hooks := bc.close()
 	bc.lock.Unlock()
+	runHooks(hooks)
 	for _, c := range children {
 		c.Close()
 	}
@@ -294,7 +356,9 @@ func (bc *BaseComponent) init(owner Container, this Component) error {
 
 // SetBounds allows to assing the comonent position and dimensions by the `r`
 func (bc *BaseComponent) SetBounds(r rl.RectangleInt32) {
-	bc.bounds.Store(r)
+	if old := bc.bounds.Swap(r); old == nil || old.(rl.RectangleInt32) != r {
+		bc.layoutDirty.Store(true)
+	}
 }
 
 // Bounds returns the component position on its owner coordinates, and its size as rl.RectangleInt32
@@ -313,7 +377,23 @@ func (bc *BaseComponent) IsVisible() bool {
 
 // SetVisible allows to specify the component visibility
 func (bc *BaseComponent) SetVisible(visible bool) {
-	bc.visible.Store(visible)
+	if bc.visible.Swap(visible) != visible && bc.owner != nil {
+		bc.owner.layoutDirty.Store(true)
+	}
+}
+
+// SetID assigns the component identifier. The identifier is not used by raywin, it allows
+// to find the component in the components tree, for example, in tests
+func (bc *BaseComponent) SetID(id string) {
+	bc.id.Store(id)
+}
+
+// ID returns the component identifier or the empty string, if it is not assigned
+func (bc *BaseComponent) ID() string {
+	if v := bc.id.Load(); v != nil {
+		return v.(string)
+	}
+	return ""
 }
 
 // Draw is the BaseComponent drawing procedure which does nothing. It is here to support
@@ -324,9 +404,9 @@ func (bc *BaseComponent) Draw(cc *CanvasContext) {
 // Close allows to close the BaseComponent
 func (bc *BaseComponent) Close() {
 	bc.lock.Lock()
-	defer bc.lock.Unlock()
-
-	bc.close()
+	hooks := bc.close()
+	bc.lock.Unlock()
+	runHooks(hooks)
 }
 
 // AssertInitialized returns an error if the component is not initialized
@@ -341,6 +421,14 @@ func (bc *BaseComponent) isClosed() bool {
 	return bc.closed.Load()
 }
 
+// componentTypeName returns the type name of the component c
+func componentTypeName(c Component) string {
+	if v := c.baseComponent().tpName.Load(); v != nil {
+		return v.(string)
+	}
+	return reflect.TypeOf(c).String()
+}
+
 // String returns the `bc` description
 func (bc *BaseComponent) String() string {
 	v := bc.tpName.Load()
@@ -368,9 +456,11 @@ func (bc *BaseComponent) baseComponent() *BaseComponent {
 	return bc
 }
 
-func (bc *BaseComponent) close() {
+// close marks the component closed and returns its close hooks, which must be called
+// by the caller, when the component lock is released
+func (bc *BaseComponent) close() map[int64]func() {
 	if bc.closed.Load() {
-		return
+		return nil
 	}
 	bc.closed.Store(true)
 	if bc.owner != nil {
@@ -378,4 +468,37 @@ func (bc *BaseComponent) close() {
 	}
 	bc.owner = nil
 	bc.this = nil
+	hooks := bc.closeHooks
+	bc.closeHooks = nil
+	return hooks
+}
+
+// addCloseHook adds the function f, which is called once, when the component is closed.
+// It returns the hook id for removeCloseHook, or false, if the component is closed
+// already, f is not called then.
+func (bc *BaseComponent) addCloseHook(f func()) (int64, bool) {
+	bc.lock.Lock()
+	defer bc.lock.Unlock()
+	if bc.closed.Load() {
+		return 0, false
+	}
+	if bc.closeHooks == nil {
+		bc.closeHooks = make(map[int64]func())
+	}
+	bc.lastHook++
+	bc.closeHooks[bc.lastHook] = f
+	return bc.lastHook, true
+}
+
+// removeCloseHook removes the hook added by addCloseHook
+func (bc *BaseComponent) removeCloseHook(id int64) {
+	bc.lock.Lock()
+	defer bc.lock.Unlock()
+	delete(bc.closeHooks, id)
+}
+
+func runHooks(hooks map[int64]func()) {
+	for _, f := range hooks {
+		f()
+	}
 }
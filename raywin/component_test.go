@@ -59,6 +59,13 @@ func TestBaseComponent_Bounds(t *testing.T) {
 	assert.Equal(t, r, bc.Bounds())
 }
 
+func TestBaseComponent_ID(t *testing.T) {
+	var bc BaseComponent
+	assert.Equal(t, "", bc.ID())
+	bc.SetID("abc")
+	assert.Equal(t, "abc", bc.ID())
+}
+
 func TestBaseComponent_IsVisible(t *testing.T) {
 	var bc BaseComponent
 	assert.False(t, bc.IsVisible())
@@ -0,0 +1,81 @@
+package components
+
+// Copyright 2025 Dmitry Spasibenko
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+import (
+	"fmt"
+	"github.com/dspasibenko/raywin-go/raywin"
+)
+
+// BindLabel makes the Label text follow the obs value, the value is converted to the
+// text by the format function. If format is nil, the value is formatted by fmt.Sprint.
+// The binding is released when the label is closed.
+func BindLabel[T comparable](l *Label, obs *raywin.Observable[T], format func(v T) string) (*raywin.Subscription, error) {
+	if format == nil {
+		format = func(v T) string { return fmt.Sprint(v) }
+	}
+	return obs.Subscribe(l, func(v T) {
+		l.SetText(format(v))
+	})
+}
+
+// BindToggle binds the Toggle state and obs in both directions: the toggle follows the
+// obs value, and the obs value is changed when the user switches the toggle. The
+// binding is released when the toggle is closed. The function should be called from
+// the raywin callbacks or before Run().
+func BindToggle(t *Toggle, obs *raywin.Observable[bool]) (*raywin.Subscription, error) {
+	sub, err := obs.Subscribe(t, t.SetOn)
+	if err != nil {
+		return nil, err
+	}
+	prev := t.onChange
+	t.onChange = func(on bool) {
+		if prev != nil {
+			prev(on)
+		}
+		if sub.IsActive() {
+			obs.Set(on)
+		}
+	}
+	return sub, nil
+}
+
+// BindEditBox binds the EditBox text and obs in both directions: the text follows the
+// obs value, and the obs value is changed when the user edits the text (OnChange is
+// called as usual). The binding is released when the edit box is closed. The function
+// should be called from the raywin callbacks or before Run().
+func BindEditBox(eb *EditBox, obs *raywin.Observable[string]) (*raywin.Subscription, error) {
+	sub, err := obs.Subscribe(eb, func(v string) {
+		// the text is already the same, if the change came from the edit box
+		if eb.Text() != v {
+			eb.SetText(v)
+		}
+	})
+	if err != nil {
+		return nil, err
+	}
+	eb.lock.Lock()
+	defer eb.lock.Unlock()
+	prev := eb.cfg.onChange
+	eb.cfg.onChange = func(text string) {
+		if prev != nil {
+			prev(text)
+		}
+		if sub.IsActive() {
+			obs.Set(text)
+		}
+	}
+	return sub, nil
+}
@@ -0,0 +1,102 @@
+package components
+
+// Copyright 2025 Dmitry Spasibenko
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+import (
+	"fmt"
+	"github.com/dspasibenko/raywin-go/raywin"
+	"github.com/dspasibenko/raywin-go/raywin/raywintest"
+	rl "github.com/gen2brain/raylib-go/raylib"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func newTestBindingHarness(t *testing.T) *raywintest.Harness {
+	cfg := raywin.DefaultConfig()
+	cfg.DisplayConfig.Width, cfg.DisplayConfig.Height = 200, 100
+	cfg.FrameListener = DefaultStyleOutlet(cfg.DisplayConfig)
+	h, err := raywintest.NewHarness(cfg)
+	assert.Nil(t, err)
+	t.Cleanup(func() { assert.Nil(t, h.Close()) })
+	return h
+}
+
+func TestBindLabel(t *testing.T) {
+	h := newTestBindingHarness(t)
+	l, err := NewLabel(h.App().RootContainer(), "", DefaultLabelConfig())
+	assert.Nil(t, err)
+	temp := raywin.NewObservable(21.5)
+	sub, err := BindLabel(l, temp, nil)
+	assert.Nil(t, err)
+	assert.Nil(t, h.Step(1))
+	assert.Equal(t, "21.5", l.text)
+
+	sub.Cancel()
+	_, err = BindLabel(l, temp, func(v float64) string { return fmt.Sprintf("t=%.0f", v) })
+	assert.Nil(t, err)
+	temp.Set(22.75)
+	assert.Nil(t, h.Step(1))
+	assert.Equal(t, "t=23", l.text)
+}
+
+func TestBindToggle(t *testing.T) {
+	h := newTestBindingHarness(t)
+	tg, err := NewToggle(h.App().RootContainer(), nil)
+	assert.Nil(t, err)
+	tg.SetBounds(rl.RectangleInt32{X: 10, Y: 10})
+	on := raywin.NewObservable(true)
+	_, err = BindToggle(tg, on)
+	assert.Nil(t, err)
+	assert.Nil(t, h.Step(1))
+	assert.True(t, tg.IsOn())
+
+	n, _ := h.Node(tg)
+	assert.Nil(t, h.LongPress(rl.Vector2{X: float32(n.Region.X + n.Region.Width/2), Y: float32(n.Region.Y + n.Region.Height/2)}, 100))
+	assert.False(t, tg.IsOn())
+	assert.False(t, on.Get())
+
+	on.Set(true)
+	assert.Nil(t, h.Step(1))
+	assert.True(t, tg.IsOn())
+}
+
+func TestBindEditBox(t *testing.T) {
+	h := newTestBindingHarness(t)
+	var changes []string
+	eb, err := NewEditBox(h.App().RootContainer(), DefaultEditBoxConfig().OnChange(func(text string) {
+		changes = append(changes, text)
+	}))
+	assert.Nil(t, err)
+	name := raywin.NewObservable("ab")
+	_, err = BindEditBox(eb, name)
+	assert.Nil(t, err)
+	assert.Nil(t, h.Step(1))
+	assert.Equal(t, "ab", eb.Text())
+
+	eb.InsertText("c")
+	assert.Equal(t, "abc", name.Get())
+	assert.Equal(t, []string{"abc"}, changes)
+	assert.Nil(t, h.Step(1))
+	assert.Equal(t, "abc", eb.Text())
+
+	name.Set("xyz")
+	assert.Nil(t, h.Step(1))
+	assert.Equal(t, "xyz", eb.Text())
+	assert.Equal(t, []string{"abc"}, changes)
+
+	eb.Close()
+	eb.InsertText("!")
+	assert.Equal(t, "xyz", name.Get())
+}
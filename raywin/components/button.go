@@ -13,17 +13,18 @@ type Button struct {
 	raywin.BaseComponent
 	raywin.Pressor
 
-	text      string
-	textSize  rl.Vector2
-	bs        atomic.Value
-	once      golibs.Once
-	pressedAt int64
-	fadeK     float32
+	text     string
+	textSize rl.Vector2
+	bs       atomic.Value
+	once     golibs.Once
+	fade     *raywin.Animation
+	fadeK    float32
 }
 
 // ButtonStyle allows to specify the style of the button
 type ButtonStyle struct {
 	textFont     rl.Font
+	italic       bool
 	textFontSize float32
 	color        color.RGBA
 	outlineColor color.RGBA
@@ -33,12 +34,20 @@ type ButtonStyle struct {
 	flags        int // See constants below (ButtonSelectStyleJumpOut etc.)
 }
 
-// TextFont specifies the font which will be used for the button text
+// TextFont specifies the font which will be used for the button text. If the font is not
+// specified, the system font of the button App is used (see Italic)
 func (bs ButtonStyle) TextFont(textFont rl.Font) ButtonStyle {
 	bs.textFont = textFont
 	return bs
 }
 
+// Italic specifies whether the system italic font is used for the button text, when the
+// TextFont is not specified
+func (bs ButtonStyle) Italic(italic bool) ButtonStyle {
+	bs.italic = italic
+	return bs
+}
+
 // TextFontSize specifies the font size used for the button text
 func (bs ButtonStyle) TextFontSize(textFontSize float32) ButtonStyle {
 	bs.textFontSize = textFontSize
@@ -84,7 +93,7 @@ func (bs ButtonStyle) Flags(flags int) ButtonStyle {
 // DialogButtonStyle - just standard button style which jumps out when it is pressed
 func DialogButtonStyle() ButtonStyle {
 	return ButtonStyle{
-		textFont:     raywin.SystemItalicFont(70),
+		italic:       true,
 		textFontSize: 70,
 		color:        S.DialogBackgroundDark,
 		outlineColor: S.OutlineColor,
@@ -97,7 +106,6 @@ func DialogButtonStyle() ButtonStyle {
 // DialogButtonCancelStyle offers "cancel" button for dialogs
 func DialogButtonCancelStyle() ButtonStyle {
 	return ButtonStyle{
-		textFont:     raywin.SystemFont(30),
 		textFontSize: 30,
 		color:        color.RGBA{82, 2, 2, 255},
 		selectColor:  color.RGBA{107, 2, 2, 255},
@@ -109,7 +117,6 @@ func DialogButtonCancelStyle() ButtonStyle {
 // DialogButtonOkStyle offers "ok" button for dialogs
 func DialogButtonOkStyle() ButtonStyle {
 	return ButtonStyle{
-		textFont:     raywin.SystemFont(30),
 		textFontSize: 30,
 		color:        color.RGBA{4, 51, 38, 255},
 		selectColor:  color.RGBA{6, 71, 53, 255},
@@ -121,7 +128,7 @@ func DialogButtonOkStyle() ButtonStyle {
 // DialogButtonControlStyle offers a button for controls button style
 func DialogButtonControlStyle() ButtonStyle {
 	return ButtonStyle{
-		textFont:     raywin.SystemItalicFont(30),
+		italic:       true,
 		textFontSize: 25,
 		color:        S.DialogBackgroundDark,
 		outlineColor: S.OutlineColor,
@@ -134,7 +141,6 @@ func DialogButtonControlStyle() ButtonStyle {
 // DialogButtonCloseStyle style for the close dialog button style
 func DialogButtonCloseStyle() ButtonStyle {
 	return ButtonStyle{
-		textFont:     raywin.SystemFont(30),
 		textFontSize: 0,
 		color:        S.DialogBackgroundDark,
 		outlineColor: S.OutlineColor,
@@ -219,28 +225,51 @@ func (b *Button) Style() ButtonStyle {
 	return b.bs.Load().(ButtonStyle)
 }
 
+// textFont returns the style font, or the system font of the button App, if the style
+// doesn't specify the font
+func (b *Button) textFont(bs ButtonStyle) rl.Font {
+	if bs.textFont.BaseSize != 0 {
+		return bs.textFont
+	}
+	if bs.italic {
+		return raywin.AppOf(b).SystemItalicFont(int(bs.textFontSize))
+	}
+	return raywin.AppOf(b).SystemFont(int(bs.textFontSize))
+}
+
 func (b *Button) onFirstDraw(cc *raywin.CanvasContext) {
 	bs := b.Style()
-	b.textSize = rl.MeasureTextEx(bs.textFont, b.text, bs.textFontSize, 0)
+	b.textSize = cc.MeasureText(b.textFont(bs), b.text, bs.textFontSize, 0)
 }
 
 // OnTPState the TouchPad notification
 func (b *Button) OnTPState(tps raywin.TPState) raywin.OnTPSResult {
+	wasPressed := b.Pressed()
 	b.Pressor.OnTPState(tps)
 	if b.Pressed() {
-		b.pressedAt = tps.Millis
+		if b.fade != nil {
+			b.fade.Cancel()
+			b.fade = nil
+		}
 		b.fadeK = 1.0
 		return raywin.OnTPSResultLocked
 	}
+	if wasPressed {
+		// fade out the selection
+		b.fade = raywin.TweenFloat(b, raywin.DefaultAnimationConfig().Duration(500).Easing(raywin.EaseLinear), 1.0, 0.0,
+			func(v float32) { b.fadeK = v })
+		if b.fade.Start() != nil {
+			b.fadeK = 0.0
+		}
+	}
 	return raywin.OnTPSResultNA
 }
 
-// OnNewFrame is the on new notification
-func (b *Button) OnNewFrame(millis int64) {
-	if !b.Pressed() && b.fadeK > 0.0 {
-		b.fadeK = max(0.0, 1.0-float32(millis-b.pressedAt)/500)
-	}
-}
+// OnNewFrame is the on new notification.
+//
+// Deprecated: the selection fade out is animated by the Button itself, the method
+// does nothing and is kept for the compatibility only.
+func (b *Button) OnNewFrame(millis int64) {}
 
 // Draw the drawing of ToggleButton notification
 func (b *Button) Draw(cc *raywin.CanvasContext) {
@@ -269,37 +298,37 @@ func (b *Button) drawJumpedOut(cc *raywin.CanvasContext) {
 		phr := cc.PhysicalRegion()
 		dx := int32(float32(phr.Width) * S.ButtonJumpOutCoef)
 		dy := int32(float32(phr.Height) * S.ButtonJumpOutCoef)
-		rl.BeginScissorMode(phr.X-(dx-phr.Width)/2, phr.Y-phr.Height, phr.Width+dx, phr.Height*2)
-		defer rl.BeginScissorMode(phr.X, phr.Y, phr.Width, phr.Height)
+		cc.BeginScissorMode(rl.RectangleInt32{X: phr.X - (dx-phr.Width)/2, Y: phr.Y - phr.Height, Width: phr.Width + dx, Height: phr.Height * 2})
+		defer cc.EndScissorMode()
 
-		b.drawFrame(pr.ToFloat32(), bs.color)
+		b.drawFrame(cc, pr.ToFloat32(), bs.color)
 		pr.X -= (dx - phr.Width) / 2
 		pr.Y -= phr.Height
 		pr.Width = dx
 		pr.Height = dy
 
 		for i := 0; i < 4; i++ {
-			b.drawFrame(pr.ToFloat32(), rl.Fade(S.FrameSelectToneColor, 0.3))
+			b.drawFrame(cc, pr.ToFloat32(), rl.Fade(S.FrameSelectToneColor, 0.3))
 			pr.X += 1
 			pr.Y++
 			pr.Height -= 2
 			pr.Width -= 2
 		}
-		b.drawFrame(pr.ToFloat32(), S.FrameSelectToneColor)
+		b.drawFrame(cc, pr.ToFloat32(), S.FrameSelectToneColor)
 		pr.X += 1
 		pr.Y++
 		pr.Height -= 2
 		pr.Width -= 2
-		b.drawFrame(pr.ToFloat32(), bs.selectColor)
+		b.drawFrame(cc, pr.ToFloat32(), bs.selectColor)
 		dy2 := float32(pr.Y + pr.Height/2)
 		center := rl.Vector2{X: float32(pr.X+pr.Width/2) - b.textSize.X/2, Y: dy2 - b.textSize.Y/2}
-		rl.DrawTextEx(bs.textFont, b.text, center, bs.textFontSize, 0, bs.textColor)
+		cc.DrawText(b.textFont(bs), b.text, center, bs.textFontSize, 0, bs.textColor)
 		return
 	}
-	b.drawFrame(pr.ToFloat32(), bs.color)
+	b.drawFrame(cc, pr.ToFloat32(), bs.color)
 	b.drawIcon(cc)
 	center := rl.Vector2{X: float32(pr.X+pr.Width/2) - b.textSize.X/2, Y: dy - b.textSize.Y/2}
-	rl.DrawTextEx(bs.textFont, b.text, center, bs.textFontSize, 0, bs.textColor)
+	cc.DrawText(b.textFont(bs), b.text, center, bs.textFontSize, 0, bs.textColor)
 }
 
 func (b *Button) drawFaded(cc *raywin.CanvasContext) {
@@ -313,16 +342,12 @@ func (b *Button) drawFaded(cc *raywin.CanvasContext) {
 	if b.Pressed() {
 		col = bs.selectColor
 	} else {
-		from := bs.selectColor
-		col.R = uint8(float32(from.R)*b.fadeK + float32(1.0-b.fadeK)*float32(col.R))
-		col.G = uint8(float32(from.G)*b.fadeK + float32(1.0-b.fadeK)*float32(col.G))
-		col.B = uint8(float32(from.B)*b.fadeK + float32(1.0-b.fadeK)*float32(col.B))
-		col.A = uint8(float32(from.A)*b.fadeK + float32(1.0-b.fadeK)*float32(col.A))
+		col = raywin.LerpColor(col, bs.selectColor, b.fadeK)
 	}
-	b.drawFrame(pr.ToFloat32(), col)
+	b.drawFrame(cc, pr.ToFloat32(), col)
 	b.drawIcon(cc)
 	center := rl.Vector2{X: float32(pr.X+pr.Width/2) - b.textSize.X/2, Y: dy - b.textSize.Y/2}
-	rl.DrawTextEx(bs.textFont, b.text, center, bs.textFontSize, 0, bs.textColor)
+	cc.DrawText(b.textFont(bs), b.text, center, bs.textFontSize, 0, bs.textColor)
 }
 
 func (b *Button) drawSwallen(cc *raywin.CanvasContext) {
@@ -336,8 +361,8 @@ func (b *Button) drawSwallen(cc *raywin.CanvasContext) {
 	if b.Pressed() {
 		phr := cc.PhysicalRegion()
 		diff := int32(float32(phr.Width) * S.ButtonSwallenCoef)
-		rl.BeginScissorMode(phr.X-diff/2, phr.Y-diff/2, phr.Width+diff, phr.Height+diff)
-		defer rl.BeginScissorMode(phr.X, phr.Y, phr.Width, phr.Height)
+		cc.BeginScissorMode(rl.RectangleInt32{X: phr.X - diff/2, Y: phr.Y - diff/2, Width: phr.Width + diff, Height: phr.Height + diff})
+		defer cc.EndScissorMode()
 
 		pr.X -= diff / 2
 		pr.Y -= diff / 2
@@ -345,51 +370,51 @@ func (b *Button) drawSwallen(cc *raywin.CanvasContext) {
 		pr.Height += diff
 
 		for i := 0; i < 4; i++ {
-			b.drawFrame(pr.ToFloat32(), rl.Fade(S.FrameSelectToneColor, 0.3))
+			b.drawFrame(cc, pr.ToFloat32(), rl.Fade(S.FrameSelectToneColor, 0.3))
 			pr.X += 1
 			pr.Y += 1
 			pr.Height -= 2
 			pr.Width -= 2
 		}
-		b.drawFrame(pr.ToFloat32(), S.FrameSelectToneColor)
+		b.drawFrame(cc, pr.ToFloat32(), S.FrameSelectToneColor)
 		pr.X += 1
 		pr.Y += 1
 		pr.Height -= 2
 		pr.Width -= 2
 		fs *= float32(pr.Width) / float32(pr.Width-diff/2)
 		d = -5.0
-		b.drawFrame(pr.ToFloat32(), bs.selectColor)
+		b.drawFrame(cc, pr.ToFloat32(), bs.selectColor)
 	} else {
-		b.drawFrame(pr.ToFloat32(), bs.color)
+		b.drawFrame(cc, pr.ToFloat32(), bs.color)
 	}
 	b.drawIcon(cc)
 	center := rl.Vector2{X: float32(pr.X+pr.Width/2) - b.textSize.X/2 + d, Y: float32(pr.Y+pr.Height/2) - b.textSize.Y/2 + d}
-	rl.DrawTextEx(bs.textFont, b.text, center, fs, 0, bs.textColor)
+	cc.DrawText(b.textFont(bs), b.text, center, fs, 0, bs.textColor)
 }
 
-func (b *Button) drawFrame(r rl.Rectangle, col color.RGBA) {
+func (b *Button) drawFrame(cc *raywin.CanvasContext, r rl.Rectangle, col color.RGBA) {
 	bs := b.Style()
 	switch bs.flags & 0x38 {
 	case ButtonFrameRounded:
-		rl.DrawRectangleRounded(r, 0.2, 5, col)
+		cc.DrawRectangleRounded(r, 0.2, 5, col)
 	case ButtonFrameRound:
-		rl.DrawCircle(int32(r.X+r.Width/2), int32(r.Y+r.Height/2), r.Width/2, col)
+		cc.DrawCircle(rl.Vector2{X: r.X + r.Width/2, Y: r.Y + r.Height/2}, r.Width/2, col)
 	case ButtonFrameSquare:
-		rl.DrawRectangle(int32(r.X), int32(r.Y), int32(r.Width), int32(r.Height), col)
+		cc.DrawRectangle(rl.RectangleInt32{X: int32(r.X), Y: int32(r.Y), Width: int32(r.Width), Height: int32(r.Height)}, col)
 		r.X += 3
 		r.Y += 3
 		r.Width -= 6
 		r.Height -= 6
-		rl.DrawRectangleLinesEx(r, 2.0, rl.Black)
+		cc.DrawRectangleLines(r, 2.0, rl.Black)
 	case ButtonFrameOutlined:
 		if !b.Pressed() {
-			rl.DrawRectangleRounded(r, 0.2, 5, bs.outlineColor)
+			cc.DrawRectangleRounded(r, 0.2, 5, bs.outlineColor)
 			r.X += 1.0
 			r.Width -= 2.0
 			r.Y += 1.0
 			r.Height -= 2.0
 		}
-		rl.DrawRectangleRounded(r, 0.2, 5, col)
+		cc.DrawRectangleRounded(r, 0.2, 5, col)
 	}
 }
 
@@ -400,6 +425,6 @@ func (b *Button) drawIcon(cc *raywin.CanvasContext) {
 	}
 	r := b.Bounds()
 	x, y := cc.PhysicalPointXY(0, 0)
-	tx, _ := raywin.GetIcon(bs.icon)
-	rl.DrawTexture(tx, x+r.Width/2-tx.Width/2, y+r.Height/2-tx.Height/2, rl.White)
+	tx, _ := raywin.AppOf(b).GetIcon(bs.icon)
+	cc.DrawTexture(tx, raywin.Vector2Int32{X: x + r.Width/2 - tx.Width/2, Y: y + r.Height/2 - tx.Height/2}, rl.White)
 }
@@ -0,0 +1,50 @@
+package components
+
+// Copyright 2025 Dmitry Spasibenko
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+import (
+	"github.com/dspasibenko/raywin-go/raywin"
+	"github.com/dspasibenko/raywin-go/raywin/raywintest"
+	rl "github.com/gen2brain/raylib-go/raylib"
+	"github.com/stretchr/testify/assert"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestButton_systemFont(t *testing.T) {
+	dir := t.TempDir()
+	assert.Nil(t, os.WriteFile(filepath.Join(dir, "regular.ttf"), []byte("font"), 0644))
+	cfg := raywin.DefaultConfig()
+	cfg.DisplayConfig.Width, cfg.DisplayConfig.Height = 200, 100
+	cfg.ResourceDir = dir
+	cfg.RegularFontFileName = "regular.ttf"
+	h, err := raywintest.NewHarness(cfg)
+	assert.Nil(t, err)
+	defer h.Close()
+
+	// the fonts are resolved by the component App, not by the default one
+	b, err := NewButton(h.App().RootContainer(), rl.RectangleInt32{Width: 50, Height: 30}, "OK", DialogButtonOkStyle(), nil)
+	assert.Nil(t, err)
+	assert.NotZero(t, b.textFont(b.Style()).BaseSize)
+	assert.Equal(t, rl.Font{}, b.textFont(b.Style().Italic(true)))
+	font := rl.Font{BaseSize: 12}
+	assert.Equal(t, font, b.textFont(b.Style().TextFont(font)))
+
+	l, err := NewLabel(h.App().RootContainer(), "label", DefaultLabelConfig())
+	assert.Nil(t, err)
+	assert.NotZero(t, l.font().BaseSize)
+	assert.Nil(t, h.Step(1))
+}
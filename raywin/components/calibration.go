@@ -0,0 +1,171 @@
+package components
+
+// Copyright 2025 Dmitry Spasibenko
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+import (
+	"fmt"
+	"github.com/dspasibenko/raywin-go/pkg/golibs/errors"
+	"github.com/dspasibenko/raywin-go/raywin"
+	rl "github.com/gen2brain/raylib-go/raylib"
+	"sync/atomic"
+)
+
+// CalibrationScreen is the modal full screen component, which computes the touch
+// calibration (see raywin.TouchCalibration). It shows the crosshairs one by one in the
+// corners and in the center of the display, and the user taps every crosshair center.
+// The calibration of the App is disabled while the screen is shown, so the raw touch
+// points are collected. When the last crosshair is tapped, the calibration is computed
+// and set to the App.
+//
+// The result is reported via the onDone callback, the screen is closed after that.
+// If the calibration could not be computed, or the screen is canceled by the Escape (back)
+// key, the previous App calibration is restored.
+type CalibrationScreen struct {
+	raywin.BaseContainer
+
+	app     *raywin.App
+	dc      raywin.DisplayConfig
+	prev    *raywin.TouchCalibration
+	targets []rl.Vector2
+	raw     []rl.Vector2
+	tapSeq  int64
+	done    atomic.Bool
+	onDone  func(tc raywin.TouchCalibration, err error)
+}
+
+var _ raywin.Modal = (*CalibrationScreen)(nil)
+var _ raywin.KeyListener = (*CalibrationScreen)(nil)
+var _ raywin.Touchpadable = (*CalibrationScreen)(nil)
+
+// CalibrationInsetPercent is the distance of the corner crosshairs from the display
+// edges in percents of the display size
+const CalibrationInsetPercent = 10
+
+// NewCalibrationScreen creates the CalibrationScreen over the root container of the app,
+// nil app means the default one. The onDone callback is called from the raywin drawing
+// loop goroutine, it may be nil.
+func NewCalibrationScreen(app *raywin.App, onDone func(tc raywin.TouchCalibration, err error)) (*CalibrationScreen, error) {
+	if app == nil {
+		app = raywin.Default()
+	}
+	cs := &CalibrationScreen{app: app, dc: app.Config().DisplayConfig, onDone: onDone}
+	if err := cs.Init(app.RootContainer(), cs); err != nil {
+		return nil, err
+	}
+	rb := app.RootContainer().(raywin.Component).Bounds()
+	cs.SetBounds(rl.RectangleInt32{Width: rb.Width, Height: rb.Height})
+	w, h := float32(rb.Width), float32(rb.Height)
+	dx, dy := w*CalibrationInsetPercent/100, h*CalibrationInsetPercent/100
+	cs.targets = []rl.Vector2{{X: dx, Y: dy}, {X: w - dx, Y: dy}, {X: w - dx, Y: h - dy}, {X: dx, Y: h - dy}, {X: w / 2, Y: h / 2}}
+
+	lineHeight := int32(raywin.Mm(6).Pixels(S.PPI))
+	if _, err := NewLabel(cs, "Tap the center of the crosshair", DefaultLabelConfig().Alignment(AlignVCenter|AlignHCenter).
+		Rectangle(rl.RectangleInt32{Y: rb.Height/3 - lineHeight/2, Width: rb.Width, Height: lineHeight})); err != nil {
+		cs.Close()
+		return nil, err
+	}
+	cs.prev = app.TouchCalibration()
+	app.SetTouchCalibration(nil)
+	return cs, nil
+}
+
+// Cancel closes the screen and restores the previous calibration, onDone receives
+// errors.ErrCanceled
+func (cs *CalibrationScreen) Cancel() {
+	cs.finish(raywin.TouchCalibration{}, fmt.Errorf("the calibration is canceled: %w", errors.ErrCanceled))
+}
+
+// IsModal implements raywin.Modal
+func (cs *CalibrationScreen) IsModal() bool {
+	return true
+}
+
+// OnUnhandledKey implements raywin.KeyListener, the Escape (back) key cancels the screen
+func (cs *CalibrationScreen) OnUnhandledKey(ke raywin.KeyEvent) bool {
+	if ke.Key != rl.KeyEscape && ke.Key != rl.KeyBack {
+		return false
+	}
+	cs.Cancel()
+	return true
+}
+
+// OnTPState implements raywin.Touchpadable. The point, where the touch is released, is
+// taken as the tap of the current crosshair
+func (cs *CalibrationScreen) OnTPState(tps raywin.TPState) raywin.OnTPSResult {
+	switch tps.State {
+	case raywin.TPStatePressed, raywin.TPStateMoving:
+		cs.tapSeq = tps.Sequence
+		return raywin.OnTPSResultLocked
+	case raywin.TPStateReleased:
+		if cs.tapSeq == 0 || cs.done.Load() {
+			return raywin.OnTPSResultStop
+		}
+		cs.tapSeq = 0
+		cs.raw = append(cs.raw, tps.Pos)
+		if len(cs.raw) == len(cs.targets) {
+			cs.complete()
+		}
+	}
+	return raywin.OnTPSResultStop
+}
+
+// Draw implements raywin.Component
+func (cs *CalibrationScreen) Draw(cc *raywin.CanvasContext) {
+	b := cs.Bounds()
+	x, y := cc.PhysicalPointXY(0, 0)
+	cc.DrawRectangle(rl.RectangleInt32{X: x, Y: y, Width: b.Width, Height: b.Height}, S.DialogBackgroundDark)
+	if len(cs.raw) >= len(cs.targets) {
+		return
+	}
+	t := cs.targets[len(cs.raw)]
+	cx, cy := int32(t.X)+x, int32(t.Y)+y
+	size := int32(raywin.Mm(5).Pixels(S.PPI))
+	thick := max(1, int32(raywin.Mm(0.4).Pixels(S.PPI)))
+	cc.DrawRectangle(rl.RectangleInt32{X: cx - size, Y: cy - thick/2, Width: 2 * size, Height: thick}, S.OutlineColor)
+	cc.DrawRectangle(rl.RectangleInt32{X: cx - thick/2, Y: cy - size, Width: thick, Height: 2 * size}, S.OutlineColor)
+	cc.DrawCircleLines(rl.Vector2{X: float32(cx), Y: float32(cy)}, float32(size)/2, S.FrameColor)
+}
+
+// complete computes the calibration from the collected points. The points are
+// transformed to the physical coordinates, which the calibration is applied in
+func (cs *CalibrationScreen) complete() {
+	raw := make([]rl.Vector2, len(cs.raw))
+	target := make([]rl.Vector2, len(cs.targets))
+	for i := range cs.targets {
+		raw[i] = cs.dc.ToPhysical(cs.raw[i])
+		target[i] = cs.dc.ToPhysical(cs.targets[i])
+	}
+	tc, err := raywin.ComputeCalibration(raw, target)
+	if err == nil {
+		cs.app.SetTouchCalibration(&tc)
+	}
+	cs.finish(tc, err)
+}
+
+// finish closes the screen and reports the result, only the first call has an effect
+func (cs *CalibrationScreen) finish(tc raywin.TouchCalibration, err error) {
+	if !cs.done.CompareAndSwap(false, true) {
+		return
+	}
+	if err != nil {
+		cs.app.SetTouchCalibration(cs.prev)
+	}
+	if cs.AssertInitialized() == nil {
+		cs.Close()
+	}
+	if cs.onDone != nil {
+		cs.onDone(tc, err)
+	}
+}
@@ -0,0 +1,72 @@
+package components
+
+// Copyright 2025 Dmitry Spasibenko
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+import (
+	"github.com/dspasibenko/raywin-go/pkg/golibs/errors"
+	"github.com/dspasibenko/raywin-go/raywin"
+	"github.com/dspasibenko/raywin-go/raywin/raywintest"
+	rl "github.com/gen2brain/raylib-go/raylib"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func newTestCalibrationHarness(t *testing.T) *raywintest.Harness {
+	cfg := raywin.DefaultConfig()
+	cfg.DisplayConfig.Width, cfg.DisplayConfig.Height = 200, 100
+	cfg.DisplayConfig.Calibration = &raywin.TouchCalibration{A: 2, E: 2}
+	cfg.FrameListener = DefaultStyleOutlet(cfg.DisplayConfig)
+	h, err := raywintest.NewHarness(cfg)
+	assert.Nil(t, err)
+	t.Cleanup(func() { assert.Nil(t, h.Close()) })
+	return h
+}
+
+func TestCalibrationScreen(t *testing.T) {
+	h := newTestCalibrationHarness(t)
+	var res *raywin.TouchCalibration
+	cs, err := NewCalibrationScreen(h.App(), func(tc raywin.TouchCalibration, err error) {
+		assert.Nil(t, err)
+		res = &tc
+	})
+	assert.Nil(t, err)
+	assert.Nil(t, h.App().TouchCalibration())
+	assert.Nil(t, h.Step(1))
+
+	// the panel reports the points shifted by (5, -3)
+	for _, p := range cs.targets {
+		assert.Nil(t, h.Tap(rl.Vector2{X: p.X + 5, Y: p.Y - 3}))
+	}
+	assert.NotNil(t, res)
+	assert.InDelta(t, -5, res.C, 0.01)
+	assert.InDelta(t, 3, res.F, 0.01)
+	assert.Equal(t, res, h.App().TouchCalibration())
+	assert.NotNil(t, cs.AssertInitialized())
+}
+
+func TestCalibrationScreen_Cancel(t *testing.T) {
+	h := newTestCalibrationHarness(t)
+	var resErr error
+	cs, err := NewCalibrationScreen(h.App(), func(tc raywin.TouchCalibration, err error) {
+		resErr = err
+	})
+	assert.Nil(t, err)
+	assert.True(t, cs.IsModal())
+	assert.False(t, cs.OnUnhandledKey(raywin.KeyEvent{Key: rl.KeyA}))
+	assert.True(t, cs.OnUnhandledKey(raywin.KeyEvent{Key: rl.KeyEscape}))
+	assert.ErrorIs(t, resErr, errors.ErrCanceled)
+	assert.Equal(t, &raywin.TouchCalibration{A: 2, E: 2}, h.App().TouchCalibration())
+	assert.NotNil(t, cs.AssertInitialized())
+}
@@ -0,0 +1,336 @@
+package components
+
+// Copyright 2025 Dmitry Spasibenko
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+import (
+	"github.com/dspasibenko/raywin-go/raywin"
+	rl "github.com/gen2brain/raylib-go/raylib"
+	"strings"
+	"sync/atomic"
+)
+
+// Dialog is the modal dialog box with a title, a message, an optional text input (prompt)
+// and a row of buttons. The Dialog covers the whole owner region with the dimming overlay
+// and blocks the input for all components below it (see raywin.Modal). The dialog box is
+// placed in the center of the overlay.
+//
+// The Dialog is closed when one of its buttons is pressed, or when it is canceled by the
+// Escape (back) key. The result is reported via the OnResult callback and via the channel
+// returned by Result(). Several dialogs may be stacked, the last one created is the top one
+// and the only one which receives the user input.
+type Dialog struct {
+	raywin.BaseContainer
+
+	cfg     DialogConfig
+	box     *raywin.BoxLayout
+	edit    *EditBox
+	result  chan DialogResult
+	done    atomic.Bool
+	boxSize rl.Vector2
+	// app is the App the dialog is shown in, and prevFocus is the component, which was
+	// focused when the dialog was opened, the focus is restored to it on close
+	app       *raywin.App
+	prevFocus raywin.Component
+}
+
+// DialogConfig allows to specify the Dialog settings
+type DialogConfig struct {
+	title      string
+	message    string
+	buttons    []dialogButton
+	prompt     *EditBoxConfig
+	cancelable bool
+	background rl.Color
+	onResult   func(res DialogResult)
+}
+
+// DialogResult describes how the Dialog was closed
+type DialogResult struct {
+	// Button is the index of the pressed button in the order the buttons were added
+	// to DialogConfig, or DialogCanceled, or DialogSubmitted
+	Button int
+	// Text is the prompt text, it is empty if the dialog has no prompt
+	Text string
+}
+
+type dialogButton struct {
+	text  string
+	style ButtonStyle
+}
+
+const (
+	// DialogCanceled is the DialogResult.Button value for the dialog closed by the Escape
+	// (back) key, or by Cancel()
+	DialogCanceled = -1
+	// DialogSubmitted is the DialogResult.Button value for the dialog without buttons
+	// closed by the prompt submit (Enter key)
+	DialogSubmitted = -2
+)
+
+var _ raywin.Modal = (*Dialog)(nil)
+var _ raywin.KeyListener = (*Dialog)(nil)
+var _ raywin.Layouter = (*Dialog)(nil)
+
+// DefaultDialogConfig returns the config for the cancelable dialog without title, message and
+// buttons. The dialog box has the DialogBackgroundDark color.
+func DefaultDialogConfig() DialogConfig {
+	return DialogConfig{cancelable: true, background: S.DialogBackgroundDark}
+}
+
+// Title specifies the dialog title
+func (dcfg DialogConfig) Title(title string) DialogConfig {
+	dcfg.title = title
+	return dcfg
+}
+
+// Message specifies the dialog message, it may contain several lines separated by '\n'
+func (dcfg DialogConfig) Message(message string) DialogConfig {
+	dcfg.message = message
+	return dcfg
+}
+
+// Button adds the button with the text and the style to the dialog buttons row. The
+// buttons are placed from left to right in the order they are added
+func (dcfg DialogConfig) Button(text string, bs ButtonStyle) DialogConfig {
+	dcfg.buttons = append(dcfg.buttons[:len(dcfg.buttons):len(dcfg.buttons)], dialogButton{text: text, style: bs})
+	return dcfg
+}
+
+// Prompt adds the text input to the dialog. The EditBox submit (Enter key) closes the
+// dialog as the last button is pressed, or with DialogSubmitted if there are no buttons.
+func (dcfg DialogConfig) Prompt(ecfg EditBoxConfig) DialogConfig {
+	dcfg.prompt = &ecfg
+	return dcfg
+}
+
+// Cancelable specifies whether the dialog may be closed by the Escape (back) key
+func (dcfg DialogConfig) Cancelable(cancelable bool) DialogConfig {
+	dcfg.cancelable = cancelable
+	return dcfg
+}
+
+// Background specifies the dialog box color (DialogBackgroundLight, DialogBackgroundDark etc.)
+func (dcfg DialogConfig) Background(col rl.Color) DialogConfig {
+	dcfg.background = col
+	return dcfg
+}
+
+// OnResult specifies the callback, which is called when the dialog is closed. The callback
+// is called from the raywin drawing loop goroutine.
+func (dcfg DialogConfig) OnResult(onResult func(res DialogResult)) DialogConfig {
+	dcfg.onResult = onResult
+	return dcfg
+}
+
+// NewDialog creates the new Dialog over the whole owner region
+func NewDialog(owner raywin.Container, cfg DialogConfig) (*Dialog, error) {
+	d := &Dialog{}
+	d.init(cfg)
+	if err := d.Init(owner, d); err != nil {
+		return nil, err
+	}
+	ob := owner.(raywin.Component).Bounds()
+	d.SetBounds(rl.RectangleInt32{Width: ob.Width, Height: ob.Height})
+
+	pad := int32(raywin.Mm(S.DialogPaddingMm).Pixels(S.PPI))
+	spacing := pad / 2
+	width := min(int32(raywin.Mm(S.DialogWidthMm).Pixels(S.PPI)), ob.Width-2*pad)
+	box, err := raywin.NewBoxLayout(d, raywin.DefaultBoxConfig().Vertical(true).Spacing(spacing).
+		Padding(raywin.Insets{Left: pad, Top: pad, Right: pad, Bottom: pad}))
+	if err != nil {
+		d.Close()
+		return nil, err
+	}
+	d.box = box
+
+	lineHeight := int32(raywin.Mm(6).Pixels(S.PPI))
+	height := 2 * pad
+	addRow := func(h int32) rl.RectangleInt32 {
+		if height > 2*pad {
+			height += spacing
+		}
+		height += h
+		return rl.RectangleInt32{Width: width - 2*pad, Height: h}
+	}
+	if cfg.title != "" {
+		lcfg := DefaultLabelConfig().FontSize(40).Alignment(AlignVCenter | AlignLeft)
+		if _, err := NewLabel(box, cfg.title, lcfg.Rectangle(addRow(lineHeight*4/3))); err != nil {
+			d.Close()
+			return nil, err
+		}
+	}
+	if cfg.message != "" {
+		for _, line := range strings.Split(cfg.message, "\n") {
+			if _, err := NewLabel(box, line, DefaultLabelConfig().Alignment(AlignVCenter|AlignLeft).Rectangle(addRow(lineHeight))); err != nil {
+				d.Close()
+				return nil, err
+			}
+		}
+	}
+	if cfg.prompt != nil {
+		ecfg := cfg.prompt.OnSubmit(func(text string) {
+			if cfg.prompt.onSubmit != nil {
+				cfg.prompt.onSubmit(text)
+			}
+			if len(cfg.buttons) == 0 {
+				d.finish(DialogSubmitted)
+				return
+			}
+			d.finish(len(cfg.buttons) - 1)
+		})
+		d.edit, err = NewEditBox(box, ecfg.Rectangle(rl.RectangleInt32{Width: width - 2*pad}))
+		if err != nil {
+			d.Close()
+			return nil, err
+		}
+		addRow(d.edit.Bounds().Height)
+	}
+	if len(cfg.buttons) > 0 {
+		row, err := raywin.NewBoxLayout(box, raywin.DefaultBoxConfig().Spacing(spacing).Rectangle(addRow(int32(raywin.Mm(11).Pixels(S.PPI)))))
+		if err != nil {
+			d.Close()
+			return nil, err
+		}
+		for i, db := range cfg.buttons {
+			idx := i
+			b, err := NewButton(row, rl.RectangleInt32{}, db.text, db.style, func() { d.finish(idx) })
+			if err != nil {
+				d.Close()
+				return nil, err
+			}
+			row.SetLayoutParams(b, raywin.LayoutParams{Weight: 1})
+		}
+	}
+	d.boxSize = rl.Vector2{X: float32(width), Y: float32(height)}
+	d.app = raywin.AppOf(d)
+	d.prevFocus = d.app.Focused()
+	// the components below the dialog must not receive the key events
+	d.app.SetFocus(nil)
+	if d.edit != nil {
+		d.app.SetFocus(d.edit)
+	}
+	return d, nil
+}
+
+func (d *Dialog) init(cfg DialogConfig) {
+	d.cfg = cfg
+	d.result = make(chan DialogResult, 1)
+}
+
+// Result returns the channel, which receives the DialogResult when the dialog is closed
+func (d *Dialog) Result() <-chan DialogResult {
+	return d.result
+}
+
+// Cancel closes the dialog with DialogCanceled result
+func (d *Dialog) Cancel() {
+	d.finish(DialogCanceled)
+}
+
+// IsModal implements raywin.Modal
+func (d *Dialog) IsModal() bool {
+	return true
+}
+
+// OnUnhandledKey implements raywin.KeyListener, the Escape (back) key cancels the
+// cancelable dialog
+func (d *Dialog) OnUnhandledKey(ke raywin.KeyEvent) bool {
+	if !d.cfg.cancelable || (ke.Key != rl.KeyEscape && ke.Key != rl.KeyBack) {
+		return false
+	}
+	d.Cancel()
+	return true
+}
+
+// Layout implements raywin.Layouter, it places the dialog box in the center
+func (d *Dialog) Layout() {
+	if d.box != nil {
+		d.box.SetBounds(centerRect(d.Bounds(), int32(d.boxSize.X), int32(d.boxSize.Y)))
+	}
+}
+
+// Draw implements raywin.Component
+func (d *Dialog) Draw(cc *raywin.CanvasContext) {
+	b := d.Bounds()
+	x, y := cc.PhysicalPointXY(0, 0)
+	cc.DrawRectangle(rl.RectangleInt32{X: x, Y: y, Width: b.Width, Height: b.Height}, S.DialogOverlayColor)
+	if d.box == nil {
+		return
+	}
+	bb := d.box.Bounds()
+	r := rl.Rectangle{X: float32(x + bb.X), Y: float32(y + bb.Y), Width: float32(bb.Width), Height: float32(bb.Height)}
+	cc.DrawRectangleRounded(r, 0.1, 10, d.cfg.background)
+	cc.DrawRectangleRoundedLines(r, 0.1, 10, 2.0, S.OutlineColor)
+}
+
+// finish closes the dialog and reports the result, only the first call has an effect
+func (d *Dialog) finish(button int) {
+	if !d.done.CompareAndSwap(false, true) {
+		return
+	}
+	res := DialogResult{Button: button}
+	if d.edit != nil {
+		res.Text = d.edit.Text()
+	}
+	if d.AssertInitialized() == nil {
+		d.Close()
+		d.restoreFocus()
+	}
+	d.result <- res
+	if d.cfg.onResult != nil {
+		d.cfg.onResult(res)
+	}
+}
+
+// restoreFocus moves the focus back to the component, which was focused before the
+// dialog was opened, if the focus is not moved to another component since then
+func (d *Dialog) restoreFocus() {
+	if d.app == nil || d.prevFocus == nil {
+		return
+	}
+	if f := d.app.Focused(); f == nil || f == raywin.Component(d.edit) {
+		// the previous component may be closed, or below another modal, it is fine then
+		d.app.SetFocus(d.prevFocus)
+	}
+}
+
+// centerRect returns the rectangle of the size w x h in the center of r. The position
+// is relative to r
+func centerRect(r rl.RectangleInt32, w, h int32) rl.RectangleInt32 {
+	return rl.RectangleInt32{X: (r.Width - w) / 2, Y: (r.Height - h) / 2, Width: w, Height: h}
+}
+
+// Alert shows the dialog with the title, the message and the "OK" button over the
+// raywin.RootContainer(). The onResult callback may be nil.
+func Alert(title, message string, onResult func(res DialogResult)) (*Dialog, error) {
+	return NewDialog(raywin.RootContainer(), DefaultDialogConfig().Title(title).Message(message).
+		Button("OK", DialogButtonOkStyle()).OnResult(onResult))
+}
+
+// Confirm shows the dialog with the title, the message and the "Cancel" (index 0) and
+// "OK" (index 1) buttons over the raywin.RootContainer(). The onResult callback may be nil.
+func Confirm(title, message string, onResult func(res DialogResult)) (*Dialog, error) {
+	return NewDialog(raywin.RootContainer(), DefaultDialogConfig().Title(title).Message(message).
+		Button("Cancel", DialogButtonCancelStyle()).Button("OK", DialogButtonOkStyle()).OnResult(onResult))
+}
+
+// Prompt shows the dialog with the title, the message, the text input with the initial
+// text and the "Cancel" (index 0) and "OK" (index 1) buttons over the raywin.RootContainer().
+// The onResult callback may be nil.
+func Prompt(title, message, text string, onResult func(res DialogResult)) (*Dialog, error) {
+	return NewDialog(raywin.RootContainer(), DefaultDialogConfig().Title(title).Message(message).
+		Prompt(DefaultEditBoxConfig().Text(text)).
+		Button("Cancel", DialogButtonCancelStyle()).Button("OK", DialogButtonOkStyle()).OnResult(onResult))
+}
@@ -0,0 +1,100 @@
+package components
+
+import (
+	"github.com/dspasibenko/raywin-go/raywin"
+	"github.com/dspasibenko/raywin-go/raywin/raywintest"
+	rl "github.com/gen2brain/raylib-go/raylib"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestDialog_Result(t *testing.T) {
+	var results []DialogResult
+	d := &Dialog{}
+	d.init(DefaultDialogConfig().OnResult(func(res DialogResult) {
+		results = append(results, res)
+	}))
+	assert.True(t, d.IsModal())
+	d.finish(1)
+	d.Cancel()
+	assert.Equal(t, []DialogResult{{Button: 1}}, results)
+	assert.Equal(t, DialogResult{Button: 1}, <-d.Result())
+	select {
+	case <-d.Result():
+		assert.Fail(t, "only one result is expected")
+	default:
+	}
+}
+
+func TestDialog_OnUnhandledKey(t *testing.T) {
+	d := &Dialog{}
+	d.init(DefaultDialogConfig().Cancelable(false))
+	assert.False(t, d.OnUnhandledKey(raywin.KeyEvent{Key: rl.KeyEscape}))
+
+	d = &Dialog{}
+	d.init(DefaultDialogConfig())
+	assert.False(t, d.OnUnhandledKey(raywin.KeyEvent{Key: rl.KeyA}))
+	assert.True(t, d.OnUnhandledKey(raywin.KeyEvent{Key: rl.KeyBack}))
+	assert.Equal(t, DialogResult{Button: DialogCanceled}, <-d.Result())
+}
+
+func TestDialogConfig_Button(t *testing.T) {
+	cfg := DefaultDialogConfig().Button("a", ButtonStyle{})
+	cfg1 := cfg.Button("b", ButtonStyle{})
+	cfg2 := cfg.Button("c", ButtonStyle{})
+	assert.Equal(t, 1, len(cfg.buttons))
+	assert.Equal(t, "b", cfg1.buttons[1].text)
+	assert.Equal(t, "c", cfg2.buttons[1].text)
+}
+
+func Test_centerRect(t *testing.T) {
+	assert.Equal(t, rl.RectangleInt32{X: 25, Y: 10, Width: 50, Height: 30}, centerRect(rl.RectangleInt32{X: 7, Y: 7, Width: 100, Height: 50}, 50, 30))
+}
+
+func newTestDialogHarness(t *testing.T) *raywintest.Harness {
+	cfg := raywin.DefaultConfig()
+	cfg.DisplayConfig.Width, cfg.DisplayConfig.Height = 800, 480
+	cfg.FrameListener = DefaultStyleOutlet(cfg.DisplayConfig)
+	h, err := raywintest.NewHarness(cfg)
+	assert.Nil(t, err)
+	t.Cleanup(func() { assert.Nil(t, h.Close()) })
+	return h
+}
+
+func TestDialog_focusedBelow(t *testing.T) {
+	h := newTestDialogHarness(t)
+	root := h.App().RootContainer()
+	eb, err := NewEditBox(root, DefaultEditBoxConfig().Text("ab").Rectangle(rl.RectangleInt32{X: 10, Y: 10, Width: 200}))
+	assert.Nil(t, err)
+	assert.Nil(t, h.App().SetFocus(eb))
+
+	d, err := NewDialog(root, DefaultDialogConfig().Title("Alert").Message("message").Button("OK", DialogButtonOkStyle()))
+	assert.Nil(t, err)
+	assert.Nil(t, h.App().Focused())
+	assert.NotNil(t, h.App().SetFocus(eb))
+
+	// neither the chars nor the Enter key reach the EditBox below the dialog
+	h.Proxy().TypeText("xy")
+	h.Proxy().PressKey(rl.KeyEnter)
+	assert.Nil(t, h.Step(2))
+	assert.Equal(t, "ab", eb.Text())
+
+	d.finish(0)
+	assert.Equal(t, raywin.Component(eb), h.App().Focused())
+	h.Proxy().TypeText("c")
+	assert.Nil(t, h.Step(2))
+	assert.Equal(t, "abc", eb.Text())
+}
+
+func TestDialog_promptNoButtons(t *testing.T) {
+	h := newTestDialogHarness(t)
+	d, err := NewDialog(h.App().RootContainer(), DefaultDialogConfig().Title("Name").Prompt(DefaultEditBoxConfig().Text("a")))
+	assert.Nil(t, err)
+	assert.Equal(t, raywin.Component(d.edit), h.App().Focused())
+
+	h.Proxy().TypeText("b")
+	assert.Nil(t, h.Step(1))
+	h.Proxy().PressKey(rl.KeyEnter)
+	assert.Nil(t, h.Step(2))
+	assert.Equal(t, DialogResult{Button: DialogSubmitted, Text: "ab"}, <-d.Result())
+}
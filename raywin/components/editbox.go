@@ -3,67 +3,493 @@ package components
 import (
 	"github.com/dspasibenko/raywin-go/raywin"
 	rl "github.com/gen2brain/raylib-go/raylib"
-	"sync/atomic"
+	"regexp"
+	"strings"
+	"sync"
 )
 
+// EditBox is the single line text input field. The EditBox receives the keyboard
+// events when it holds the focus (see raywin.Focusable), the component gains the
+// focus when it is tapped. The caret may be positioned by tap, and the text may be
+// selected by moving the touch point or by Shift+arrows.
+//
+// The text may be restricted by the maximum length, numeric-only or a regexp mask
+// (see EditBoxConfig). All changes made by the user are reported via OnChange callback
+// and the Enter key is reported via OnSubmit callback. The callbacks are called from
+// the raywin drawing loop goroutine.
 type EditBox struct {
 	raywin.BaseComponent
 
-	text atomic.Value
+	lock sync.Mutex
+	text []rune
+	// caret is the caret position in runes, anchor is the other end of the selection,
+	// the selection is empty if anchor == caret
+	caret  int
+	anchor int
+	cfg    EditBoxConfig
+
+	focused bool
+	// caretOn is the caret blink phase, the blink timer toggles it while the EditBox is
+	// focused. The timer is restarted on every caret change, so the caret is solid while
+	// the user is typing
+	caretOn bool
+	blink   *raywin.Timer
+	scroll  float32
+
+	// offsets caches the x-offset of every caret position in offsetsText drawn by
+	// offsetsFont of offsetsSize, the offsets are measured again only when the text,
+	// the font or its size are changed
+	offsets     []float32
+	offsetsText string
+	offsetsFont rl.Font
+	offsetsSize float32
+
+	tapSeq    int64
+	tapPos    float32
+	tapExtend bool
+	tapActive bool
+}
+
+// EditBoxConfig allows to specify the EditBox settings
+type EditBoxConfig struct {
+	text      string
+	rect      rl.RectangleInt32
+	maxLength int
+	flags     int
+	mask      *regexp.Regexp
+	onChange  func(text string)
+	onSubmit  func(text string)
+}
+
+// EditBoxCaretBlinkMillis is the time the caret of the focused EditBox is shown and hidden for
+const EditBoxCaretBlinkMillis = 500
+
+const (
+	// EditBoxNumeric flag allows to enter numbers only (an optional sign, digits and a decimal point)
+	EditBoxNumeric = 1
+	// EditBoxPassword flag makes the EditBox show '*' instead of the text characters
+	EditBoxPassword = 2
+)
+
+var numericRegexp = regexp.MustCompile(`^[-+]?[0-9]*\.?[0-9]*$`)
+
+// DefaultEditBoxConfig returns the config for the empty EditBox without any restrictions.
+// Default region is {0, 0, 100, 100}, but the height is always defined by the Style.
+func DefaultEditBoxConfig() EditBoxConfig {
+	return EditBoxConfig{
+		rect: rl.RectangleInt32{X: 0, Y: 0, Width: 100, Height: 100},
+	}
+}
+
+// Text specifies the initial text
+func (ecfg EditBoxConfig) Text(text string) EditBoxConfig {
+	ecfg.text = text
+	return ecfg
+}
+
+// Rectangle specifies the EditBox bounds
+func (ecfg EditBoxConfig) Rectangle(r rl.RectangleInt32) EditBoxConfig {
+	ecfg.rect = r
+	return ecfg
+}
+
+// MaxLength specifies the maximum number of characters, 0 means no limits
+func (ecfg EditBoxConfig) MaxLength(maxLength int) EditBoxConfig {
+	ecfg.maxLength = maxLength
+	return ecfg
+}
+
+// Flags specifies the EditBox flags (EditBoxNumeric, EditBoxPassword)
+func (ecfg EditBoxConfig) Flags(flags int) EditBoxConfig {
+	ecfg.flags = flags
+	return ecfg
+}
+
+// Mask specifies the regular expression, which the text must match. The expression
+// is checked against the whole text on every change, so it must match all intermediate
+// values the user types as well (e.g. `^[0-9]{0,3}$` instead of `^[0-9]{3}$`)
+func (ecfg EditBoxConfig) Mask(mask *regexp.Regexp) EditBoxConfig {
+	ecfg.mask = mask
+	return ecfg
+}
+
+// OnChange specifies the function, which is called every time the text is changed by the user
+func (ecfg EditBoxConfig) OnChange(onChange func(text string)) EditBoxConfig {
+	ecfg.onChange = onChange
+	return ecfg
+}
+
+// OnSubmit specifies the function, which is called when the user presses Enter
+func (ecfg EditBoxConfig) OnSubmit(onSubmit func(text string)) EditBoxConfig {
+	ecfg.onSubmit = onSubmit
+	return ecfg
 }
 
-func NewEditBox(owner raywin.Container) (*EditBox, error) {
+// NewEditBox creates the new EditBox owned by `owner` with the `cfg` settings
+func NewEditBox(owner raywin.Container, cfg EditBoxConfig) (*EditBox, error) {
 	eb := &EditBox{}
-	eb.SetText("ghpy")
-	eb.SetBounds(rl.RectangleInt32{X: 0, Y: 0, Width: 100, Height: 100})
-	return eb, eb.Init(owner, eb)
+	eb.init(cfg)
+	err := eb.Init(owner, eb)
+	eb.SetBounds(cfg.rect)
+	return eb, err
 }
 
+func (eb *EditBox) init(cfg EditBoxConfig) {
+	eb.cfg = cfg
+	eb.text = []rune(cfg.text)
+	eb.caret = len(eb.text)
+	eb.anchor = eb.caret
+}
+
+// SetBounds changes the component position and its width. The height is taken from Style
 func (eb *EditBox) SetBounds(r rl.RectangleInt32) {
-	r.Height = int32(S.PPcm * S.EditBoxHeightMm / 10.0)
+	r.Height = int32(raywin.Mm(S.EditBoxHeightMm).Pixels(S.PPI))
 	r.Width = max(r.Width, r.Height)
 	eb.BaseComponent.SetBounds(r)
 }
 
+// SetText replaces the EditBox text and moves the caret to the end of the text. The
+// text is not checked against the EditBox restrictions and OnChange is not called.
 func (eb *EditBox) SetText(s string) {
-	eb.text.Store(s)
+	eb.lock.Lock()
+	defer eb.lock.Unlock()
+	eb.text = []rune(s)
+	eb.caret = len(eb.text)
+	eb.anchor = eb.caret
 }
 
+// Text returns the EditBox text
 func (eb *EditBox) Text() string {
-	return eb.text.Load().(string)
+	eb.lock.Lock()
+	defer eb.lock.Unlock()
+	return string(eb.text)
+}
+
+// Caret returns the caret position (in characters)
+func (eb *EditBox) Caret() int {
+	eb.lock.Lock()
+	defer eb.lock.Unlock()
+	return eb.caret
+}
+
+// SetCaret moves the caret to the position pos and drops the selection
+func (eb *EditBox) SetCaret(pos int) {
+	eb.SetSelection(pos, pos)
+}
+
+// Selection returns the selected text range [start, end). start == end if nothing is selected
+func (eb *EditBox) Selection() (int, int) {
+	eb.lock.Lock()
+	defer eb.lock.Unlock()
+	return eb.selection()
+}
+
+// SetSelection selects the text from the anchor position to the caret position,
+// the caret is placed to the caret position
+func (eb *EditBox) SetSelection(anchor, caret int) {
+	eb.lock.Lock()
+	defer eb.lock.Unlock()
+	eb.anchor = max(0, min(anchor, len(eb.text)))
+	eb.caret = max(0, min(caret, len(eb.text)))
+}
+
+// SelectAll selects the whole text
+func (eb *EditBox) SelectAll() {
+	eb.SetSelection(0, len(eb.Text()))
+}
+
+// InsertText inserts the text s in the caret position, replacing the selection (if any).
+// The function returns false if the result violates the EditBox restrictions, the text is
+// not changed then.
+func (eb *EditBox) InsertText(s string) bool {
+	return eb.edit(func() bool {
+		start, end := eb.selection()
+		return eb.replace(start, end, []rune(s))
+	})
+}
+
+// DeleteBackward deletes the selection or the character before the caret (Backspace)
+func (eb *EditBox) DeleteBackward() bool {
+	return eb.edit(func() bool {
+		start, end := eb.selection()
+		if start == end {
+			start = max(0, start-1)
+		}
+		return start != end && eb.replace(start, end, nil)
+	})
+}
+
+// DeleteForward deletes the selection or the character after the caret (Delete)
+func (eb *EditBox) DeleteForward() bool {
+	return eb.edit(func() bool {
+		start, end := eb.selection()
+		if start == end {
+			end = min(len(eb.text), end+1)
+		}
+		return start != end && eb.replace(start, end, nil)
+	})
+}
+
+// Submit calls the OnSubmit callback with the current text
+func (eb *EditBox) Submit() {
+	if eb.cfg.onSubmit != nil {
+		eb.cfg.onSubmit(eb.Text())
+	}
+}
+
+// KeyboardLayout implements TextInput, the numeric EditBox prefers the numeric keypad
+func (eb *EditBox) KeyboardLayout() string {
+	if eb.cfg.flags&EditBoxNumeric != 0 {
+		return VKLayoutNumeric
+	}
+	return ""
+}
+
+// OnFocusChanged implements raywin.Focusable
+func (eb *EditBox) OnFocusChanged(focused bool) {
+	eb.lock.Lock()
+	defer eb.lock.Unlock()
+	eb.focused = focused
+	eb.restartBlink()
 }
 
+// OnKeyEvent implements raywin.Focusable
+func (eb *EditBox) OnKeyEvent(ke raywin.KeyEvent) bool {
+	if ke.IsChar() {
+		if ke.Modifiers&(raywin.KeyModControl|raywin.KeyModAlt|raywin.KeyModSuper) == 0 {
+			eb.InsertText(string(ke.Char))
+		}
+		return true
+	}
+	shift := ke.Modifiers&raywin.KeyModShift != 0
+	switch ke.Key {
+	case rl.KeyBackspace:
+		eb.DeleteBackward()
+	case rl.KeyDelete:
+		eb.DeleteForward()
+	case rl.KeyLeft:
+		eb.moveCaret(-1, shift)
+	case rl.KeyRight:
+		eb.moveCaret(1, shift)
+	case rl.KeyHome:
+		eb.moveCaret(-len(eb.Text()), shift)
+	case rl.KeyEnd:
+		eb.moveCaret(len(eb.Text()), shift)
+	case rl.KeyA:
+		if ke.Modifiers&(raywin.KeyModControl|raywin.KeyModSuper) == 0 {
+			return false
+		}
+		eb.SelectAll()
+	case rl.KeyEnter, rl.KeyKpEnter:
+		eb.Submit()
+	default:
+		return false
+	}
+	return true
+}
+
+// OnTPState implements raywin.Touchpadable. The tap moves the caret to the tap
+// position and the moving touch point selects the text.
+func (eb *EditBox) OnTPState(tps raywin.TPState) raywin.OnTPSResult {
+	switch tps.State {
+	case raywin.TPStatePressed:
+		if tps.Sequence == eb.tapSeq {
+			return raywin.OnTPSResultLocked
+		}
+		eb.tapSeq = tps.Sequence
+		eb.setTap(tps.Pos.X, false)
+		if app := raywin.AppOf(eb); app.Focused() != eb {
+			app.SetFocus(eb)
+		}
+		return raywin.OnTPSResultLocked
+	case raywin.TPStateMoving:
+		if eb.tapSeq == 0 {
+			return raywin.OnTPSResultNA
+		}
+		eb.setTap(tps.Pos.X, true)
+		return raywin.OnTPSResultLocked
+	}
+	eb.tapSeq = 0
+	return raywin.OnTPSResultNA
+}
+
+// Draw renders the EditBox
 func (eb *EditBox) Draw(cc *raywin.CanvasContext) {
-	txt := eb.text.Load().(string)
+	eb.lock.Lock()
+	defer eb.lock.Unlock()
+
+	font := raywin.AppOf(eb).SystemFont(int(S.EditBoxFontSize))
+	txt := eb.displayText()
+	str := string(txt)
 	bi := eb.Bounds()
 	b := bi.ToFloat32()
 	x, y := cc.PhysicalPointXY(0, 0)
 	b.X, b.Y = float32(x), float32(y)
-	spacer := S.PPcm * S.EditBoxSpacerMm / 10.0
-	v := rl.MeasureTextEx(raywin.SystemFont(int(S.EditBoxFontSize)), txt, S.EditBoxFontSize, 0)
+	spacer := raywin.Mm(S.EditBoxSpacerMm).Pixels(S.PPI)
 	e := rl.Rectangle{X: float32(x) + b.Height/4.0, Y: float32(y) + spacer, Width: b.Width - b.Height/2, Height: b.Height - 2*spacer}
-	curPos := v.X
-	curPos = max(0.0, min(curPos, e.Width))
-	curPos += e.X
-	rl.DrawRectangleRounded(b, 0.5, 10, S.EditBoxOutlineColor)
+
+	offsets := eb.textOffsets(cc, font, txt, str)
+	if eb.tapActive {
+		eb.caret = nearestOffset(offsets, eb.tapPos-e.X+eb.scroll)
+		if !eb.tapExtend {
+			eb.anchor = eb.caret
+		}
+		eb.tapActive = false
+		eb.restartBlink()
+	}
+	eb.scroll = scrollToCaret(eb.scroll, offsets[eb.caret], offsets[len(txt)], e.Width)
+
+	cc.DrawRectangleRounded(b, 0.5, 10, S.EditBoxOutlineColor)
 	b.X += 2
 	b.Y += 2
 	b.Width -= 4
 	b.Height -= 4
-	rl.DrawRectangleRounded(b, 0.5, 10, S.EditBoxBackgoundColor)
-	if v.X > 0.0 {
-		rl.BeginScissorMode(int32(e.X), int32(e.Y), int32(e.Width), int32(e.Height))
-		rl.DrawTextEx(raywin.SystemFont(int(S.EditBoxFontSize)), txt, rl.Vector2{X: curPos - v.X, Y: e.Y}, S.EditBoxFontSize, 0.0, S.EditBoxTextColor)
-		rl.BeginScissorMode(int32(b.X), int32(b.Y), int32(b.Width), int32(b.Height))
-	}
-	m := raywin.Millis() % 1000
-	col := S.EditBoxTextColor
-	if m > 655 {
-		col.A = 0
-	} else if m > 400 {
-		col.A = uint8(655 - int(m))
-	}
-	if col.A > 0 {
-		rl.DrawRectangleV(rl.Vector2{X: curPos, Y: e.Y}, rl.Vector2{X: S.CurorWidth, Y: e.Height}, col)
+	cc.DrawRectangleRounded(b, 0.5, 10, S.EditBoxBackgoundColor)
+	cc.BeginScissorMode(rl.RectangleInt32{X: int32(e.X), Y: int32(e.Y), Width: int32(e.Width), Height: int32(e.Height)})
+	defer cc.EndScissorMode()
+	if start, end := eb.selection(); start != end {
+		sx := e.X + offsets[start] - eb.scroll
+		cc.DrawRectangleRec(rl.Rectangle{X: sx, Y: e.Y, Width: offsets[end] - offsets[start], Height: e.Height}, S.EditBoxSelectColor)
+	}
+	if len(txt) > 0 {
+		cc.DrawText(font, str, rl.Vector2{X: e.X - eb.scroll, Y: e.Y}, S.EditBoxFontSize, 0.0, S.EditBoxTextColor)
+	}
+	if eb.focused && eb.caretOn {
+		curPos := min(e.X+offsets[eb.caret]-eb.scroll, e.X+e.Width-S.CurorWidth)
+		cc.DrawRectangleRec(rl.Rectangle{X: curPos, Y: e.Y, Width: S.CurorWidth, Height: e.Height}, S.EditBoxTextColor)
+	}
+}
+
+// textOffsets returns the x-offset for every caret position in the text txt (str is the
+// same text as string). The offsets are cached until the text, the font or its size are
+// changed. Must be called under the lock
+func (eb *EditBox) textOffsets(cc *raywin.CanvasContext, font rl.Font, txt []rune, str string) []float32 {
+	if eb.offsets != nil && eb.offsetsText == str && eb.offsetsFont == font && eb.offsetsSize == S.EditBoxFontSize {
+		return eb.offsets
+	}
+	offsets := make([]float32, len(txt)+1)
+	for i := 1; i <= len(txt); i++ {
+		offsets[i] = cc.MeasureText(font, string(txt[:i]), S.EditBoxFontSize, 0).X
+	}
+	eb.offsets, eb.offsetsText, eb.offsetsFont, eb.offsetsSize = offsets, str, font, S.EditBoxFontSize
+	return offsets
+}
+
+// restartBlink shows the caret and restarts the blink timer, if the EditBox is focused. If
+// the timer cannot be started (the App is not initialized), the caret stays solid. Must be
+// called under the lock
+func (eb *EditBox) restartBlink() {
+	if eb.blink != nil {
+		eb.blink.Cancel()
+		eb.blink = nil
+	}
+	eb.caretOn = true
+	if !eb.focused {
+		return
+	}
+	t, err := raywin.AppOf(eb).Every(eb, EditBoxCaretBlinkMillis, func() {
+		eb.lock.Lock()
+		defer eb.lock.Unlock()
+		eb.caretOn = !eb.caretOn
+	})
+	if err == nil {
+		eb.blink = t
+	}
+}
+
+func (eb *EditBox) setTap(x float32, extend bool) {
+	eb.lock.Lock()
+	defer eb.lock.Unlock()
+	eb.tapPos = x
+	eb.tapExtend = extend
+	eb.tapActive = true
+}
+
+func (eb *EditBox) moveCaret(delta int, extend bool) {
+	eb.lock.Lock()
+	defer eb.lock.Unlock()
+	start, end := eb.selection()
+	switch {
+	case !extend && start != end && delta < 0:
+		eb.caret = start
+	case !extend && start != end:
+		eb.caret = end
+	default:
+		eb.caret = max(0, min(len(eb.text), eb.caret+delta))
+	}
+	if !extend {
+		eb.anchor = eb.caret
+	}
+	eb.restartBlink()
+}
+
+// edit runs the modification f under the lock and notifies OnChange if the text is changed
+func (eb *EditBox) edit(f func() bool) bool {
+	eb.lock.Lock()
+	changed := f()
+	txt := string(eb.text)
+	eb.restartBlink()
+	eb.lock.Unlock()
+	if changed && eb.cfg.onChange != nil {
+		eb.cfg.onChange(txt)
+	}
+	return changed
+}
+
+// replace replaces the text in [start, end) by rs if the result is acceptable. Must be called under the lock
+func (eb *EditBox) replace(start, end int, rs []rune) bool {
+	nt := make([]rune, 0, len(eb.text)-(end-start)+len(rs))
+	nt = append(nt, eb.text[:start]...)
+	nt = append(nt, rs...)
+	nt = append(nt, eb.text[end:]...)
+	if !eb.accept(nt) {
+		return false
+	}
+	eb.text = nt
+	eb.caret = start + len(rs)
+	eb.anchor = eb.caret
+	return true
+}
+
+func (eb *EditBox) accept(text []rune) bool {
+	if eb.cfg.maxLength > 0 && len(text) > eb.cfg.maxLength {
+		return false
+	}
+	if eb.cfg.flags&EditBoxNumeric != 0 && !numericRegexp.MatchString(string(text)) {
+		return false
+	}
+	return eb.cfg.mask == nil || eb.cfg.mask.MatchString(string(text))
+}
+
+func (eb *EditBox) selection() (int, int) {
+	return min(eb.anchor, eb.caret), max(eb.anchor, eb.caret)
+}
+
+func (eb *EditBox) displayText() []rune {
+	if eb.cfg.flags&EditBoxPassword != 0 {
+		return []rune(strings.Repeat("*", len(eb.text)))
+	}
+	return eb.text
+}
+
+// nearestOffset returns the index of the offset closest to x
+func nearestOffset(offsets []float32, x float32) int {
+	for i := 1; i < len(offsets); i++ {
+		if x < (offsets[i-1]+offsets[i])/2 {
+			return i - 1
+		}
+	}
+	return len(offsets) - 1
+}
+
+// scrollToCaret returns the new text scroll value, so the caret position is visible within the width
+func scrollToCaret(scroll, caretX, textWidth, width float32) float32 {
+	scroll = min(scroll, max(0, textWidth-width))
+	if caretX-scroll > width {
+		scroll = caretX - width
+	}
+	if caretX < scroll {
+		scroll = caretX
 	}
+	return max(0, scroll)
 }
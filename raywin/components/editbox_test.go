@@ -0,0 +1,188 @@
+package components
+
+import (
+	"github.com/dspasibenko/raywin-go/raywin"
+	rl "github.com/gen2brain/raylib-go/raylib"
+	"github.com/stretchr/testify/assert"
+	"regexp"
+	"testing"
+)
+
+func newTestEditBox(cfg EditBoxConfig) *EditBox {
+	eb := &EditBox{}
+	eb.init(cfg)
+	return eb
+}
+
+func TestEditBox_Edit(t *testing.T) {
+	var changes []string
+	eb := newTestEditBox(DefaultEditBoxConfig().Text("abc").OnChange(func(text string) {
+		changes = append(changes, text)
+	}))
+	assert.Equal(t, 3, eb.Caret())
+	assert.True(t, eb.InsertText("d"))
+	assert.Equal(t, "abcd", eb.Text())
+	eb.SetCaret(1)
+	assert.True(t, eb.InsertText("XY"))
+	assert.Equal(t, "aXYbcd", eb.Text())
+	assert.Equal(t, 3, eb.Caret())
+	assert.True(t, eb.DeleteBackward())
+	assert.Equal(t, "aXbcd", eb.Text())
+	assert.True(t, eb.DeleteForward())
+	assert.Equal(t, "aXcd", eb.Text())
+	assert.Equal(t, 2, eb.Caret())
+
+	eb.SetCaret(0)
+	assert.False(t, eb.DeleteBackward())
+	eb.SetCaret(100)
+	assert.Equal(t, 4, eb.Caret())
+	assert.False(t, eb.DeleteForward())
+	assert.Equal(t, []string{"abcd", "aXYbcd", "aXbcd", "aXcd"}, changes)
+
+	eb.SetText("hello")
+	assert.Equal(t, 5, eb.Caret())
+	assert.Equal(t, 4, len(changes))
+}
+
+func TestEditBox_Selection(t *testing.T) {
+	eb := newTestEditBox(DefaultEditBoxConfig().Text("hello world"))
+	eb.SetSelection(6, 100)
+	s, e := eb.Selection()
+	assert.Equal(t, 6, s)
+	assert.Equal(t, 11, e)
+	assert.True(t, eb.InsertText("all"))
+	assert.Equal(t, "hello all", eb.Text())
+
+	eb.SetSelection(5, 0)
+	assert.True(t, eb.DeleteBackward())
+	assert.Equal(t, " all", eb.Text())
+
+	eb.SelectAll()
+	assert.True(t, eb.DeleteForward())
+	assert.Equal(t, "", eb.Text())
+}
+
+func TestEditBox_Restrictions(t *testing.T) {
+	eb := newTestEditBox(DefaultEditBoxConfig().MaxLength(3))
+	assert.True(t, eb.InsertText("abc"))
+	assert.False(t, eb.InsertText("d"))
+	assert.Equal(t, "abc", eb.Text())
+
+	eb = newTestEditBox(DefaultEditBoxConfig().Flags(EditBoxNumeric))
+	assert.True(t, eb.InsertText("-12"))
+	assert.False(t, eb.InsertText("a"))
+	assert.True(t, eb.InsertText(".5"))
+	assert.False(t, eb.InsertText("."))
+	assert.Equal(t, "-12.5", eb.Text())
+
+	eb = newTestEditBox(DefaultEditBoxConfig().Mask(regexp.MustCompile(`^[A-F]{0,2}$`)))
+	assert.True(t, eb.InsertText("A"))
+	assert.False(t, eb.InsertText("G"))
+	assert.True(t, eb.InsertText("F"))
+	assert.False(t, eb.InsertText("A"))
+	assert.Equal(t, "AF", eb.Text())
+
+	eb = newTestEditBox(DefaultEditBoxConfig().Text("secret").Flags(EditBoxPassword))
+	assert.Equal(t, "******", string(eb.displayText()))
+	assert.Equal(t, "secret", eb.Text())
+}
+
+func TestEditBox_OnKeyEvent(t *testing.T) {
+	submitted := ""
+	eb := newTestEditBox(DefaultEditBoxConfig().Text("ab").OnSubmit(func(text string) { submitted = text }))
+	assert.True(t, eb.OnKeyEvent(raywin.KeyEvent{Char: 'c'}))
+	assert.True(t, eb.OnKeyEvent(raywin.KeyEvent{Char: 'x', Modifiers: raywin.KeyModControl}))
+	assert.Equal(t, "abc", eb.Text())
+
+	assert.True(t, eb.OnKeyEvent(raywin.KeyEvent{Key: rl.KeyLeft}))
+	assert.True(t, eb.OnKeyEvent(raywin.KeyEvent{Key: rl.KeyLeft, Modifiers: raywin.KeyModShift}))
+	s, e := eb.Selection()
+	assert.Equal(t, 1, s)
+	assert.Equal(t, 2, e)
+	assert.True(t, eb.OnKeyEvent(raywin.KeyEvent{Key: rl.KeyRight}))
+	assert.Equal(t, 2, eb.Caret())
+	assert.True(t, eb.OnKeyEvent(raywin.KeyEvent{Key: rl.KeyHome}))
+	assert.Equal(t, 0, eb.Caret())
+	assert.True(t, eb.OnKeyEvent(raywin.KeyEvent{Key: rl.KeyEnd, Modifiers: raywin.KeyModShift}))
+	s, e = eb.Selection()
+	assert.Equal(t, 0, s)
+	assert.Equal(t, 3, e)
+	assert.True(t, eb.OnKeyEvent(raywin.KeyEvent{Key: rl.KeyDelete}))
+	assert.Equal(t, "", eb.Text())
+
+	assert.True(t, eb.OnKeyEvent(raywin.KeyEvent{Char: 'q'}))
+	assert.False(t, eb.OnKeyEvent(raywin.KeyEvent{Key: rl.KeyA}))
+	assert.True(t, eb.OnKeyEvent(raywin.KeyEvent{Key: rl.KeyA, Modifiers: raywin.KeyModControl}))
+	assert.True(t, eb.OnKeyEvent(raywin.KeyEvent{Key: rl.KeyBackspace}))
+	assert.Equal(t, "", eb.Text())
+
+	assert.True(t, eb.OnKeyEvent(raywin.KeyEvent{Char: 'z'}))
+	assert.True(t, eb.OnKeyEvent(raywin.KeyEvent{Key: rl.KeyEnter}))
+	assert.Equal(t, "z", submitted)
+	assert.False(t, eb.OnKeyEvent(raywin.KeyEvent{Key: rl.KeyTab}))
+}
+
+func Test_nearestOffset(t *testing.T) {
+	offs := []float32{0, 10, 20, 30}
+	assert.Equal(t, 0, nearestOffset(offs, -5))
+	assert.Equal(t, 0, nearestOffset(offs, 4))
+	assert.Equal(t, 1, nearestOffset(offs, 6))
+	assert.Equal(t, 2, nearestOffset(offs, 24))
+	assert.Equal(t, 3, nearestOffset(offs, 100))
+	assert.Equal(t, 0, nearestOffset([]float32{0}, 100))
+}
+
+func Test_scrollToCaret(t *testing.T) {
+	assert.Equal(t, float32(0), scrollToCaret(0, 50, 80, 100))
+	assert.Equal(t, float32(50), scrollToCaret(0, 150, 150, 100))
+	assert.Equal(t, float32(20), scrollToCaret(50, 20, 150, 100))
+	assert.Equal(t, float32(0), scrollToCaret(50, 20, 60, 100))
+}
+
+func TestEditBox_caretBlink(t *testing.T) {
+	h := newTestDialogHarness(t)
+	eb, err := NewEditBox(h.App().RootContainer(), DefaultEditBoxConfig().Rectangle(rl.RectangleInt32{Width: 200}))
+	assert.Nil(t, err)
+	assert.Nil(t, h.App().SetFocus(eb))
+	assert.Nil(t, h.Step(1))
+	assert.True(t, eb.caretOn)
+	assert.Nil(t, h.StepMillis(EditBoxCaretBlinkMillis))
+	assert.False(t, eb.caretOn)
+	assert.Nil(t, h.StepMillis(EditBoxCaretBlinkMillis))
+	assert.True(t, eb.caretOn)
+	assert.Nil(t, h.StepMillis(EditBoxCaretBlinkMillis))
+	assert.False(t, eb.caretOn)
+
+	// the caret is solid right after the edit
+	h.Proxy().TypeText("a")
+	assert.Nil(t, h.Step(1))
+	assert.Equal(t, "a", eb.Text())
+	assert.True(t, eb.caretOn)
+	assert.Nil(t, h.StepMillis(EditBoxCaretBlinkMillis/2))
+	assert.True(t, eb.caretOn)
+
+	// the timer is stopped without the focus
+	blink := eb.blink
+	assert.Nil(t, h.App().SetFocus(nil))
+	assert.False(t, blink.IsActive())
+	assert.Nil(t, eb.blink)
+}
+
+func TestEditBox_textOffsets(t *testing.T) {
+	h := newTestDialogHarness(t)
+	eb, err := NewEditBox(h.App().RootContainer(), DefaultEditBoxConfig().Text("ab").Rectangle(rl.RectangleInt32{Width: 200}))
+	assert.Nil(t, err)
+	assert.Nil(t, h.Step(1))
+	offsets := eb.offsets
+	assert.Len(t, offsets, 3)
+	assert.True(t, offsets[0] < offsets[1] && offsets[1] < offsets[2])
+
+	// the offsets are not measured again while the text is the same
+	assert.Nil(t, h.Step(2))
+	assert.Same(t, &offsets[0], &eb.offsets[0])
+
+	eb.SetText("abc")
+	assert.Nil(t, h.Step(1))
+	assert.Len(t, eb.offsets, 4)
+	assert.Equal(t, offsets, eb.offsets[:3])
+}
@@ -0,0 +1,104 @@
+package components
+
+// Copyright 2025 Dmitry Spasibenko
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+import (
+	"github.com/dspasibenko/raywin-go/raywin"
+	"github.com/dspasibenko/raywin-go/raywin/raywintest"
+	rl "github.com/gen2brain/raylib-go/raylib"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+// the rendering may slightly change with the antialiasing of the circles and the easing
+// calculations, so the goldens are compared with the tolerance
+const (
+	goldenTolerance = 8
+	goldenMaxDiff   = 16
+)
+
+func newTestGoldenHarness(t *testing.T) *raywintest.Harness {
+	cfg := raywin.DefaultConfig()
+	cfg.DisplayConfig.Width, cfg.DisplayConfig.Height = 160, 100
+	cfg.FrameListener = DefaultStyleOutlet(cfg.DisplayConfig)
+	h, err := raywintest.NewHarness(cfg)
+	assert.Nil(t, err)
+	t.Cleanup(func() { assert.Nil(t, h.Close()) })
+	return h
+}
+
+func TestButton_golden(t *testing.T) {
+	h := newTestGoldenHarness(t)
+	b, err := NewButton(h.App().RootContainer(), rl.RectangleInt32{X: 20, Y: 20, Width: 120, Height: 60}, "OK", DialogButtonOkStyle(), nil)
+	assert.Nil(t, err)
+	assert.Nil(t, h.Step(2))
+	assert.Nil(t, raywin.MatchGolden(h.Image(), "testdata/golden/button.png", goldenTolerance, goldenMaxDiff))
+
+	// the touch is held till the press delay is over
+	h.Proxy().Touch(1, rl.Vector2{X: 80, Y: 50})
+	assert.Nil(t, h.StepMillis(500))
+	assert.True(t, b.Pressed())
+	assert.Nil(t, raywin.MatchGolden(h.Image(), "testdata/golden/button_pressed.png", goldenTolerance, goldenMaxDiff))
+	h.Proxy().Release(1)
+	assert.Nil(t, h.Step(1))
+}
+
+func TestToggle_golden(t *testing.T) {
+	h := newTestGoldenHarness(t)
+	tg, err := NewToggle(h.App().RootContainer(), nil)
+	assert.Nil(t, err)
+	tg.SetBounds(rl.RectangleInt32{X: 20, Y: 20})
+	assert.Nil(t, h.Step(2))
+	assert.Nil(t, raywin.MatchGolden(h.Image(), "testdata/golden/toggle_off.png", goldenTolerance, goldenMaxDiff))
+
+	// the toggle is held till the press delay is over, and the animation of the ball is completed
+	b := tg.Bounds()
+	assert.Nil(t, h.LongPress(rl.Vector2{X: float32(b.X + b.Width/2), Y: float32(b.Y + b.Height/2)}, S.TogglePressMillis+50))
+	assert.Nil(t, h.StepMillis(300))
+	assert.True(t, tg.IsOn())
+	assert.Nil(t, raywin.MatchGolden(h.Image(), "testdata/golden/toggle_on.png", goldenTolerance, goldenMaxDiff))
+}
+
+type _golden_test_stripe struct {
+	raywin.BaseComponent
+	col rl.Color
+}
+
+func (s *_golden_test_stripe) Draw(cc *raywin.CanvasContext) {
+	b := s.Bounds()
+	x, y := cc.PhysicalPointXY(0, 0)
+	cc.DrawRectangle(rl.RectangleInt32{X: x, Y: y, Width: b.Width, Height: b.Height}, s.col)
+}
+
+func TestScrollableContainer_golden(t *testing.T) {
+	h := newTestGoldenHarness(t)
+	var sc ScrollableContainer
+	assert.Nil(t, sc.InitScrollableContainer(h.App().RootContainer(), &sc, raywin.ScrollVertical|ShowVerticalScrollBar))
+	sc.SetBounds(rl.RectangleInt32{Width: 160, Height: 100})
+	sc.SetVirtualBounds(rl.RectangleInt32{Width: 160, Height: 300})
+	for i, col := range []rl.Color{rl.Maroon, rl.DarkGreen, rl.DarkBlue, rl.Gold, rl.Purple, rl.Orange} {
+		s := &_golden_test_stripe{col: col}
+		assert.Nil(t, s.Init(&sc, s))
+		s.SetBounds(rl.RectangleInt32{Y: int32(i) * 50, Width: 160, Height: 50})
+	}
+	assert.Nil(t, h.Step(2))
+	assert.Nil(t, raywin.MatchGolden(h.Image(), "testdata/golden/scrollablecontainer.png", goldenTolerance, goldenMaxDiff))
+
+	// the scroll bar is shown for a while after the drag is over
+	assert.Nil(t, h.Drag(rl.Vector2{X: 80, Y: 90}, rl.Vector2{X: 80, Y: 30}, 6))
+	assert.Nil(t, h.Step(1))
+	assert.Greater(t, sc.Offset().Y, int32(0))
+	assert.Nil(t, raywin.MatchGolden(h.Image(), "testdata/golden/scrollablecontainer_scrolled.png", goldenTolerance, goldenMaxDiff))
+}
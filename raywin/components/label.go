@@ -28,11 +28,11 @@ type LabelConfig struct {
 	backgroundColor color.RGBA
 }
 
-// DefaultLabelConfig returns the config with bottom left text alignment. The
-// text will have the white color and size 32ppt. Default region is {0, 0, 100, 100}
+// DefaultLabelConfig returns the config with bottom left text alignment. The text
+// will have the white color and size 32ppt, it is drawn by the system font of the
+// label App. Default region is {0, 0, 100, 100}
 func DefaultLabelConfig() LabelConfig {
 	return LabelConfig{
-		font:      raywin.SystemFont(32),
 		fontSize:  32,
 		rect:      rl.RectangleInt32{X: 0, Y: 0, Width: 100, Height: 100},
 		textColor: rl.White,
@@ -45,7 +45,7 @@ func (lcfg LabelConfig) Alignment(flags int) LabelConfig {
 	return lcfg
 }
 
-// Font allows to change the label font
+// Font allows to change the label font, the system font is used if it is not specified
 func (lcfg LabelConfig) Font(font rl.Font) LabelConfig {
 	lcfg.font = font
 	return lcfg
@@ -109,7 +109,7 @@ func (l *Label) Draw(cc *raywin.CanvasContext) {
 	if l.cacheV == nil {
 		l.cacheV = &rl.Vector2{}
 		r := l.Bounds()
-		v := rl.MeasureTextEx(l.cfg.font, txt, l.cfg.fontSize, 0)
+		v := cc.MeasureText(l.font(), txt, l.cfg.fontSize, 0)
 		switch l.cfg.alignment & 3 {
 		case AlignBottom:
 			l.cacheV.Y = float32(r.Height) - v.Y
@@ -126,7 +126,15 @@ func (l *Label) Draw(cc *raywin.CanvasContext) {
 	x, y := cc.PhysicalPointXY(0, 0)
 	if l.cfg.backgroundColor.A != 0 {
 		b := l.Bounds()
-		rl.DrawRectangle(x, y, b.Width, b.Height, l.cfg.backgroundColor)
+		cc.DrawRectangle(rl.RectangleInt32{X: x, Y: y, Width: b.Width, Height: b.Height}, l.cfg.backgroundColor)
 	}
-	rl.DrawTextEx(l.cfg.font, txt, rl.Vector2{X: float32(x) + l.cacheV.X, Y: float32(y) + l.cacheV.Y}, l.cfg.fontSize, 0, l.cfg.textColor)
+	cc.DrawText(l.font(), txt, rl.Vector2{X: float32(x) + l.cacheV.X, Y: float32(y) + l.cacheV.Y}, l.cfg.fontSize, 0, l.cfg.textColor)
+}
+
+// font returns the config font, or the system font of the label App if it is not specified
+func (l *Label) font() rl.Font {
+	if l.cfg.font.BaseSize != 0 {
+		return l.cfg.font
+	}
+	return raywin.AppOf(l).SystemFont(int(l.cfg.fontSize))
 }
@@ -62,10 +62,16 @@ func (sc *ScrollableContainer) InitScrollableContainer(owner, this raywin.Contai
 	sc.showFlags = flags
 	o := owner.(raywin.Component)
 	c := this.(raywin.Component)
-	if err := sc.InitInertialScroller(c, o.Bounds(), raywin.DefaultInternalScrollerDeceleration(), uint8(flags)&raywin.ScrollBoth); err != nil {
+	// the container is added first, so the scroller is set up for the owner App display
+	if err := sc.Init(owner, c); err != nil {
 		return err
 	}
-	return sc.Init(owner, c)
+	decel := raywin.InertialScrollerDeceleration(raywin.AppOf(c).Config().DisplayConfig.FPS)
+	if err := sc.InitInertialScroller(c, o.Bounds(), decel, uint8(flags)&raywin.ScrollBoth); err != nil {
+		sc.Close()
+		return err
+	}
+	return nil
 }
 
 // OnNewFrame provides the FrameListener implementation
@@ -97,8 +103,8 @@ func (sc *ScrollableContainer) DrawAfter(cc *raywin.CanvasContext) {
 	if sc.showFlags&ScrollBarLightColor != 0 {
 		col = S.ScrollBarLightColor
 	}
-	w := S.ScrollBarThiknessMm * S.PPcm / 10.0
-	space := S.ScrollBarOffsetMm * S.PPcm / 10.0
+	w := raywin.Mm(S.ScrollBarThiknessMm).Pixels(S.PPI)
+	space := raywin.Mm(S.ScrollBarOffsetMm).Pixels(S.PPI)
 	if showHorizontal {
 		b := b0
 		if showVertical {
@@ -124,10 +130,10 @@ func (sc *ScrollableContainer) DrawAfter(cc *raywin.CanvasContext) {
 
 		r := w / 2.0
 		c := rl.Vector2{X: x + r, Y: y + r}
-		rl.DrawCircleSector(c, r, 90, 270, int32(r), col)
-		rl.DrawRectangleV(rl.Vector2{X: x + r, Y: y}, rl.Vector2{X: ln - w, Y: w}, col)
+		cc.DrawCircleSector(c, r, 90, 270, int32(r), col)
+		cc.DrawRectangleRec(rl.Rectangle{X: x + r, Y: y, Width: ln - w, Height: w}, col)
 		c.X += ln - w
-		rl.DrawCircleSector(c, r, 270, 450, int32(r), col)
+		cc.DrawCircleSector(c, r, 270, 450, int32(r), col)
 	}
 	if showVertical {
 		b := b0
@@ -154,10 +160,10 @@ func (sc *ScrollableContainer) DrawAfter(cc *raywin.CanvasContext) {
 
 		r := w / 2.0
 		c := rl.Vector2{X: x + r, Y: y + r}
-		rl.DrawCircleSector(c, r, 180, 360, int32(r), col)
-		rl.DrawRectangleV(rl.Vector2{X: x, Y: y + r}, rl.Vector2{X: w, Y: ln - w}, col)
+		cc.DrawCircleSector(c, r, 180, 360, int32(r), col)
+		cc.DrawRectangleRec(rl.Rectangle{X: x, Y: y + r, Width: w, Height: ln - w}, col)
 		c.Y += ln - w
-		rl.DrawCircleSector(c, r, 180, 0, int32(r), col)
+		cc.DrawCircleSector(c, r, 180, 0, int32(r), col)
 	}
 }
 
@@ -171,7 +177,7 @@ func (sc *ScrollableContainer) shouldDraw() bool {
 	if sc.releaseMillis == 0 {
 		return false
 	}
-	return raywin.Millis()-sc.releaseMillis < int64(S.ScrollBarDisappearMillis)
+	return raywin.AppOf(sc).Millis()-sc.releaseMillis < int64(S.ScrollBarDisappearMillis)
 }
 
 func (sc *ScrollableContainer) autoResize() {
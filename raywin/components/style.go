@@ -65,6 +65,21 @@ type Style struct {
 	EditBoxTextColor      rl.Color
 	EditBoxBackgoundColor rl.Color
 	EditBoxOutlineColor   rl.Color
+	EditBoxSelectColor    rl.Color
+
+	// VirtualKeyboard
+	VirtualKeyboardBackgroundColor rl.Color
+	VirtualKeyboardKeyColor        rl.Color
+	VirtualKeyboardSpecialKeyColor rl.Color
+	VirtualKeyboardTextColor       rl.Color
+	VirtualKeyboardKeyHeightMm     float32
+	VirtualKeyboardKeySpaceMm      float32
+	VirtualKeyboardSlideMillis     int64
+
+	// Dialog
+	DialogOverlayColor rl.Color
+	DialogPaddingMm    float32
+	DialogWidthMm      float32
 
 	// Dimensions
 	PPcm  float32
@@ -90,7 +105,7 @@ type defaultOutlet struct{}
 
 // OnNewFrame implements FrameListener
 func (do defaultOutlet) OnNewFrame(millis int64) {
-	S = s.Load().(Style)
+	S = themes.frameStyle(millis)
 }
 
 // DefaultStyleOutlet returns the FrameListener for managing Style settings within raywin. Use this
@@ -104,10 +119,16 @@ func DefaultStyleOutlet(cfg raywin.DisplayConfig) raywin.FrameListener {
 
 // SetStyle allows to change the style dyncamically
 func SetStyle(newStyle Style) error {
-	s.Store(newStyle)
+	themes.setStyle(newStyle)
 	return nil
 }
 
+// DefaultStyle returns the default Style for the display cfg. It may be used as the base
+// for LoadStyle
+func DefaultStyle(cfg raywin.DisplayConfig) Style {
+	return initDefaultStyle(cfg)
+}
+
 func initDefaultStyle(cfg raywin.DisplayConfig) Style {
 	return Style{
 		FrameColor:            color.RGBA{220, 220, 220, 255},
@@ -149,6 +170,21 @@ func initDefaultStyle(cfg raywin.DisplayConfig) Style {
 		EditBoxTextColor:      rl.Color{R: 240, G: 252, B: 255, A: 255},
 		EditBoxBackgoundColor: rl.Color{R: 73, G: 85, B: 79, A: 255},
 		EditBoxOutlineColor:   rl.Color{R: 147, G: 169, B: 158, A: 255},
+		EditBoxSelectColor:    rl.Color{R: 14, G: 110, B: 138, A: 160},
+
+		// VirtualKeyboard
+		VirtualKeyboardBackgroundColor: color.RGBA{2, 41, 48, 240},
+		VirtualKeyboardKeyColor:        color.RGBA{7, 83, 97, 255},
+		VirtualKeyboardSpecialKeyColor: color.RGBA{4, 60, 70, 255},
+		VirtualKeyboardTextColor:       rl.White,
+		VirtualKeyboardKeyHeightMm:     11.0,
+		VirtualKeyboardKeySpaceMm:      0.7,
+		VirtualKeyboardSlideMillis:     200,
+
+		// Dialog
+		DialogOverlayColor: color.RGBA{0, 0, 0, 150},
+		DialogPaddingMm:    4.0,
+		DialogWidthMm:      120.0,
 
 		// Dimensions
 		PPcm:  cfg.PPI / 2.54,
@@ -0,0 +1,238 @@
+package components
+
+// Copyright 2025 Dmitry Spasibenko
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/dspasibenko/raywin-go/pkg/golibs/config"
+	"github.com/dspasibenko/raywin-go/pkg/golibs/errors"
+	"github.com/dspasibenko/raywin-go/raywin"
+	rl "github.com/gen2brain/raylib-go/raylib"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// styleFade is the cross-fade between two styles played by the defaultOutlet
+type styleFade struct {
+	from, to Style
+	duration int64
+	// start is the millis of the first frame of the fade, it is set by the drawing loop
+	start int64
+}
+
+// themeRegistry holds the registered themes and the style fade being played. As the
+// current style (see S) is process-wide, the registry is process-wide as well: the themes
+// and the fade are shared by all the Apps of the process, the registry is guarded by lock.
+type themeRegistry struct {
+	lock   sync.Mutex
+	themes map[string]Style
+	fade   *styleFade
+}
+
+var (
+	themes = themeRegistry{themes: map[string]Style{}}
+
+	colorType = reflect.TypeOf(rl.Color{})
+)
+
+// LoadStyle reads the Style from the JSON or YAML file (the format is defined by the file
+// extension, see config.Enricher). The file may contain only some fields of the Style, the
+// other fields are taken from the base. The colors are written as "#RRGGBBAA" or "#RRGGBB"
+// strings, the field names are case-insensitive:
+//
+//	{"FrameColor": "#DCDCDCFF", "toggleOnColor": "#10AD37FF", "DialogPaddingMm": 5}
+func LoadStyle(base Style, fileName string) (Style, error) {
+	e := config.NewEnricher(base)
+	if err := e.LoadFromFile(fileName); err != nil {
+		return base, err
+	}
+	return e.Value(), nil
+}
+
+// StyleFileLoader returns the function, which loads the Style from the file by LoadStyle
+// with the base and makes it the current one. It is supposed to be used as
+// raywin.Config.StyleLoader for the style hot reload in the dev mode.
+func StyleFileLoader(base Style) func(fileName string) error {
+	return func(fileName string) error {
+		st, err := LoadStyle(base, fileName)
+		if err != nil {
+			return err
+		}
+		return SetStyle(st)
+	}
+}
+
+// RegisterTheme registers the style st with the name, so the style may be activated by
+// SetTheme. The theme with the same name is replaced. The themes are process-wide, like
+// the current style.
+func RegisterTheme(name string, st Style) {
+	themes.lock.Lock()
+	defer themes.lock.Unlock()
+	themes.themes[name] = st
+}
+
+// Theme returns the registered theme by its name
+func Theme(name string) (Style, bool) {
+	themes.lock.Lock()
+	defer themes.lock.Unlock()
+	st, ok := themes.themes[name]
+	return st, ok
+}
+
+// SetTheme makes the registered theme with the name the current style. If fadeMillis is
+// positive, the colors of the current style are cross-faded to the theme colors within
+// fadeMillis, other fields are switched immediately. The style is changed by the
+// FrameListener returned by DefaultStyleOutlet, so it must be installed. The style and
+// the fade are process-wide, so the theme is switched for all the Apps of the process.
+func SetTheme(name string, fadeMillis int64) error {
+	st, ok := Theme(name)
+	if !ok {
+		return fmt.Errorf("the theme %q is not registered: %w", name, errors.ErrNotExist)
+	}
+	if fadeMillis <= 0 {
+		return SetStyle(st)
+	}
+	themes.lock.Lock()
+	defer themes.lock.Unlock()
+	from, _ := s.Load().(Style)
+	s.Store(st)
+	themes.fade = &styleFade{from: from, to: st, duration: fadeMillis, start: -1}
+	return nil
+}
+
+// setStyle makes st the current style and stops the fade, if any
+func (tr *themeRegistry) setStyle(st Style) {
+	tr.lock.Lock()
+	defer tr.lock.Unlock()
+	s.Store(st)
+	tr.fade = nil
+}
+
+// frameStyle returns the current style for the frame millis
+func (tr *themeRegistry) frameStyle(millis int64) Style {
+	tr.lock.Lock()
+	defer tr.lock.Unlock()
+	if tr.fade == nil {
+		return s.Load().(Style)
+	}
+	st, done := tr.fade.onNewFrame(millis)
+	if done {
+		tr.fade = nil
+	}
+	return st
+}
+
+// fading returns whether the style fade is played
+func (tr *themeRegistry) fading() bool {
+	tr.lock.Lock()
+	defer tr.lock.Unlock()
+	return tr.fade != nil
+}
+
+// ParseColor parses the color written as "#RRGGBBAA" or "#RRGGBB" string
+func ParseColor(str string) (rl.Color, error) {
+	hex, ok := strings.CutPrefix(strings.TrimSpace(str), "#")
+	if !ok || (len(hex) != 6 && len(hex) != 8) {
+		return rl.Color{}, fmt.Errorf("the color %q must be in #RRGGBBAA or #RRGGBB format: %w", str, errors.ErrInvalid)
+	}
+	if len(hex) == 6 {
+		hex += "ff"
+	}
+	v, err := strconv.ParseUint(hex, 16, 32)
+	if err != nil {
+		return rl.Color{}, fmt.Errorf("the color %q must be in #RRGGBBAA or #RRGGBB format: %w", str, errors.ErrInvalid)
+	}
+	return rl.Color{R: uint8(v >> 24), G: uint8(v >> 16), B: uint8(v >> 8), A: uint8(v)}, nil
+}
+
+// FormatColor returns the color as "#RRGGBBAA" string
+func FormatColor(col rl.Color) string {
+	return fmt.Sprintf("#%02X%02X%02X%02X", col.R, col.G, col.B, col.A)
+}
+
+// MarshalJSON writes the Style colors as "#RRGGBBAA" strings
+func (st Style) MarshalJSON() ([]byte, error) {
+	v := reflect.ValueOf(st)
+	res := make(map[string]any, v.NumField())
+	for i := 0; i < v.NumField(); i++ {
+		f := v.Field(i)
+		if f.Type() == colorType {
+			res[v.Type().Field(i).Name] = FormatColor(f.Interface().(rl.Color))
+		} else {
+			res[v.Type().Field(i).Name] = f.Interface()
+		}
+	}
+	return json.Marshal(res)
+}
+
+// UnmarshalJSON reads the Style fields present in data, the colors may be written as
+// "#RRGGBBAA" strings or as {"R":..., "G":..., "B":..., "A":...} objects. The fields, which
+// are not in data, keep their values.
+func (st *Style) UnmarshalJSON(data []byte) error {
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return err
+	}
+	tp := reflect.TypeOf(*st)
+	for k, raw := range fields {
+		f, ok := tp.FieldByNameFunc(func(n string) bool { return strings.EqualFold(n, k) })
+		if !ok || f.Type != colorType || len(raw) == 0 || raw[0] != '"' {
+			continue
+		}
+		var str string
+		if err := json.Unmarshal(raw, &str); err != nil {
+			return err
+		}
+		col, err := ParseColor(str)
+		if err != nil {
+			return fmt.Errorf("invalid value of the field %s: %w", k, err)
+		}
+		if fields[k], err = json.Marshal(col); err != nil {
+			return err
+		}
+	}
+	data, err := json.Marshal(fields)
+	if err != nil {
+		return err
+	}
+	// plainStyle has no UnmarshalJSON, so the fields are read by the json package
+	type plainStyle Style
+	return json.Unmarshal(data, (*plainStyle)(st))
+}
+
+// onNewFrame returns the style for the frame millis while the fade is played
+func (sf *styleFade) onNewFrame(millis int64) (Style, bool) {
+	if sf.start < 0 {
+		sf.start = millis
+	}
+	k := float32(millis-sf.start) / float32(sf.duration)
+	if k >= 1 {
+		return sf.to, true
+	}
+	res := sf.to
+	from := reflect.ValueOf(sf.from)
+	to := reflect.ValueOf(sf.to)
+	rv := reflect.ValueOf(&res).Elem()
+	for i := 0; i < rv.NumField(); i++ {
+		if rv.Field(i).Type() == colorType {
+			col := raywin.LerpColor(from.Field(i).Interface().(rl.Color), to.Field(i).Interface().(rl.Color), k)
+			rv.Field(i).Set(reflect.ValueOf(col))
+		}
+	}
+	return res, false
+}
@@ -0,0 +1,150 @@
+package components
+
+// Copyright 2025 Dmitry Spasibenko
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+import (
+	"encoding/json"
+	"github.com/dspasibenko/raywin-go/pkg/golibs/errors"
+	"github.com/dspasibenko/raywin-go/raywin"
+	rl "github.com/gen2brain/raylib-go/raylib"
+	"github.com/stretchr/testify/assert"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+)
+
+func TestParseColor(t *testing.T) {
+	col, err := ParseColor("#10AD37FF")
+	assert.Nil(t, err)
+	assert.Equal(t, rl.Color{R: 16, G: 173, B: 55, A: 255}, col)
+	col, err = ParseColor(" #0a0b0c ")
+	assert.Nil(t, err)
+	assert.Equal(t, rl.Color{R: 10, G: 11, B: 12, A: 255}, col)
+	assert.Equal(t, "#0A0B0CFF", FormatColor(col))
+
+	for _, s := range []string{"", "10AD37FF", "#10AD37F", "#10AD37FFF", "#10AD3XFF"} {
+		_, err = ParseColor(s)
+		assert.ErrorIs(t, err, errors.ErrInvalid, s)
+	}
+}
+
+func TestStyle_JSON(t *testing.T) {
+	st := DefaultStyle(raywin.DefaultDisplayConfig())
+	buf, err := json.Marshal(st)
+	assert.Nil(t, err)
+	assert.Contains(t, string(buf), `"FrameColor":"#DCDCDCFF"`)
+	var st1 Style
+	assert.Nil(t, json.Unmarshal(buf, &st1))
+	assert.Equal(t, st, st1)
+
+	assert.Nil(t, json.Unmarshal([]byte(`{"frameColor": "#01020304", "ToggleOffColor": {"R": 1, "G": 0, "B": 0, "A": 2}, "DialogPaddingMm": 5}`), &st1))
+	assert.Equal(t, rl.Color{R: 1, G: 2, B: 3, A: 4}, st1.FrameColor)
+	assert.Equal(t, rl.Color{R: 1, A: 2}, st1.ToggleOffColor)
+	assert.Equal(t, float32(5), st1.DialogPaddingMm)
+	assert.Equal(t, st.OutlineColor, st1.OutlineColor)
+
+	assert.ErrorIs(t, json.Unmarshal([]byte(`{"FrameColor": "red"}`), &st1), errors.ErrInvalid)
+}
+
+func TestLoadStyle(t *testing.T) {
+	dir := t.TempDir()
+	base := DefaultStyle(raywin.DefaultDisplayConfig())
+	jf := filepath.Join(dir, "night.json")
+	assert.Nil(t, os.WriteFile(jf, []byte(`{"FrameColor": "#400000FF", "ToggleWidthMm": 25}`), 0644))
+	yf := filepath.Join(dir, "day.yaml")
+	assert.Nil(t, os.WriteFile(yf, []byte("frameColor: \"#FFFFFF80\"\neditBoxFontSize: 40\n"), 0644))
+
+	st, err := LoadStyle(base, jf)
+	assert.Nil(t, err)
+	assert.Equal(t, rl.Color{R: 64, A: 255}, st.FrameColor)
+	assert.Equal(t, float32(25), st.ToggleWidthMm)
+	assert.Equal(t, base.EditBoxFontSize, st.EditBoxFontSize)
+
+	st, err = LoadStyle(base, yf)
+	assert.Nil(t, err)
+	assert.Equal(t, rl.Color{R: 255, G: 255, B: 255, A: 128}, st.FrameColor)
+	assert.Equal(t, float32(40), st.EditBoxFontSize)
+	assert.Equal(t, base.ToggleWidthMm, st.ToggleWidthMm)
+
+	defer SetStyle(base)
+	assert.Nil(t, StyleFileLoader(base)(jf))
+	assert.Equal(t, rl.Color{R: 64, A: 255}, s.Load().(Style).FrameColor)
+	assert.NotNil(t, StyleFileLoader(base)(filepath.Join(dir, "absent.json")))
+	assert.Equal(t, rl.Color{R: 64, A: 255}, s.Load().(Style).FrameColor)
+
+	_, err = LoadStyle(base, filepath.Join(dir, "style.txt"))
+	assert.ErrorIs(t, err, errors.ErrInvalid)
+	_, err = LoadStyle(base, filepath.Join(dir, "absent.json"))
+	assert.NotNil(t, err)
+}
+
+func TestSetTheme(t *testing.T) {
+	cfg := raywin.DefaultDisplayConfig()
+	fl := DefaultStyleOutlet(cfg)
+	day := DefaultStyle(cfg)
+	night := day
+	night.FrameColor = rl.Color{R: 100, A: 255}
+	night.DialogPaddingMm = 10
+	RegisterTheme("day", day)
+	RegisterTheme("night", night)
+	defer SetStyle(day)
+
+	assert.ErrorIs(t, SetTheme("dusk", 0), errors.ErrNotExist)
+	assert.Nil(t, SetTheme("night", 0))
+	fl.OnNewFrame(10)
+	assert.Equal(t, night, S)
+
+	assert.Nil(t, SetTheme("day", 100))
+	fl.OnNewFrame(20)
+	assert.Equal(t, rl.Color{R: 100, A: 255}, S.FrameColor)
+	assert.Equal(t, day.DialogPaddingMm, S.DialogPaddingMm)
+	fl.OnNewFrame(70)
+	assert.Equal(t, raywin.LerpColor(night.FrameColor, day.FrameColor, 0.5), S.FrameColor)
+	fl.OnNewFrame(120)
+	assert.Equal(t, day, S)
+	assert.False(t, themes.fading())
+
+	assert.Nil(t, SetTheme("night", 100))
+	fl.OnNewFrame(200)
+	assert.Nil(t, SetStyle(day))
+	fl.OnNewFrame(210)
+	assert.Equal(t, day, S)
+}
+
+func TestSetTheme_concurrent(t *testing.T) {
+	cfg := raywin.DefaultDisplayConfig()
+	fl := DefaultStyleOutlet(cfg)
+	day := DefaultStyle(cfg)
+	RegisterTheme("day", day)
+	defer SetStyle(day)
+
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		for i := 0; i < 100; i++ {
+			assert.Nil(t, SetTheme("day", 10))
+		}
+	}()
+	for i := int64(0); i < 100; i++ {
+		fl.OnNewFrame(i)
+	}
+	wg.Wait()
+	fl.OnNewFrame(1000)
+	fl.OnNewFrame(1020)
+	assert.Equal(t, day, S)
+	assert.False(t, themes.fading())
+}
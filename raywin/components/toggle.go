@@ -10,36 +10,61 @@ type Toggle struct {
 	raywin.BaseComponent
 	raywin.Pressor
 
-	on        bool
-	pressedAt int64
+	on bool
+	// onChange is called when the state is changed by the user, it is used by BindToggle
+	onChange func(on bool)
+	// ballOffset is the ball position between the previous and the current one (0)
+	ballOffset float32
 }
 
 // NewToggle returns the new Toggle switch
 func NewToggle(owner raywin.Container, onToggle func(newState bool) bool) (*Toggle, error) {
 	t := &Toggle{}
-	t.InitPressor(S.TogglePressRadius, S.TogglePressMillis, func() {
-		t.on = !t.on
-		if onToggle != nil {
-			t.on = onToggle(t.on)
-		}
-		t.pressedAt = raywin.Millis()
-	})
+	t.InitPressor(S.TogglePressRadius, S.TogglePressMillis, func() { t.toggle(onToggle) })
 	t.SetBounds(rl.RectangleInt32{})
 	err := t.Init(owner, t)
 	return t, err
 }
 
+// toggle changes the toggle state by the tap and animates the ball movement
+func (t *Toggle) toggle(onToggle func(newState bool) bool) {
+	t.on = !t.on
+	if onToggle != nil {
+		t.on = onToggle(t.on)
+	}
+	if t.onChange != nil {
+		t.onChange(t.on)
+	}
+	t.ballOffset = 1.0
+	if err := raywin.TweenFloat(t, raywin.DefaultAnimationConfig().Duration(100).Easing(raywin.EaseLinear), 1.0, 0.0,
+		func(v float32) { t.ballOffset = v }).Start(); err != nil {
+		// raywin is not running, the ball is moved without the animation
+		t.ballOffset = 0
+	}
+}
+
+// SetOn changes the toggle state without the animation, onToggle is not called
+func (t *Toggle) SetOn(on bool) {
+	t.on = on
+	t.ballOffset = 0
+}
+
+// IsOn returns the toggle state
+func (t *Toggle) IsOn() bool {
+	return t.on
+}
+
 // SetBounds changes the component position, but not its size. The size is taken from Style
 func (t *Toggle) SetBounds(b rl.RectangleInt32) {
-	b.Width = int32(S.ToggleWidthMm * S.PPcm / 10)
-	b.Height = int32(S.ToggleHeightMm * S.PPcm / 10)
+	b.Width = int32(raywin.Mm(S.ToggleWidthMm).Pixels(S.PPI))
+	b.Height = int32(raywin.Mm(S.ToggleHeightMm).Pixels(S.PPI))
 	t.BaseComponent.SetBounds(b)
 }
 
 // Draw renders the component
 func (t *Toggle) Draw(cc *raywin.CanvasContext) {
 	b := t.Bounds()
-	s := S.ToggleSpaceMm * S.PPcm / 10.0
+	s := raywin.Mm(S.ToggleSpaceMm).Pixels(S.PPI)
 	rad := (float32(b.Height)) / 2.0
 	x, y := cc.PhysicalPointXY(0, 0)
 	r := rl.Rectangle{X: float32(x) + rad, Y: float32(y), Width: float32(b.Width) - 2*rad, Height: float32(b.Height)}
@@ -47,39 +72,39 @@ func (t *Toggle) Draw(cc *raywin.CanvasContext) {
 		r1 := r
 		st := s / 4
 		for i := 0; i < 4; i++ {
-			t.drawFrame(r1, rl.Fade(S.FrameSelectToneColor, 0.3))
+			t.drawFrame(cc, r1, rl.Fade(S.FrameSelectToneColor, 0.3))
 			r1.X += st
 			r1.Y += st
 			r1.Width -= 2 * st
 			r1.Height -= 2 * st
 		}
-		t.drawFrame(r1, S.FrameSelectColor)
+		t.drawFrame(cc, r1, S.FrameSelectColor)
 	}
 	r.X += s
 	r.Y += s
 	r.Width -= 2 * s
 	r.Height -= 2 * s
-	t.drawFrame(r, S.FrameColor)
+	t.drawFrame(cc, r, S.FrameColor)
 	r.Y += 2
 	r.Height -= 4
-	ballOffset := max(0.0, 1.0+float32(t.pressedAt-raywin.Millis())/100)
+	ballOffset := t.ballOffset
 	if t.on {
 		x := r.X + r.Width - float32(r.Width)*ballOffset
-		t.drawFrame(r, S.ToggleOnColor)
+		t.drawFrame(cc, r, S.ToggleOnColor)
 		v := rl.Vector2{X: x, Y: r.Y + r.Height/2}
-		rl.DrawCircleV(v, float32(r.Height)/2-2.0, S.FrameSelectColor)
-		rl.DrawCircleLinesV(v, float32(r.Height)/2-2.0, S.FrameShadeColor)
+		cc.DrawCircle(v, float32(r.Height)/2-2.0, S.FrameSelectColor)
+		cc.DrawCircleLines(v, float32(r.Height)/2-2.0, S.FrameShadeColor)
 	} else {
 		x := r.X + float32(r.Width)*ballOffset
-		t.drawFrame(r, S.ToggleOffColor)
+		t.drawFrame(cc, r, S.ToggleOffColor)
 		v := rl.Vector2{X: x, Y: r.Y + r.Height/2}
-		rl.DrawCircleV(v, float32(r.Height)/2-2.0, S.FrameShadeColor)
+		cc.DrawCircle(v, float32(r.Height)/2-2.0, S.FrameShadeColor)
 	}
 }
 
-func (t *Toggle) drawFrame(r rl.Rectangle, color rl.Color) {
+func (t *Toggle) drawFrame(cc *raywin.CanvasContext, r rl.Rectangle, color rl.Color) {
 	rad := r.Height / 2.0
-	rl.DrawRectangleV(rl.Vector2{X: r.X, Y: r.Y}, rl.Vector2{X: r.Width, Y: r.Height}, color)
-	rl.DrawCircleSector(rl.Vector2{X: r.X, Y: r.Y + rad}, rad, 90, 270, int32(rad), color)
-	rl.DrawCircleSector(rl.Vector2{X: r.X + r.Width, Y: r.Y + rad}, rad, 270, 450, int32(rad), color)
+	cc.DrawRectangleRec(rl.Rectangle{X: r.X, Y: r.Y, Width: r.Width, Height: r.Height}, color)
+	cc.DrawCircleSector(rl.Vector2{X: r.X, Y: r.Y + rad}, rad, 90, 270, int32(rad), color)
+	cc.DrawCircleSector(rl.Vector2{X: r.X + r.Width, Y: r.Y + rad}, rad, 270, 450, int32(rad), color)
 }
@@ -0,0 +1,33 @@
+package components
+
+// Copyright 2025 Dmitry Spasibenko
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestToggle_noAnimation(t *testing.T) {
+	// the toggle is not in an initialized App, so the animation cannot be started
+	tg := &Toggle{}
+	var states []bool
+	tg.toggle(func(newState bool) bool {
+		states = append(states, newState)
+		return newState
+	})
+	assert.Equal(t, []bool{true}, states)
+	assert.True(t, tg.IsOn())
+	assert.Equal(t, float32(0), tg.ballOffset)
+}
@@ -0,0 +1,517 @@
+package components
+
+// Copyright 2025 Dmitry Spasibenko
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/dspasibenko/raywin-go/pkg/golibs/errors"
+	"github.com/dspasibenko/raywin-go/raywin"
+	rl "github.com/gen2brain/raylib-go/raylib"
+	"os"
+	"strings"
+	"sync"
+)
+
+type (
+	// TextInput is the interface implemented by the components which accept the text
+	// typed on the VirtualKeyboard. When the focused component (see raywin.SetFocus())
+	// implements the interface, the VirtualKeyboard shows up and feeds the typed text
+	// into the component. EditBox implements the interface.
+	TextInput interface {
+		raywin.Component
+
+		// InsertText inserts the text s in the current input position
+		InsertText(s string) bool
+		// DeleteBackward deletes the character before the input position
+		DeleteBackward() bool
+		// Submit is called when the user presses the Enter key
+		Submit()
+		// KeyboardLayout returns the name of the preferred VKLayout for the
+		// component, or empty string if the component has no preferences
+		KeyboardLayout() string
+	}
+
+	// VKKey describes a key of the VirtualKeyboard layout
+	VKKey struct {
+		// Label is the text drawn on the key
+		Label string `json:"label"`
+		// Text is the text typed by the key. Label is typed, if the Text is empty
+		Text string `json:"text,omitempty"`
+		// ShiftText is the text typed by the key when the shift is on. If it is empty
+		// the upper-cased Text is typed
+		ShiftText string `json:"shiftText,omitempty"`
+		// Action defines the key function (see VKAction constants below). Empty action
+		// means the key types the Text
+		Action string `json:"action,omitempty"`
+		// Layout is the name of the layout the VKActionLayout key switches to
+		Layout string `json:"layout,omitempty"`
+		// Width is the relative key width. The key has width 1 if it is not specified
+		Width float32 `json:"width,omitempty"`
+	}
+
+	// VKLayout describes the VirtualKeyboard keys layout. The layout is the rows of the keys,
+	// every row takes the whole VirtualKeyboard width, so the keys widths are relative to the
+	// sum of the widths of the row keys.
+	VKLayout struct {
+		Name string    `json:"name"`
+		Rows [][]VKKey `json:"rows"`
+	}
+
+	// VirtualKeyboard is the on-screen keyboard which slides up from the bottom of its
+	// owner, when a TextInput component gains the focus, and slides down when the focus
+	// is moved to a component that is not a TextInput. The owner is supposed to be the
+	// raywin.RootContainer(). The VirtualKeyboard is the raywin.Overlay, so it stays on top
+	// of the owner components and it accepts the touches over the modal ones (e.g. Prompt).
+	//
+	// The VirtualKeyboard supports several layouts (QWERTY, numeric and symbols by default),
+	// which may be loaded from JSON as well (see ParseVKLayouts).
+	VirtualKeyboard struct {
+		raywin.BaseComponent
+
+		lock    sync.Mutex
+		owner   raywin.Component
+		layouts []VKLayout
+		layout  int
+		// shift is one of the vkShift constants
+		shift  int
+		target TextInput
+
+		// progress is the slide position: 0 - hidden, 1 - fully shown
+		progress   float32
+		lastMillis int64
+		origin     raywin.Vector2Int32
+
+		pressed bool
+		pRow    int
+		pCol    int
+	}
+)
+
+const (
+	// VKActionShift key switches the shift: off -> on (for the next key) -> caps lock -> off
+	VKActionShift = "shift"
+	// VKActionBackspace key deletes the character before the input position
+	VKActionBackspace = "backspace"
+	// VKActionEnter key submits the input
+	VKActionEnter = "enter"
+	// VKActionSpace key types the space character
+	VKActionSpace = "space"
+	// VKActionLayout key switches the VirtualKeyboard to the layout provided by the VKKey.Layout
+	VKActionLayout = "layout"
+	// VKActionHide key removes the focus from the TextInput, so the VirtualKeyboard slides down
+	VKActionHide = "hide"
+
+	// VKLayoutQwerty is the name of the default QWERTY layout
+	VKLayoutQwerty = "qwerty"
+	// VKLayoutNumeric is the name of the default numeric keypad layout
+	VKLayoutNumeric = "numeric"
+	// VKLayoutSymbols is the name of the default symbols layout
+	VKLayoutSymbols = "symbols"
+)
+
+const (
+	vkShiftOff = iota
+	vkShiftOn
+	vkShiftCaps
+)
+
+var _ raywin.FrameListener = (*VirtualKeyboard)(nil)
+var _ raywin.Touchpadable = (*VirtualKeyboard)(nil)
+var _ raywin.Overlay = (*VirtualKeyboard)(nil)
+var _ TextInput = (*EditBox)(nil)
+
+// DefaultVKLayouts returns the QWERTY, numeric and symbols layouts
+func DefaultVKLayouts() []VKLayout {
+	chars := func(s string) []VKKey {
+		res := make([]VKKey, 0, len(s))
+		for _, r := range s {
+			res = append(res, VKKey{Label: string(r)})
+		}
+		return res
+	}
+	return []VKLayout{
+		{
+			Name: VKLayoutQwerty,
+			Rows: [][]VKKey{
+				chars("qwertyuiop"),
+				chars("asdfghjkl"),
+				append(append([]VKKey{{Label: "Shift", Action: VKActionShift, Width: 1.5}}, chars("zxcvbnm")...),
+					VKKey{Label: "<-", Action: VKActionBackspace, Width: 1.5}),
+				{{Label: "?123", Action: VKActionLayout, Layout: VKLayoutSymbols, Width: 1.5}, {Label: ","},
+					{Label: " ", Action: VKActionSpace, Width: 4}, {Label: "."},
+					{Label: "Enter", Action: VKActionEnter, Width: 1.5}, {Label: "Hide", Action: VKActionHide, Width: 1.5}},
+			},
+		},
+		{
+			Name: VKLayoutSymbols,
+			Rows: [][]VKKey{
+				chars("1234567890"),
+				chars("@#$%&-+()/"),
+				append(chars(`_*"':;!?`), VKKey{Label: "<-", Action: VKActionBackspace, Width: 2}),
+				{{Label: "ABC", Action: VKActionLayout, Layout: VKLayoutQwerty, Width: 1.5}, {Label: "="},
+					{Label: " ", Action: VKActionSpace, Width: 4}, {Label: "."},
+					{Label: "Enter", Action: VKActionEnter, Width: 1.5}, {Label: "Hide", Action: VKActionHide, Width: 1.5}},
+			},
+		},
+		{
+			Name: VKLayoutNumeric,
+			Rows: [][]VKKey{
+				append(chars("123"), VKKey{Label: "<-", Action: VKActionBackspace}),
+				append(chars("456"), VKKey{Label: "Enter", Action: VKActionEnter}),
+				append(chars("789"), VKKey{Label: "Hide", Action: VKActionHide}),
+				append(chars("-0."), VKKey{Label: "ABC", Action: VKActionLayout, Layout: VKLayoutQwerty}),
+			},
+		},
+	}
+}
+
+// ParseVKLayouts parses the JSON array of the VKLayout objects. It returns errors.ErrInvalid
+// if a layout has no name, no rows, an empty row or a key with the negative width.
+func ParseVKLayouts(data []byte) ([]VKLayout, error) {
+	var res []VKLayout
+	if err := json.Unmarshal(data, &res); err != nil {
+		return nil, fmt.Errorf("could not parse the virtual keyboard layouts: %w", err)
+	}
+	for _, l := range res {
+		if err := l.validate(); err != nil {
+			return nil, err
+		}
+	}
+	return res, nil
+}
+
+// validate checks the layout (see ParseVKLayouts)
+func (l VKLayout) validate() error {
+	if l.Name == "" || len(l.Rows) == 0 {
+		return fmt.Errorf("the virtual keyboard layout must have a name and at least one row: %w", errors.ErrInvalid)
+	}
+	for i, row := range l.Rows {
+		if len(row) == 0 {
+			return fmt.Errorf("the row %d of the virtual keyboard layout %q has no keys: %w", i, l.Name, errors.ErrInvalid)
+		}
+		for _, k := range row {
+			if k.Width < 0 {
+				return fmt.Errorf("the key %q of the virtual keyboard layout %q has the negative width %f: %w", k.Label, l.Name, k.Width, errors.ErrInvalid)
+			}
+		}
+	}
+	return nil
+}
+
+// LoadVKLayouts reads the layouts from the JSON file fileName (see ParseVKLayouts)
+func LoadVKLayouts(fileName string) ([]VKLayout, error) {
+	data, err := os.ReadFile(fileName)
+	if err != nil {
+		return nil, fmt.Errorf("could not read the virtual keyboard layouts from %s: %w", fileName, err)
+	}
+	return ParseVKLayouts(data)
+}
+
+// NewVirtualKeyboard creates the VirtualKeyboard with the layouts. The DefaultVKLayouts()
+// are used if the layouts is empty. The first layout is the default one. The layouts are
+// checked the same way as ParseVKLayouts does.
+func NewVirtualKeyboard(owner raywin.Container, layouts []VKLayout) (*VirtualKeyboard, error) {
+	for _, l := range layouts {
+		if err := l.validate(); err != nil {
+			return nil, err
+		}
+	}
+	vk := &VirtualKeyboard{}
+	vk.init(owner.(raywin.Component), layouts)
+	err := vk.Init(owner, vk)
+	vk.SetVisible(false)
+	return vk, err
+}
+
+func (vk *VirtualKeyboard) init(owner raywin.Component, layouts []VKLayout) {
+	if len(layouts) == 0 {
+		layouts = DefaultVKLayouts()
+	}
+	vk.owner = owner
+	vk.layouts = layouts
+}
+
+// SetLayout switches the VirtualKeyboard to the layout with the name. Returns false if
+// there is no such layout
+func (vk *VirtualKeyboard) SetLayout(name string) bool {
+	vk.lock.Lock()
+	defer vk.lock.Unlock()
+	return vk.setLayout(name)
+}
+
+// Layout returns the current layout name
+func (vk *VirtualKeyboard) Layout() string {
+	vk.lock.Lock()
+	defer vk.lock.Unlock()
+	return vk.layouts[vk.layout].Name
+}
+
+// IsOverlay implements raywin.Overlay
+func (vk *VirtualKeyboard) IsOverlay() bool {
+	return true
+}
+
+// OnNewFrame implements raywin.FrameListener. It tracks the focused component and
+// slides the VirtualKeyboard up and down
+func (vk *VirtualKeyboard) OnNewFrame(millis int64) {
+	vk.lock.Lock()
+	defer vk.lock.Unlock()
+
+	ti, _ := raywin.AppOf(vk).Focused().(TextInput)
+	if ti != vk.target {
+		// the key pressed for the previous target must not be typed into the new one
+		vk.pressed = false
+		vk.target = ti
+		vk.shift = vkShiftOff
+		if ti != nil && !vk.setLayout(ti.KeyboardLayout()) {
+			vk.layout = 0
+		}
+	}
+
+	step := float32(millis-vk.lastMillis) / float32(max(1, S.VirtualKeyboardSlideMillis))
+	vk.lastMillis = millis
+	if vk.target != nil {
+		vk.progress = min(1.0, vk.progress+step)
+	} else {
+		vk.progress = max(0.0, vk.progress-step)
+		vk.pressed = false
+	}
+
+	ob := vk.owner.Bounds()
+	rowHeight := raywin.Mm(S.VirtualKeyboardKeyHeightMm).Pixels(S.PPI)
+	h := min(int32(rowHeight*float32(len(vk.layouts[vk.layout].Rows))), ob.Height/2)
+	vk.SetBounds(rl.RectangleInt32{X: 0, Y: ob.Height - int32(float32(h)*vk.progress), Width: ob.Width, Height: h})
+	vk.SetVisible(vk.progress > 0.0)
+}
+
+// OnTPState implements raywin.Touchpadable. The key is activated when it is released,
+// so the user may slide the finger over the keys to find the right one
+func (vk *VirtualKeyboard) OnTPState(tps raywin.TPState) raywin.OnTPSResult {
+	vk.lock.Lock()
+	defer vk.lock.Unlock()
+
+	if vk.target == nil || vk.progress < 1.0 {
+		vk.pressed = false
+		return raywin.OnTPSResultStop
+	}
+	b := vk.Bounds()
+	row, col := vk.keyAt(tps.Pos.X-float32(vk.origin.X), tps.Pos.Y-float32(vk.origin.Y), float32(b.Width), float32(b.Height))
+	switch tps.State {
+	case raywin.TPStatePressed, raywin.TPStateMoving:
+		vk.pressed = row >= 0
+		vk.pRow, vk.pCol = row, col
+		return raywin.OnTPSResultLocked
+	case raywin.TPStateReleased:
+		if k, ok := vk.pressedKey(); ok {
+			vk.pressed = false
+			target := vk.target
+			vk.lock.Unlock()
+			vk.onKey(target, k)
+			vk.lock.Lock()
+		}
+		vk.pressed = false
+	}
+	return raywin.OnTPSResultStop
+}
+
+// Draw renders the VirtualKeyboard
+func (vk *VirtualKeyboard) Draw(cc *raywin.CanvasContext) {
+	vk.lock.Lock()
+	defer vk.lock.Unlock()
+
+	b := vk.Bounds()
+	x, y := cc.PhysicalPointXY(0, 0)
+	vk.origin = raywin.Vector2Int32{X: x, Y: y}
+	cc.DrawRectangle(rl.RectangleInt32{X: x, Y: y, Width: b.Width, Height: b.Height}, S.VirtualKeyboardBackgroundColor)
+
+	l := vk.layouts[vk.layout]
+	space := raywin.Mm(S.VirtualKeyboardKeySpaceMm).Pixels(S.PPI)
+	var popup rl.Rectangle
+	var popupLabel string
+	for ri, row := range l.Rows {
+		for ki, k := range row {
+			r := keyRect(l, ri, ki, float32(b.Width), float32(b.Height))
+			r.X += float32(x) + space
+			r.Y += float32(y) + space
+			r.Width -= 2 * space
+			r.Height -= 2 * space
+			col := S.VirtualKeyboardKeyColor
+			if k.Action != "" && k.Action != VKActionSpace {
+				col = S.VirtualKeyboardSpecialKeyColor
+			}
+			if k.Action == VKActionShift && vk.shift != vkShiftOff {
+				col = S.FrameSelectToneColor
+			}
+			pressed := vk.pressed && vk.pRow == ri && vk.pCol == ki
+			if pressed {
+				col = S.DialogBackgroundLight
+				if k.Action == "" {
+					popup = r
+					popupLabel = vk.keyLabel(k)
+				}
+			}
+			cc.DrawRectangleRounded(r, 0.2, 5, col)
+			if k.Action == VKActionShift && vk.shift == vkShiftCaps {
+				cc.DrawRectangleRoundedLines(r, 0.2, 5, 2.0, S.OutlineColor)
+			}
+			vk.drawLabel(cc, vk.keyLabel(k), r, r.Height/2)
+		}
+	}
+	if popupLabel != "" {
+		vk.drawPopup(cc, popup, popupLabel)
+	}
+}
+
+// drawPopup draws the bubble for the pressed key r in the ButtonSelectStyleJumpOut manner
+func (vk *VirtualKeyboard) drawPopup(cc *raywin.CanvasContext, r rl.Rectangle, label string) {
+	phr := cc.PhysicalRegion()
+	w := r.Width * S.ButtonJumpOutCoef
+	h := r.Height * S.ButtonJumpOutCoef
+	cc.BeginScissorMode(rl.RectangleInt32{X: phr.X, Y: phr.Y - int32(h), Width: phr.Width, Height: phr.Height + int32(h)})
+	defer cc.EndScissorMode()
+
+	pr := rl.Rectangle{X: r.X - (w-r.Width)/2, Y: r.Y - r.Height, Width: w, Height: h}
+	for i := 0; i < 4; i++ {
+		cc.DrawRectangleRounded(pr, 0.2, 5, rl.Fade(S.FrameSelectToneColor, 0.3))
+		pr.X++
+		pr.Y++
+		pr.Width -= 2
+		pr.Height -= 2
+	}
+	cc.DrawRectangleRounded(pr, 0.2, 5, S.FrameSelectToneColor)
+	pr.X++
+	pr.Y++
+	pr.Width -= 2
+	pr.Height -= 2
+	cc.DrawRectangleRounded(pr, 0.2, 5, S.DialogBackgroundLight)
+	vk.drawLabel(cc, label, rl.Rectangle{X: pr.X, Y: pr.Y, Width: pr.Width, Height: r.Height}, r.Height*0.7)
+}
+
+func (vk *VirtualKeyboard) drawLabel(cc *raywin.CanvasContext, label string, r rl.Rectangle, fs float32) {
+	font := raywin.AppOf(vk).SystemFont(int(fs))
+	v := cc.MeasureText(font, label, fs, 0)
+	cc.DrawText(font, label, rl.Vector2{X: r.X + (r.Width-v.X)/2, Y: r.Y + (r.Height-v.Y)/2}, fs, 0, S.VirtualKeyboardTextColor)
+}
+
+// onKey performs the key action. It is called without holding the lock, cause the target
+// callbacks may call the VirtualKeyboard functions
+func (vk *VirtualKeyboard) onKey(target TextInput, k VKKey) {
+	switch k.Action {
+	case VKActionShift:
+		vk.lock.Lock()
+		vk.shift = (vk.shift + 1) % (vkShiftCaps + 1)
+		vk.lock.Unlock()
+	case VKActionBackspace:
+		target.DeleteBackward()
+	case VKActionEnter:
+		target.Submit()
+	case VKActionSpace:
+		target.InsertText(" ")
+	case VKActionLayout:
+		vk.SetLayout(k.Layout)
+	case VKActionHide:
+		raywin.AppOf(vk).SetFocus(nil)
+	default:
+		vk.lock.Lock()
+		txt := vk.keyText(k)
+		if vk.shift == vkShiftOn {
+			vk.shift = vkShiftOff
+		}
+		vk.lock.Unlock()
+		target.InsertText(txt)
+	}
+}
+
+func (vk *VirtualKeyboard) keyText(k VKKey) string {
+	txt := k.Text
+	if txt == "" {
+		txt = k.Label
+	}
+	if vk.shift == vkShiftOff {
+		return txt
+	}
+	if k.ShiftText != "" {
+		return k.ShiftText
+	}
+	return strings.ToUpper(txt)
+}
+
+func (vk *VirtualKeyboard) keyLabel(k VKKey) string {
+	if k.Action == "" && vk.shift != vkShiftOff {
+		return strings.ToUpper(k.Label)
+	}
+	return k.Label
+}
+
+func (vk *VirtualKeyboard) setLayout(name string) bool {
+	for i, l := range vk.layouts {
+		if l.Name == name {
+			if vk.layout != i {
+				// the pressed key position is not valid for another layout
+				vk.pressed = false
+			}
+			vk.layout = i
+			return true
+		}
+	}
+	return false
+}
+
+// pressedKey returns the pressed key, if its position is valid for the current layout
+func (vk *VirtualKeyboard) pressedKey() (VKKey, bool) {
+	rows := vk.layouts[vk.layout].Rows
+	if !vk.pressed || vk.pRow < 0 || vk.pRow >= len(rows) || vk.pCol < 0 || vk.pCol >= len(rows[vk.pRow]) {
+		return VKKey{}, false
+	}
+	return rows[vk.pRow][vk.pCol], true
+}
+
+// keyAt returns the row and the column of the key in the point (x, y) of the keyboard
+// with the width and height provided. Returns -1, -1 if there is no key in the point
+func (vk *VirtualKeyboard) keyAt(x, y, width, height float32) (int, int) {
+	l := vk.layouts[vk.layout]
+	for ri, row := range l.Rows {
+		for ki := range row {
+			r := keyRect(l, ri, ki, width, height)
+			if x >= r.X && x < r.X+r.Width && y >= r.Y && y < r.Y+r.Height {
+				return ri, ki
+			}
+		}
+	}
+	return -1, -1
+}
+
+// keyRect returns the key region relative to the keyboard of the size width x height
+func keyRect(l VKLayout, row, col int, width, height float32) rl.Rectangle {
+	rh := height / float32(len(l.Rows))
+	total := float32(0)
+	x := float32(0)
+	for i, k := range l.Rows[row] {
+		w := k.Width
+		if w <= 0 {
+			w = 1.0
+		}
+		if i < col {
+			x += w
+		}
+		total += w
+	}
+	w := l.Rows[row][col].Width
+	if w <= 0 {
+		w = 1.0
+	}
+	return rl.Rectangle{X: x * width / total, Y: float32(row) * rh, Width: w * width / total, Height: rh}
+}
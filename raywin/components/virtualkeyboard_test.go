@@ -0,0 +1,179 @@
+package components
+
+// Copyright 2025 Dmitry Spasibenko
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+import (
+	"github.com/dspasibenko/raywin-go/pkg/golibs/errors"
+	"github.com/dspasibenko/raywin-go/raywin"
+	"github.com/dspasibenko/raywin-go/raywin/raywintest"
+	rl "github.com/gen2brain/raylib-go/raylib"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestParseVKLayouts(t *testing.T) {
+	ls, err := ParseVKLayouts([]byte(`[{"name":"hex","rows":[[{"label":"A"},{"label":"B","width":2}],[{"label":"<-","action":"backspace"}]]}]`))
+	assert.Nil(t, err)
+	assert.Equal(t, []VKLayout{{Name: "hex", Rows: [][]VKKey{{{Label: "A"}, {Label: "B", Width: 2}}, {{Label: "<-", Action: VKActionBackspace}}}}}, ls)
+
+	_, err = ParseVKLayouts([]byte(`[{"name":"hex"}]`))
+	assert.NotNil(t, err)
+	_, err = ParseVKLayouts([]byte(`{`))
+	assert.NotNil(t, err)
+	// the empty rows would make the keys rectangles infinite
+	_, err = ParseVKLayouts([]byte(`[{"name":"hex","rows":[[{"label":"A"}],[]]}]`))
+	assert.ErrorIs(t, err, errors.ErrInvalid)
+	_, err = ParseVKLayouts([]byte(`[{"name":"hex","rows":[[{"label":"A","width":-1}]]}]`))
+	assert.ErrorIs(t, err, errors.ErrInvalid)
+	_, err = NewVirtualKeyboard(nil, []VKLayout{{Name: "hex", Rows: [][]VKKey{{}}}})
+	assert.ErrorIs(t, err, errors.ErrInvalid)
+	_, err = LoadVKLayouts("not-existing-file.json")
+	assert.NotNil(t, err)
+}
+
+func Test_keyRect(t *testing.T) {
+	l := VKLayout{Rows: [][]VKKey{{{Label: "a"}, {Label: "b", Width: 2}, {Label: "c"}}, {{Label: "d"}}}}
+	assert.Equal(t, rl.Rectangle{X: 0, Y: 0, Width: 100, Height: 50}, keyRect(l, 0, 0, 400, 100))
+	assert.Equal(t, rl.Rectangle{X: 100, Y: 0, Width: 200, Height: 50}, keyRect(l, 0, 1, 400, 100))
+	assert.Equal(t, rl.Rectangle{X: 300, Y: 0, Width: 100, Height: 50}, keyRect(l, 0, 2, 400, 100))
+	assert.Equal(t, rl.Rectangle{X: 0, Y: 50, Width: 400, Height: 50}, keyRect(l, 1, 0, 400, 100))
+
+	vk := &VirtualKeyboard{}
+	vk.init(nil, []VKLayout{l})
+	r, c := vk.keyAt(150, 10, 400, 100)
+	assert.Equal(t, 0, r)
+	assert.Equal(t, 1, c)
+	r, c = vk.keyAt(399, 99, 400, 100)
+	assert.Equal(t, 1, r)
+	assert.Equal(t, 0, c)
+	r, c = vk.keyAt(400, 99, 400, 100)
+	assert.Equal(t, -1, r)
+	assert.Equal(t, -1, c)
+}
+
+type testTextInput struct {
+	EditBox
+	submitted int
+}
+
+func (ti *testTextInput) Submit() {
+	ti.submitted++
+}
+
+func TestVirtualKeyboard_onKey(t *testing.T) {
+	vk := &VirtualKeyboard{}
+	vk.init(nil, nil)
+	assert.Equal(t, VKLayoutQwerty, vk.Layout())
+	ti := &testTextInput{}
+
+	vk.onKey(ti, VKKey{Label: "a"})
+	vk.onKey(ti, VKKey{Label: "b", Text: "bb"})
+	vk.onKey(ti, VKKey{Label: " ", Action: VKActionSpace})
+	assert.Equal(t, "abb ", ti.Text())
+
+	// shift is applied to one key only
+	vk.onKey(ti, VKKey{Action: VKActionShift})
+	assert.Equal(t, "A", vk.keyLabel(VKKey{Label: "a"}))
+	assert.Equal(t, "Shift", vk.keyLabel(VKKey{Label: "Shift", Action: VKActionShift}))
+	vk.onKey(ti, VKKey{Label: "c"})
+	vk.onKey(ti, VKKey{Label: "d"})
+	assert.Equal(t, "abb Cd", ti.Text())
+
+	// caps lock
+	vk.onKey(ti, VKKey{Action: VKActionShift})
+	vk.onKey(ti, VKKey{Action: VKActionShift})
+	vk.onKey(ti, VKKey{Label: "e"})
+	vk.onKey(ti, VKKey{Label: "1", ShiftText: "!"})
+	vk.onKey(ti, VKKey{Action: VKActionShift})
+	vk.onKey(ti, VKKey{Label: "f"})
+	assert.Equal(t, "abb CdE!f", ti.Text())
+
+	vk.onKey(ti, VKKey{Action: VKActionBackspace})
+	vk.onKey(ti, VKKey{Action: VKActionEnter})
+	assert.Equal(t, "abb CdE!", ti.Text())
+	assert.Equal(t, 1, ti.submitted)
+
+	vk.onKey(ti, VKKey{Action: VKActionLayout, Layout: VKLayoutNumeric})
+	assert.Equal(t, VKLayoutNumeric, vk.Layout())
+	vk.onKey(ti, VKKey{Action: VKActionLayout, Layout: "unknown"})
+	assert.Equal(t, VKLayoutNumeric, vk.Layout())
+	assert.True(t, vk.SetLayout(VKLayoutSymbols))
+	assert.False(t, vk.SetLayout("unknown"))
+	assert.Equal(t, VKLayoutSymbols, vk.Layout())
+}
+
+func TestEditBox_KeyboardLayout(t *testing.T) {
+	assert.Equal(t, "", newTestEditBox(DefaultEditBoxConfig()).KeyboardLayout())
+	assert.Equal(t, VKLayoutNumeric, newTestEditBox(DefaultEditBoxConfig().Flags(EditBoxNumeric)).KeyboardLayout())
+}
+
+// tapVKKey taps the center of the key with the label in the current layout of vk
+func tapVKKey(t *testing.T, h *raywintest.Harness, vk *VirtualKeyboard, label string) {
+	l := vk.layouts[vk.layout]
+	b := vk.Bounds()
+	for ri, row := range l.Rows {
+		for ki, k := range row {
+			if k.Label == label {
+				r := keyRect(l, ri, ki, float32(b.Width), float32(b.Height))
+				assert.Nil(t, h.Tap(rl.Vector2{X: float32(b.X) + r.X + r.Width/2, Y: float32(b.Y) + r.Y + r.Height/2}))
+				return
+			}
+		}
+	}
+	assert.Fail(t, "no key with the label", label)
+}
+
+func TestVirtualKeyboard_Prompt(t *testing.T) {
+	h := newTestDialogHarness(t)
+	root := h.App().RootContainer()
+	vk, err := NewVirtualKeyboard(root, nil)
+	assert.Nil(t, err)
+	var res []DialogResult
+	d, err := NewDialog(root, DefaultDialogConfig().Title("Name").Prompt(DefaultEditBoxConfig()).
+		Button("OK", DialogButtonOkStyle()).OnResult(func(r DialogResult) { res = append(res, r) }))
+	assert.Nil(t, err)
+	assert.Nil(t, h.StepMillis(S.VirtualKeyboardSlideMillis+100))
+	assert.True(t, vk.IsVisible())
+
+	// the keyboard is drawn over the dialog overlay, which would halve its brightness
+	b := vk.Bounds()
+	assert.InDelta(t, S.VirtualKeyboardBackgroundColor.G, h.Image().RGBAAt(int(b.X)+1, int(b.Y)+1).G, 5)
+
+	tapVKKey(t, h, vk, "h")
+	tapVKKey(t, h, vk, "i")
+	assert.Equal(t, "hi", d.edit.Text())
+	tapVKKey(t, h, vk, "Enter")
+	assert.Equal(t, []DialogResult{{Button: 0, Text: "hi"}}, res)
+}
+
+func TestVirtualKeyboard_pressedLayoutChange(t *testing.T) {
+	vk := &VirtualKeyboard{}
+	vk.init(nil, nil)
+	ti := &testTextInput{}
+	vk.target, vk.progress = ti, 1.0
+
+	// "p" is the 10th key of the first qwerty row, the numeric layout has 4 keys in a row
+	vk.pressed, vk.pRow, vk.pCol = true, 0, 9
+	assert.True(t, vk.SetLayout(VKLayoutNumeric))
+	assert.False(t, vk.pressed)
+	assert.Equal(t, raywin.OnTPSResultStop, vk.OnTPState(raywin.TPState{State: raywin.TPStateReleased}))
+	assert.Equal(t, "", ti.Text())
+
+	// the stale position is not used even if the pressed flag is set
+	vk.pressed = true
+	assert.NotPanics(t, func() { vk.OnTPState(raywin.TPState{State: raywin.TPStateReleased}) })
+	assert.Equal(t, "", ti.Text())
+	assert.False(t, vk.pressed)
+}
@@ -51,8 +51,49 @@ type (
 		IconsDir string
 
 		// FrameListener allows to specify an external frame listener which will be called
-		// on each new frame. It can be nil
+		// on each new frame. It can be nil. More listeners may be added at runtime
+		// via AddFrameListener
 		FrameListener FrameListener
+
+		// Proxy allows to replace the raylib backend. It is nil by default, which means the
+		// raylib window is used. The SoftProxy may be provided to render the frames headless.
+		Proxy RlProxy `json:"-"`
+
+		// TouchRecorder allows to record the touchpad states of every frame, the recording
+		// may be replayed later via TouchReplay. The field may be nil.
+		TouchRecorder *TouchRecorder `json:"-"`
+
+		// TouchReplay allows to replay the recorded touchpad states instead of the real input.
+		// The display clock follows the recorded frames millis while the replay is active.
+		// The field may be nil.
+		TouchReplay *TouchReplay `json:"-"`
+
+		// Clock allows to specify the source of the frames timestamps: the real clock
+		// (NewRealClock), the simulated one (NewFixedStepClock) or the scaled for the slow
+		// motion (NewScaledClock). The field may be nil, the real clock is used then.
+		Clock Clock `json:"-"`
+
+		// DispatchQueueSize is the maximum number of the functions queued by Post() and
+		// Invoke() for the next frame. If it is 0, DefaultDispatchQueueSize is used.
+		DispatchQueueSize int
+
+		// DevMode enables the hot reload of the resources for the development. The
+		// files of the icons (IconsDir), the fonts and the StyleFileName are checked every
+		// DevPollMillis, and the changed ones are reloaded by the drawing loop goroutine.
+		// If a resource could not be reloaded, the error is logged and the previous
+		// resource is kept. The files are looked up in ResourceDir as usual.
+		DevMode bool
+		// DevPollMillis is the period of the files check in the dev mode. If it is 0,
+		// DefaultDevPollMillis is used.
+		DevPollMillis int64
+		// StyleFileName is the style file, which is watched in the dev mode. When it is
+		// changed, StyleLoader is called with its name.
+		StyleFileName string
+		// StyleLoader loads and applies the style from the file in the dev mode, see
+		// components.StyleFileLoader. The loader must apply the style only if it is loaded
+		// completely, so the previous style is kept when the error is returned. The field
+		// may be nil.
+		StyleLoader func(fileName string) error `json:"-"`
 	}
 
 	// DisplayConfig contain the basic display configuration
@@ -63,11 +104,29 @@ type (
 		Height uint32
 		// PPI is the Pixels per Inch, depends on the display physical size
 		PPI float32
+		// MinTouchTarget is the minimum physical size of a touch target. A touch next to a
+		// smaller Touchpadable component, which doesn't hit any other component, is
+		// delivered to the component as if it was that size. 0 disables the extension, it
+		// is the default, so the touches hit the components bounds only (e.g. 9mm is
+		// the recommended size for the finger touch).
+		MinTouchTarget Mm
 		// FPS - frames per second. The number of the display updates the library
 		// will try to support.
 		FPS int
 		// BackgroundColor contains the default color for display area
 		BackgroundColor rl.Color
+		// Rotation allows to mount the display rotated, e.g. in portrait, when the output
+		// is landscape. Width and Height are the physical output dimensions, the components
+		// are laid out in the logical (rotated) area (see LogicalSize). The frames are
+		// rendered into a texture, which is presented rotated, and the touch points are
+		// transformed to the logical coordinates.
+		Rotation Rotation
+		// Calibration is the transformation applied to every raw touch point, before it
+		// is transformed by the Rotation. The field may be nil, the raw points are used then.
+		// See TouchCalibration and App.SetTouchCalibration
+		Calibration *TouchCalibration `json:",omitempty"`
+		// TouchFilter contains the touch noise filters settings, the zero value disables them
+		TouchFilter TouchFilter
 	}
 )
 
@@ -91,7 +150,8 @@ func (dc DisplayConfig) String() string {
 // DefaultConfig returns the default Config
 func DefaultConfig() Config {
 	return Config{
-		DisplayConfig: DefaultDisplayConfig(),
+		DisplayConfig:     DefaultDisplayConfig(),
+		DispatchQueueSize: DefaultDispatchQueueSize,
 	}
 }
 
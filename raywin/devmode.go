@@ -0,0 +1,176 @@
+package raywin
+
+// Copyright 2025 Dmitry Spasibenko
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+import (
+	"fmt"
+	"github.com/dspasibenko/raywin-go/pkg/golibs/files"
+	rl "github.com/gen2brain/raylib-go/raylib"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// devWatcher tracks the resources files changes in the dev mode (see Config.DevMode). The
+// files are polled by the drawing loop goroutine, so the resources are reloaded between
+// the frames, when nothing is drawn.
+type devWatcher struct {
+	icons string
+	fonts string
+	style string
+}
+
+// DefaultDevPollMillis is the period of the resources files check in the dev mode, if it
+// is not specified by Config.DevPollMillis
+const DefaultDevPollMillis = 500
+
+// startDevMode remembers the current state of the resources files and starts polling them
+func (c *App) startDevMode() error {
+	c.devw = &devWatcher{icons: c.iconsSignature(), fonts: c.fontsSignature(), style: c.styleSignature()}
+	period := c.cfg.DevPollMillis
+	if period <= 0 {
+		period = DefaultDevPollMillis
+	}
+	c.logger.Infof("dev mode: watching the resources files every %dms", period)
+	_, err := c.Every(nil, period, c.checkDevResources)
+	return err
+}
+
+// checkDevResources reloads the resources, which files are changed since the last check
+func (c *App) checkDevResources() {
+	if sig := c.iconsSignature(); sig != c.devw.icons {
+		c.devw.icons = sig
+		c.reloadIcons()
+	}
+	if sig := c.fontsSignature(); sig != c.devw.fonts {
+		c.devw.fonts = sig
+		c.reloadFonts()
+	}
+	if sig := c.styleSignature(); sig != c.devw.style {
+		c.devw.style = sig
+		c.reloadStyle()
+	}
+}
+
+// reloadIcons loads the icons from Config.IconsDir again. The textures of the reloaded
+// icons are unloaded, the icon, which could not be loaded, keeps its previous texture.
+func (c *App) reloadIcons() {
+	dir := c.resolveFileName(c.cfg.ResourceDir, c.cfg.IconsDir)
+	c.logger.Infof("dev mode: reloading icons from %s", dir)
+	for _, tx := range c.storeIcons(c.readIcons(dir)) {
+		c.disp.proxy.UnloadTexture(tx)
+	}
+}
+
+// reloadFonts loads all the fonts requested by Font() from the files again. The fonts are
+// replaced and the previous ones are unloaded only if all of them are loaded, otherwise the
+// new fonts are unloaded and the previous ones are kept.
+func (c *App) reloadFonts() {
+	c.lock.Lock()
+	keys := make([]string, 0, len(c.fonts))
+	for k := range c.fonts {
+		keys = append(keys, k)
+	}
+	c.lock.Unlock()
+	sort.Strings(keys)
+	c.logger.Infof("dev mode: reloading fonts")
+	fonts := make(map[string]rl.Font, len(keys))
+	for _, k := range keys {
+		f, err := c.newFont(k)
+		if err != nil {
+			c.logger.Errorf("dev mode: could not reload fonts, keeping the previous ones: %v", err)
+			unloadFonts(c.disp.proxy, fonts)
+			return
+		}
+		fonts[k] = f
+	}
+	c.lock.Lock()
+	prev := c.fonts
+	c.fonts = fonts
+	c.lock.Unlock()
+	c.fontsCache.Clear()
+	unloadFonts(c.disp.proxy, prev)
+}
+
+// reloadStyle calls Config.StyleLoader for Config.StyleFileName
+func (c *App) reloadStyle() {
+	if c.cfg.StyleLoader == nil {
+		return
+	}
+	fn := c.resolveFileName(c.cfg.ResourceDir, c.cfg.StyleFileName)
+	c.logger.Infof("dev mode: reloading style from %s", fn)
+	if err := c.cfg.StyleLoader(fn); err != nil {
+		c.logger.Errorf("dev mode: could not reload style from %s, keeping the previous one: %v", fn, err)
+	}
+}
+
+func (c *App) iconsSignature() string {
+	if c.cfg.IconsDir == "" {
+		return ""
+	}
+	dir := c.resolveFileName(c.cfg.ResourceDir, c.cfg.IconsDir)
+	var sb strings.Builder
+	for _, f := range files.ListDir(dir) {
+		sb.WriteString(fileSignature(filepath.Join(dir, f.Name())))
+	}
+	return sb.String()
+}
+
+func (c *App) fontsSignature() string {
+	var sb strings.Builder
+	for _, fn := range c.fontFileNames() {
+		sb.WriteString(fileSignature(c.resolveFileName(c.cfg.ResourceDir, fn)))
+	}
+	return sb.String()
+}
+
+func (c *App) styleSignature() string {
+	if c.cfg.StyleFileName == "" {
+		return ""
+	}
+	return fileSignature(c.resolveFileName(c.cfg.ResourceDir, c.cfg.StyleFileName))
+}
+
+// fontFileNames returns the sorted names of the font files requested by Font()
+func (c *App) fontFileNames() []string {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	res := make([]string, 0, len(c.fontFiles))
+	for fn := range c.fontFiles {
+		if fn != "" {
+			res = append(res, fn)
+		}
+	}
+	sort.Strings(res)
+	return res
+}
+
+// resolveFileName returns fn, if it exists, or fn in the dir otherwise, the same way as
+// checkFileName does, but without logging
+func (c *App) resolveFileName(dir, fn string) string {
+	if _, err := os.Stat(fn); err == nil {
+		return fn
+	}
+	return filepath.Join(dir, fn)
+}
+
+func fileSignature(fn string) string {
+	fi, err := os.Stat(fn)
+	if err != nil {
+		return fmt.Sprintf("%s:absent;", fn)
+	}
+	return fmt.Sprintf("%s:%d:%d;", fn, fi.Size(), fi.ModTime().UnixNano())
+}
@@ -0,0 +1,187 @@
+package raywin
+
+// Copyright 2025 Dmitry Spasibenko
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+import (
+	"fmt"
+	rl "github.com/gen2brain/raylib-go/raylib"
+	"github.com/stretchr/testify/assert"
+	"image"
+	"image/color"
+	"image/png"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func writeTestPNG(t *testing.T, fn string, w int, mtime time.Time) {
+	img := image.NewRGBA(image.Rect(0, 0, w, w))
+	img.Set(0, 0, color.RGBA{R: 255, A: 255})
+	f, err := os.Create(fn)
+	assert.Nil(t, err)
+	assert.Nil(t, png.Encode(f, img))
+	assert.Nil(t, f.Close())
+	assert.Nil(t, os.Chtimes(fn, mtime, mtime))
+}
+
+func writeTestFile(t *testing.T, fn, data string, mtime time.Time) {
+	assert.Nil(t, os.WriteFile(fn, []byte(data), 0644))
+	assert.Nil(t, os.Chtimes(fn, mtime, mtime))
+}
+
+type _devmode_test_proxy struct {
+	*SoftProxy
+	fonts    int
+	unloaded []rl.Font
+}
+
+func (dp *_devmode_test_proxy) LoadFontEx(fileName string, fontSize int32) rl.Font {
+	if buf, _ := os.ReadFile(fileName); string(buf) == "corrupt" {
+		return rl.Font{}
+	}
+	dp.fonts++
+	f := dp.SoftProxy.LoadFontEx(fileName, fontSize)
+	f.Texture.ID = uint32(dp.fonts)
+	return f
+}
+
+func (dp *_devmode_test_proxy) UnloadFont(font rl.Font) {
+	dp.unloaded = append(dp.unloaded, font)
+	dp.SoftProxy.UnloadFont(font)
+}
+
+func TestApp_devMode(t *testing.T) {
+	dir := t.TempDir()
+	assert.Nil(t, os.Mkdir(filepath.Join(dir, "icons"), 0755))
+	mt := time.Now().Add(-time.Hour)
+	writeTestPNG(t, filepath.Join(dir, "icons", "ok.png"), 4, mt)
+	writeTestFile(t, filepath.Join(dir, "font.ttf"), "font", mt)
+	writeTestFile(t, filepath.Join(dir, "style.json"), "{}", mt)
+
+	var styles []string
+	cfg := DefaultConfig()
+	sp := NewSoftProxy()
+	dp := &_devmode_test_proxy{SoftProxy: sp}
+	cfg.Proxy = dp
+	cfg.ResourceDir = dir
+	cfg.IconsDir = "icons"
+	cfg.RegularFontFileName = "font.ttf"
+	cfg.DevMode = true
+	cfg.DevPollMillis = 100
+	cfg.StyleFileName = "style.json"
+	cfg.StyleLoader = func(fileName string) error {
+		buf, _ := os.ReadFile(fileName)
+		if string(buf) == "bad" {
+			return fmt.Errorf("bad style")
+		}
+		styles = append(styles, string(buf))
+		return nil
+	}
+	app, err := NewApp(cfg)
+	assert.Nil(t, err)
+	defer app.Close()
+	tx, err := app.GetIcon("ok")
+	assert.Nil(t, err)
+	assert.Equal(t, int32(4), tx.Width)
+	assert.Equal(t, 1, len(sp.textures))
+	font := app.SystemFont(30)
+	assert.Equal(t, 1, dp.fonts)
+
+	// nothing is changed
+	assert.Nil(t, app.RunFrame(100))
+	assert.Equal(t, tx, app.resource("ico_ok"))
+	app.SystemFont(30)
+	assert.Equal(t, 1, dp.fonts)
+	assert.Nil(t, styles)
+
+	mt = mt.Add(time.Minute)
+	writeTestPNG(t, filepath.Join(dir, "icons", "ok.png"), 8, mt)
+	writeTestFile(t, filepath.Join(dir, "font.ttf"), "font2", mt)
+	writeTestFile(t, filepath.Join(dir, "style.json"), `{"a": 1}`, mt)
+	assert.Nil(t, app.RunFrame(150))
+	assert.Nil(t, styles)
+	assert.Nil(t, app.RunFrame(200))
+	tx1, err := app.GetIcon("ok")
+	assert.Nil(t, err)
+	assert.Equal(t, int32(8), tx1.Width)
+	// the replaced texture is unloaded
+	assert.Equal(t, 1, len(sp.textures))
+	assert.NotNil(t, sp.textures[tx1.ID])
+	app.SystemFont(30)
+	assert.Equal(t, 2, dp.fonts)
+	// the previous font is unloaded
+	assert.Equal(t, []rl.Font{font}, dp.unloaded)
+	assert.Equal(t, []string{`{"a": 1}`}, styles)
+
+	// the broken resources are not reloaded
+	mt = mt.Add(time.Minute)
+	writeTestFile(t, filepath.Join(dir, "icons", "ok.png"), "not a png", mt)
+	writeTestFile(t, filepath.Join(dir, "style.json"), "bad", mt)
+	assert.Nil(t, os.Remove(filepath.Join(dir, "font.ttf")))
+	assert.Nil(t, app.RunFrame(300))
+	assert.Equal(t, tx1, app.resource("ico_ok"))
+	assert.Equal(t, 1, len(sp.textures))
+	app.SystemFont(30)
+	assert.Equal(t, 2, dp.fonts)
+	assert.Equal(t, 1, len(dp.unloaded))
+	assert.Equal(t, []string{`{"a": 1}`}, styles)
+}
+
+func TestApp_devModeCorruptFont(t *testing.T) {
+	dir := t.TempDir()
+	mt := time.Now().Add(-time.Hour)
+	writeTestFile(t, filepath.Join(dir, "font.ttf"), "font", mt)
+	writeTestFile(t, filepath.Join(dir, "italic.ttf"), "italic", mt)
+
+	cfg := DefaultConfig()
+	dp := &_devmode_test_proxy{SoftProxy: NewSoftProxy()}
+	cfg.Proxy = dp
+	cfg.ResourceDir = dir
+	cfg.RegularFontFileName = "font.ttf"
+	cfg.ItalicFontFileName = "italic.ttf"
+	cfg.DevMode = true
+	cfg.DevPollMillis = 100
+	app, err := NewApp(cfg)
+	assert.Nil(t, err)
+	font := app.SystemFont(30)
+	italic := app.SystemItalicFont(30)
+	assert.Equal(t, 2, dp.fonts)
+
+	// the regular font is reloaded, but the italic one is corrupt, so both are kept
+	mt = mt.Add(time.Minute)
+	writeTestFile(t, filepath.Join(dir, "font.ttf"), "font2", mt)
+	writeTestFile(t, filepath.Join(dir, "italic.ttf"), "corrupt", mt)
+	assert.Nil(t, app.RunFrame(100))
+	assert.Nil(t, app.RunFrame(200))
+	assert.Equal(t, font, app.SystemFont(30))
+	assert.Equal(t, italic, app.SystemItalicFont(30))
+	assert.Equal(t, 3, dp.fonts)
+	// only the regular font loaded by the failed reload is unloaded
+	assert.Equal(t, 1, len(dp.unloaded))
+	assert.NotEqual(t, font, dp.unloaded[0])
+
+	// the fixed font replaces the previous ones
+	mt = mt.Add(time.Minute)
+	writeTestFile(t, filepath.Join(dir, "italic.ttf"), "italic2", mt)
+	assert.Nil(t, app.RunFrame(300))
+	assert.NotEqual(t, italic, app.SystemItalicFont(30))
+	assert.ElementsMatch(t, []rl.Font{font, italic}, dp.unloaded[1:])
+
+	// the fonts are unloaded on close
+	dp.unloaded = nil
+	assert.Nil(t, app.Close())
+	assert.Equal(t, 2, len(dp.unloaded))
+}
@@ -0,0 +1,243 @@
+package raywin
+
+// Copyright 2025 Dmitry Spasibenko
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+import (
+	"context"
+	"fmt"
+	"github.com/dspasibenko/raywin-go/pkg/golibs/errors"
+	"sync"
+	"sync/atomic"
+)
+
+type (
+	// DispatcherStats contains the counters of the work queued by Post() and Invoke()
+	DispatcherStats struct {
+		// Queued is the number of the functions waiting for the next frame
+		Queued int
+		// Executed is the number of the functions executed
+		Executed int64
+		// Dropped is the number of the functions which were not executed: rejected by
+		// Post() because the queue was full, cancelled by the Invoke() context, or
+		// discarded when the App was closed
+		Dropped int64
+	}
+
+	// dispatcher is the bounded queue of the functions to be run by the drawing loop goroutine
+	dispatcher struct {
+		queue     chan *dispatchTask
+		closed    chan struct{}
+		closeOnce sync.Once
+		executed  atomic.Int64
+		dropped   atomic.Int64
+		// wake wakes up the drawing loop, which may wait for the input events, if not nil
+		wake func()
+		// deferred contains the functions, which didn't fit into the full queue (see postOrDefer)
+		dlock    sync.Mutex
+		deferred []func()
+	}
+
+	dispatchTask struct {
+		f    func()
+		done chan struct{}
+		// claimed is set either by the dispatcher, which executes the function, or by
+		// Invoke(), which context is closed
+		claimed atomic.Bool
+	}
+)
+
+// DefaultDispatchQueueSize is the Post() and Invoke() queue size, if it is not specified
+// by Config.DispatchQueueSize
+const DefaultDispatchQueueSize = 1024
+
+// Post queues the function f to be run by the drawing loop goroutine of the default App
+// (see App.Post)
+func Post(f func()) error {
+	return c.Post(f)
+}
+
+// Invoke runs the function f by the drawing loop goroutine of the default App and waits
+// until it is done (see App.Invoke)
+func Invoke(ctx context.Context, f func()) error {
+	return c.Invoke(ctx, f)
+}
+
+// Post queues the function f to be run by the drawing loop goroutine at the beginning of
+// the next frame, before any component is notified about the frame. This is the way to
+// update the components from other goroutines without racing with the drawing. Post
+// doesn't block, it returns the errors.ErrExhausted if the queue is full, and the
+// function is dropped then (see DispatcherStats).
+func (c *App) Post(f func()) error {
+	if !c.valid.Load() {
+		return fmt.Errorf("Post: raywin is not initialized: %w", errors.ErrInvalid)
+	}
+	return c.disp.dsp.post(f)
+}
+
+// Invoke queues the function f to be run by the drawing loop goroutine and waits until
+// it is executed. If the queue is full, Invoke waits for the room in it. It returns
+// ctx.Err() if the context is closed before f is executed, f will not be executed then.
+// Invoke must not be called from the drawing loop goroutine, it would be blocked forever.
+func (c *App) Invoke(ctx context.Context, f func()) error {
+	if !c.valid.Load() {
+		return fmt.Errorf("Invoke: raywin is not initialized: %w", errors.ErrInvalid)
+	}
+	return c.disp.dsp.invoke(ctx, f)
+}
+
+// postOrDefer queues the function f, which is deferred till the next frame, if the queue
+// is full (see dispatcher.postOrDefer)
+func (c *App) postOrDefer(f func()) error {
+	if !c.valid.Load() {
+		return fmt.Errorf("Post: raywin is not initialized: %w", errors.ErrInvalid)
+	}
+	return c.disp.dsp.postOrDefer(f)
+}
+
+// DispatcherStats returns the counters of the work queued by Post() and Invoke()
+func (c *App) DispatcherStats() DispatcherStats {
+	if c.disp == nil {
+		return DispatcherStats{}
+	}
+	return c.disp.dsp.stats()
+}
+
+func newDispatcher(size int, wake func()) *dispatcher {
+	if size <= 0 {
+		size = DefaultDispatchQueueSize
+	}
+	return &dispatcher{queue: make(chan *dispatchTask, size), closed: make(chan struct{}), wake: wake}
+}
+
+func (dsp *dispatcher) post(f func()) error {
+	select {
+	case <-dsp.closed:
+		dsp.dropped.Add(1)
+		return fmt.Errorf("Post: the App is closed: %w", errors.ErrClosed)
+	default:
+	}
+	select {
+	case dsp.queue <- &dispatchTask{f: f}:
+		dsp.wakeUp()
+		return nil
+	default:
+		dsp.dropped.Add(1)
+		return fmt.Errorf("Post: the queue of %d functions is full: %w", cap(dsp.queue), errors.ErrExhausted)
+	}
+}
+
+// postOrDefer queues the function f as post does, but if the queue is full, f is deferred
+// till the next frame instead of being dropped. The caller must bound the number of the
+// deferred functions, for example, by coalescing them (see Observable).
+func (dsp *dispatcher) postOrDefer(f func()) error {
+	select {
+	case <-dsp.closed:
+		dsp.dropped.Add(1)
+		return fmt.Errorf("Post: the App is closed: %w", errors.ErrClosed)
+	case dsp.queue <- &dispatchTask{f: f}:
+	default:
+		dsp.dlock.Lock()
+		dsp.deferred = append(dsp.deferred, f)
+		dsp.dlock.Unlock()
+	}
+	dsp.wakeUp()
+	return nil
+}
+
+func (dsp *dispatcher) invoke(ctx context.Context, f func()) error {
+	t := &dispatchTask{f: f, done: make(chan struct{})}
+	select {
+	case <-dsp.closed:
+		dsp.dropped.Add(1)
+		return fmt.Errorf("Invoke: the App is closed: %w", errors.ErrClosed)
+	case <-ctx.Done():
+		dsp.dropped.Add(1)
+		return ctx.Err()
+	case dsp.queue <- t:
+		dsp.wakeUp()
+	}
+	select {
+	case <-t.done:
+		return nil
+	case <-dsp.closed:
+		return fmt.Errorf("Invoke: the App is closed: %w", errors.ErrClosed)
+	case <-ctx.Done():
+		if t.claimed.CompareAndSwap(false, true) {
+			return ctx.Err()
+		}
+		// the function is being executed, wait for it
+		<-t.done
+		return nil
+	}
+}
+
+// wakeUp makes the drawing loop to run the next frame, even if there is no input
+func (dsp *dispatcher) wakeUp() {
+	if dsp.wake != nil {
+		dsp.wake()
+	}
+}
+
+// run executes the functions queued before the call and the deferred ones, the functions
+// queued by them are executed in the next frame
+func (dsp *dispatcher) run() {
+	for n := len(dsp.queue); n > 0; n-- {
+		t := <-dsp.queue
+		if t.done != nil && !t.claimed.CompareAndSwap(false, true) {
+			dsp.dropped.Add(1)
+			continue
+		}
+		t.f()
+		dsp.executed.Add(1)
+		if t.done != nil {
+			close(t.done)
+		}
+	}
+	for _, f := range dsp.takeDeferred() {
+		f()
+		dsp.executed.Add(1)
+	}
+}
+
+func (dsp *dispatcher) takeDeferred() []func() {
+	dsp.dlock.Lock()
+	defer dsp.dlock.Unlock()
+	res := dsp.deferred
+	dsp.deferred = nil
+	return res
+}
+
+// close discards the queued functions, the following post and invoke calls fail
+func (dsp *dispatcher) close() {
+	dsp.closeOnce.Do(func() {
+		close(dsp.closed)
+	})
+	dsp.dropped.Add(int64(len(dsp.takeDeferred())))
+	for {
+		select {
+		case <-dsp.queue:
+			dsp.dropped.Add(1)
+		default:
+			return
+		}
+	}
+}
+
+func (dsp *dispatcher) stats() DispatcherStats {
+	dsp.dlock.Lock()
+	deferred := len(dsp.deferred)
+	dsp.dlock.Unlock()
+	return DispatcherStats{Queued: len(dsp.queue) + deferred, Executed: dsp.executed.Load(), Dropped: dsp.dropped.Load()}
+}
@@ -0,0 +1,119 @@
+package raywin
+
+// Copyright 2025 Dmitry Spasibenko
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+import (
+	"context"
+	"github.com/dspasibenko/raywin-go/pkg/golibs/errors"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestApp_Post(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.Proxy = &testProxy{}
+	cfg.DispatchQueueSize = 2
+	tfl := &testFrameListener{}
+	cfg.FrameListener = tfl
+	app, err := NewApp(cfg)
+	assert.Nil(t, err)
+
+	var res []int64
+	assert.Nil(t, app.Post(func() {
+		res = append(res, tfl.ms)
+		app.Post(func() { res = append(res, tfl.ms) })
+	}))
+	assert.Nil(t, app.Post(func() {}))
+	assert.ErrorIs(t, app.Post(func() {}), errors.ErrExhausted)
+	assert.Equal(t, DispatcherStats{Queued: 2, Dropped: 1}, app.DispatcherStats())
+
+	assert.Nil(t, app.RunFrame(10))
+	assert.Equal(t, []int64{0}, res) // before the frame listener is notified
+	assert.Nil(t, app.RunFrame(20))
+	assert.Equal(t, []int64{0, 10}, res)
+	assert.Equal(t, DispatcherStats{Executed: 3, Dropped: 1}, app.DispatcherStats())
+
+	assert.Nil(t, app.Post(func() {}))
+	assert.Nil(t, app.Close())
+	assert.Equal(t, DispatcherStats{Executed: 3, Dropped: 2}, app.DispatcherStats())
+	assert.ErrorIs(t, app.Post(func() {}), errors.ErrInvalid)
+	assert.ErrorIs(t, app.disp.dsp.post(func() {}), errors.ErrClosed)
+}
+
+func TestApp_PostWakeUp(t *testing.T) {
+	cfg := DefaultConfig()
+	sp := NewSoftProxy()
+	cfg.Proxy = sp
+	app, err := NewApp(cfg)
+	assert.Nil(t, err)
+	defer app.Close()
+
+	// the drawing loop, which waits for the input events, is woken up by the queued work
+	assert.Nil(t, app.Post(func() {}))
+	assert.Equal(t, 1, sp.wakeUps)
+	go app.Invoke(context.Background(), func() {})
+	assert.Eventually(t, func() bool {
+		sp.lock.Lock()
+		defer sp.lock.Unlock()
+		return sp.wakeUps == 2
+	}, time.Second, time.Millisecond)
+	assert.Nil(t, app.RunFrame(10))
+	assert.Equal(t, DispatcherStats{Executed: 2}, app.DispatcherStats())
+}
+
+func TestApp_Invoke(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.Proxy = &testProxy{}
+	cfg.DispatchQueueSize = 1
+	app, err := NewApp(cfg)
+	assert.Nil(t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go func() {
+		for ctx.Err() == nil {
+			app.RunFrame(1)
+			time.Sleep(time.Millisecond)
+		}
+	}()
+	cnt := 0
+	for i := 0; i < 10; i++ {
+		assert.Nil(t, app.Invoke(ctx, func() { cnt++ }))
+	}
+	assert.Equal(t, 10, cnt)
+	cancel()
+	assert.ErrorIs(t, app.Invoke(ctx, func() {}), context.Canceled)
+}
+
+func Test_dispatcher_invokeCancel(t *testing.T) {
+	dsp := newDispatcher(1, nil)
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	executed := false
+	assert.ErrorIs(t, dsp.invoke(ctx, func() { executed = true }), context.DeadlineExceeded)
+	assert.Equal(t, 1, dsp.stats().Queued)
+	dsp.run()
+	assert.False(t, executed)
+	assert.Equal(t, DispatcherStats{Dropped: 1}, dsp.stats())
+
+	assert.Nil(t, dsp.post(func() {}))
+	go func() {
+		time.Sleep(10 * time.Millisecond)
+		dsp.close()
+	}()
+	assert.ErrorIs(t, dsp.invoke(context.Background(), func() {}), errors.ErrClosed)
+	assert.Equal(t, DispatcherStats{Dropped: 3}, dsp.stats())
+}
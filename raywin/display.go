@@ -21,30 +21,57 @@ import (
 	"github.com/dspasibenko/raywin-go/pkg/golibs/logging"
 	rl "github.com/gen2brain/raylib-go/raylib"
 	"sync/atomic"
-	"time"
 )
 
 type display struct {
 	proxy RlProxy
+	// tracer is the proxy as DrawTracer, if it implements the interface
+	tracer DrawTracer
 	// fc frame counter
 	fc  uint64
 	cfg DisplayConfig
 
 	running int32
+	closed  atomic.Bool
 	logger  logging.Logger
 
 	cc     *CanvasContext
 	tp     *touchPad
+	kb     keyboard
+	anim   animator
 	millis atomic.Int64
 
 	root        rootContainer
 	tpsAcceptor Component
 	frmListener FrameListener
+	// focused is the component which holds the input focus (see Focusable)
+	focused Component
+	// minTouch is the minimum touch target size in pixels
+	minTouch int32
+	// tpRecorder records the touchpad states, if not nil
+	tpRecorder *TouchRecorder
+	// clock provides the frames millis for run()
+	clock Clock
+	// dsp runs the functions queued by Post() and Invoke()
+	dsp *dispatcher
+	// sched runs the timers (see After) and the frame listeners added at runtime
+	sched scheduler
+	// tpReplay provides the touchpad states instead of the proxy, if not nil
+	tpReplay *TouchReplay
+	// rt is the render texture the frames are drawn into, if the display is rotated
+	rt      rl.RenderTexture2D
+	rotated bool
+	// eventWaiting is the last value passed to proxy.SetEventWaiting
+	eventWaiting bool
+	// onClose is called when the display is closed, before the window is closed, if not nil
+	onClose func()
 }
 
 type rootContainer struct {
 	BaseContainer
 
+	// app is the App the root container belongs to
+	app             *App
 	proxy           RlProxy
 	backgroundColor rl.Color
 	wallpaper       rl.Texture2D
@@ -87,12 +114,22 @@ func (r *rootContainer) Draw(cc *CanvasContext) {
 func newDisplay(cfg DisplayConfig, rp RlProxy) *display {
 	d := &display{cfg: cfg, logger: logging.NewLogger("raywin.display")}
 	d.proxy = rp
+	d.tracer, _ = rp.(DrawTracer)
 	d.proxy.Init(cfg)
 	d.root.proxy = rp
 	d.root.init()
-	d.root.SetBounds(rl.RectangleInt32{X: 0, Y: 0, Width: int32(cfg.Width), Height: int32(cfg.Height)})
-	d.cc = newCanvas(d.cfg.Width, d.cfg.Height)
-	d.tp = &touchPad{}
+	lw, lh := cfg.LogicalSize()
+	d.root.SetBounds(rl.RectangleInt32{X: 0, Y: 0, Width: int32(lw), Height: int32(lh)})
+	d.cc = newCanvas(lw, lh)
+	d.cc.proxy = rp
+	d.tp = &touchPad{cfg: cfg}
+	if cfg.Rotation != Rotation0 {
+		d.rt = rp.LoadRenderTexture(int32(lw), int32(lh))
+		d.rotated = true
+	}
+	d.clock = NewRealClock()
+	d.dsp = newDispatcher(DefaultDispatchQueueSize, rp.WakeUp)
+	d.minTouch = cfg.MinTouchTargetPixels()
 	return d
 }
 
@@ -101,42 +138,141 @@ func (d *display) run(ctx context.Context) error {
 		return fmt.Errorf("Run() is already runnning: %w", errors.ErrExist)
 	}
 	d.logger.Infof("Run() starting with %s", d.cfg)
-	defer d.proxy.CloseWindow()
-	defer d.root.Close()
+	defer d.close()
 	defer func() {
 		atomic.StoreInt32(&d.running, 0)
 		d.logger.Infof("Run() finishing")
 	}()
 
-	startTime := time.Now()
+	if d.tpRecorder != nil {
+		defer d.tpRecorder.Flush()
+	}
+
+	// offs is the clock correction, the replay may move the time forward
+	offs := int64(0)
 	for !d.proxy.WindowShouldClose() && ctx.Err() == nil {
-		millis := time.Now().Sub(startTime).Milliseconds()
-		d.millis.Store(millis)
-		if d.frmListener != nil {
-			d.frmListener.OnNewFrame(millis)
+		millis := d.clock.Millis() + offs
+		if d.tpReplay != nil {
+			tps, ok := d.tpReplay.peek()
+			if !ok {
+				if d.tpReplay.stopAtEnd {
+					return nil
+				}
+				// the clock continues from the last replayed frame
+				d.tpReplay = nil
+				offs = max(0, d.millis.Load()-d.clock.Millis())
+				continue
+			}
+			millis = tps.Millis
 		}
-		d.formFrame(millis)
+		d.step(millis)
 	}
 	return ctx.Err()
 }
 
+// step makes the frame millis to be the current one, runs the queued functions (see Post)
+// and the timers, notifies the frame listeners, forms the frame and runs the OnIdle
+// timers, if the frame was idle
+func (d *display) step(millis int64) {
+	d.millis.Store(millis)
+	d.dsp.run()
+	if d.frmListener != nil {
+		d.frmListener.OnNewFrame(millis)
+	}
+	d.sched.onNewFrame(millis)
+	d.formFrame(millis)
+	d.sched.onFrameDone(d.isIdle())
+}
+
+// isIdle returns whether the touchpad is not touched, no animations are played and no
+// functions are queued by Post
+func (d *display) isIdle() bool {
+	return d.tp.tpState().State == TPStateNA && !d.anim.isBusy() && d.dsp.stats().Queued == 0
+}
+
+// close closes the root container and the window, only the first call has an effect
+func (d *display) close() {
+	if !d.closed.CompareAndSwap(false, true) {
+		return
+	}
+	d.dsp.close()
+	d.root.Close()
+	if d.rotated {
+		d.proxy.UnloadRenderTexture(d.rt)
+	}
+	if d.onClose != nil {
+		d.onClose()
+	}
+	d.proxy.CloseWindow()
+}
+
 func (d *display) formFrame(millis int64) {
-	tps := d.tp.onNewFrame(millis, d.proxy)
+	tps := d.readTPState(millis)
 	if d.tpsAcceptor == nil || d.tpsAcceptor.baseComponent().isClosed() || d.tpsAcceptor.(Touchpadable).OnTPState(tps) != OnTPSResultLocked {
+		claimed := d.tpsAcceptor != nil
 		d.tpsAcceptor = nil
-		// the root is passive, so skip it and start from its children
-		d.walkForTouchPadChildren(&d.root)
+		// the touch sequence claimed by the acceptor ends with the release, which
+		// is not offered to other components, so they don't see a tap there
+		if !claimed || tps.State != TPStateReleased {
+			// the root is passive, so skip it and start from its children
+			d.walkForTouchPadChildren(&d.root)
+		}
+	}
+	d.checkFocus()
+	for _, ke := range d.kb.onNewFrame(millis, d.proxy) {
+		d.onKeyEvent(ke)
 	}
+	d.anim.onNewFrame(millis)
 
 	d.walkForFC(&d.root, millis)
 
 	d.proxy.BeginDrawing()
-	defer d.proxy.EndDrawing()
+	defer func() {
+		d.updateEventWaiting()
+		d.proxy.EndDrawing()
+	}()
 
+	if d.rotated {
+		d.proxy.BeginTextureMode(d.rt)
+		d.walkForDrawComp(&d.root, true)
+		d.proxy.EndTextureMode()
+		d.present()
+		return
+	}
 	d.walkForDrawComp(&d.root, true)
 }
 
+// updateEventWaiting lets EndDrawing() wait for the input events, if nothing is changed
+// without the input: the display is idle, the touches are not replayed and no timers are
+// pending, otherwise the timers and the animations would stall till the next input event
+func (d *display) updateEventWaiting() {
+	w := d.isIdle() && d.tpReplay == nil && !d.sched.hasTimers()
+	if w != d.eventWaiting {
+		d.eventWaiting = w
+		d.proxy.SetEventWaiting(w)
+	}
+}
+
+// readTPState returns the touchpad state for the frame. It is either the recorded one,
+// if the replay is active, or the state read from the proxy
+func (d *display) readTPState(millis int64) TPState {
+	if d.tpReplay != nil {
+		if tps, ok := d.tpReplay.next(); ok {
+			d.tp.setState(tps)
+			return tps
+		}
+	}
+	tps := d.tp.onNewFrame(millis, d.proxy)
+	if d.tpRecorder != nil {
+		d.tpRecorder.record(tps)
+	}
+	return tps
+}
+
 func (d *display) walkForFC(c Component, millis int64) {
+	if l, ok := c.(Layouter); ok && c.baseComponent().layoutDirty.CompareAndSwap(true, false) {
+		l.Layout()
+	}
 	if fl, ok := c.(FrameListener); ok {
 		fl.OnNewFrame(millis)
 	}
@@ -149,7 +285,7 @@ func (d *display) walkForFC(c Component, millis int64) {
 
 func (d *display) walkForDrawChildren(root Container) {
 	var active Component
-	for _, chld := range root.Children() {
+	for _, chld := range inputOrder(root.Children()) {
 		r := chld.Bounds()
 		if !d.cc.IsVisible(r) || !chld.IsVisible() {
 			continue
@@ -177,6 +313,11 @@ func (d *display) walkForDrawComp(c Component, force bool) bool {
 		offs = s.Offset()
 	}
 	d.cc.pushRelativeRegion(offs, c.Bounds())
+	if o := c.baseComponent().owner; o != nil {
+		if f, ok := o.this.(ChildrenFader); ok {
+			d.cc.fadeTop(f.ChildAlpha(c))
+		}
+	}
 	scissors := false
 	defer func() {
 		d.cc.pop()
@@ -188,7 +329,7 @@ func (d *display) walkForDrawComp(c Component, force bool) bool {
 	}()
 
 	curPR := d.cc.PhysicalRegion()
-	if !hasArea(curPR) {
+	if !hasArea(curPR) || d.cc.Alpha() <= 0.0 {
 		// the box is not visible, repoerts true, like it was drawn
 		return true
 	}
@@ -197,6 +338,12 @@ func (d *display) walkForDrawComp(c Component, force bool) bool {
 		d.proxy.BeginScissorMode(curPR)
 	}
 
+	if d.tracer != nil {
+		x, y := d.cc.PhysicalPointXY(0, 0)
+		b := c.Bounds()
+		d.tracer.BeginComponent(componentTypeName(c), rl.RectangleInt32{X: x, Y: y, Width: b.Width, Height: b.Height}, curPR)
+		defer d.tracer.EndComponent()
+	}
 	c.Draw(d.cc)
 	if cont, ok := c.(Container); ok {
 		d.walkForDrawChildren(cont)
@@ -212,19 +359,45 @@ func (d *display) walkForTouchPadChildren(root Container) OnTPSResult {
 		return OnTPSResultLocked
 	}
 	x, y := d.cc.relativePointXY(d.tp.tpState().PosXY())
-	children := root.Children()
+	children := inputOrder(root.Children())
+	// floor is the index of the lowest child which may receive the touch, it is above
+	// the topmost visible modal child, if any
+	floor := 0
 	// walk in backward order, cause the lastest component is the toppest (higher priority) one
 	for i := len(children) - 1; i >= 0; i-- {
+		c := children[i]
+		if !c.IsVisible() {
+			continue
+		}
+		if IsPointInRegionInt32(x, y, c.Bounds()) {
+			res := d.walkForTouchPadComp(c)
+			if res != OnTPSResultNA {
+				return res
+			}
+		}
+		if isModal(c) {
+			floor = i + 1
+			break
+		}
+	}
+	// no exact hit, try the small touch targets extended to the minimum size
+	for i := len(children) - 1; i >= floor && d.minTouch > 0; i-- {
 		c := children[i]
 		rect := c.Bounds()
-		if !IsPointInRegionInt32(x, y, rect) || !c.IsVisible() {
+		if _, ok := c.(Touchpadable); !ok || (rect.Width >= d.minTouch && rect.Height >= d.minTouch) || !c.IsVisible() {
 			continue
 		}
-		res := d.walkForTouchPadComp(c)
-		if res != OnTPSResultNA {
-			return res
+		if !IsPointInRegionInt32(x, y, rect) && IsPointInRegionInt32(x, y, TouchTarget(rect, d.minTouch)) {
+			res := d.walkForTouchPadComp(c)
+			if res != OnTPSResultNA {
+				return res
+			}
 		}
 	}
+	if floor > 0 {
+		// the touch is blocked by the modal component
+		return OnTPSResultStop
+	}
 	return OnTPSResultNA
 }
 
@@ -241,6 +414,16 @@ func (d *display) walkForTouchPadComp(root Component) OnTPSResult {
 		return OnTPSResultNA
 	}
 
+	if ti, ok := root.(TouchInterceptor); ok {
+		res := ti.InterceptTPState(d.tp.tpState())
+		if res == OnTPSResultLocked {
+			d.tpsAcceptor = root
+		}
+		if res != OnTPSResultNA {
+			return res
+		}
+	}
+
 	if cont, ok := root.(Container); ok {
 		res := d.walkForTouchPadChildren(cont)
 		if res != OnTPSResultNA {
@@ -258,3 +441,178 @@ func (d *display) walkForTouchPadComp(root Component) OnTPSResult {
 
 	return OnTPSResultNA
 }
+
+// setFocus moves the input focus to c, which must be a Focusable or nil
+func (d *display) setFocus(c Component) error {
+	if c != nil {
+		if _, ok := c.(Focusable); !ok {
+			return fmt.Errorf("the component %s is not Focusable: %w", c, errors.ErrInvalid)
+		}
+		if err := c.baseComponent().AssertInitialized(); err != nil {
+			return err
+		}
+		if isBelowModal(c) {
+			return fmt.Errorf("the component %s is below a modal component: %w", c, errors.ErrInvalid)
+		}
+	}
+	if c == d.focused {
+		return nil
+	}
+	prev := d.focused
+	d.focused = c
+	if prev != nil && !prev.baseComponent().isClosed() {
+		prev.(Focusable).OnFocusChanged(false)
+	}
+	if c != nil {
+		c.(Focusable).OnFocusChanged(true)
+	}
+	return nil
+}
+
+// checkFocus drops the focus if the focused component is closed, not visible anymore or
+// it is placed below a visible modal component
+func (d *display) checkFocus() {
+	if d.focused != nil && (d.focused.baseComponent().isClosed() || !d.focused.IsVisible() || isBelowModal(d.focused)) {
+		d.setFocus(nil)
+	}
+}
+
+// isBelowModal returns whether c is placed below the topmost visible modal component
+// of any of its owners, so c must not receive the user input (see Modal)
+func isBelowModal(c Component) bool {
+	for owner := c.baseComponent().owner; owner != nil; owner = owner.owner {
+		children := inputOrder(owner.Children())
+		if childIndex(children, c) < topModalIndex(children) {
+			return true
+		}
+		c = owner.this
+	}
+	return false
+}
+
+// onKeyEvent dispatches the KeyEvent ke to the focused component. If the
+// component does not consume the event, the navigation keys move the focus
+func (d *display) onKeyEvent(ke KeyEvent) {
+	// a previous key event may open a modal component over the focused one
+	d.checkFocus()
+	if d.focused != nil && d.focused.(Focusable).OnKeyEvent(ke) {
+		return
+	}
+	switch ke.Key {
+	case rl.KeyTab:
+		d.moveFocus(ke.Modifiers&KeyModShift == 0)
+	case rl.KeyDown, rl.KeyRight:
+		d.moveFocus(true)
+	case rl.KeyUp, rl.KeyLeft:
+		d.moveFocus(false)
+	default:
+		d.walkForKeyListener(&d.root, ke)
+	}
+}
+
+// walkForKeyListener offers the key event ke to the KeyListeners in the c subtree
+// starting from the topmost one. It returns true if the event is consumed
+func (d *display) walkForKeyListener(c Component, ke KeyEvent) bool {
+	if !c.IsVisible() {
+		return false
+	}
+	if cont, ok := c.(Container); ok {
+		children := inputOrder(cont.Children())
+		for i := len(children) - 1; i >= 0; i-- {
+			if d.walkForKeyListener(children[i], ke) {
+				return true
+			}
+			if isModal(children[i]) && children[i].IsVisible() {
+				return false
+			}
+		}
+	}
+	if kl, ok := c.(KeyListener); ok {
+		return kl.OnUnhandledKey(ke)
+	}
+	return false
+}
+
+// moveFocus moves the focus to the next (forward == true) or previous visible Focusable
+// component. The components are ordered as they are walked in the components tree
+func (d *display) moveFocus(forward bool) {
+	fcs := d.walkForFocusable(&d.root, nil)
+	if len(fcs) == 0 {
+		return
+	}
+	idx := childIndex(fcs, d.focused)
+	switch {
+	case idx == len(fcs) && forward:
+		idx = 0
+	case idx == len(fcs):
+		idx = len(fcs) - 1
+	case forward:
+		idx = (idx + 1) % len(fcs)
+	default:
+		idx = (idx + len(fcs) - 1) % len(fcs)
+	}
+	d.setFocus(fcs[idx])
+}
+
+func (d *display) walkForFocusable(c Component, res []Component) []Component {
+	if !c.IsVisible() {
+		return res
+	}
+	if _, ok := c.(Focusable); ok {
+		res = append(res, c)
+	}
+	if cont, ok := c.(Container); ok {
+		children := inputOrder(cont.Children())
+		for _, chld := range children[topModalIndex(children):] {
+			res = d.walkForFocusable(chld, res)
+		}
+	}
+	return res
+}
+
+// inputOrder returns the children in the order they are drawn: the Overlay components are
+// moved after the others. The children slice is returned as is, if there are no overlays
+func inputOrder(children []Component) []Component {
+	n := 0
+	for _, c := range children {
+		if isOverlay(c) {
+			n++
+		}
+	}
+	if n == 0 {
+		return children
+	}
+	res := make([]Component, 0, len(children))
+	for _, c := range children {
+		if !isOverlay(c) {
+			res = append(res, c)
+		}
+	}
+	for _, c := range children {
+		if isOverlay(c) {
+			res = append(res, c)
+		}
+	}
+	return res
+}
+
+// topModalIndex returns the index of the topmost visible Modal component in children,
+// or 0 if there is no one. The children are expected in the inputOrder
+func topModalIndex(children []Component) int {
+	for i := len(children) - 1; i >= 0; i-- {
+		if isModal(children[i]) && children[i].IsVisible() {
+			return i
+		}
+	}
+	return 0
+}
+
+func isModal(c Component) bool {
+	m, ok := c.(Modal)
+	return ok && m.IsModal()
+}
+
+func isOverlay(c Component) bool {
+	o, ok := c.(Overlay)
+	return ok && o.IsOverlay()
+}
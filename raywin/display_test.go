@@ -182,3 +182,284 @@ func Test_display_walkForDrawComp(t *testing.T) {
 	assert.Equal(t, 4, c.drawings)
 	assert.Equal(t, 1, c2.drawings)
 }
+
+type _display_test_focusable struct {
+	BaseComponent
+	focused  bool
+	consume  bool
+	received []KeyEvent
+}
+
+func (df *_display_test_focusable) OnFocusChanged(focused bool) {
+	df.focused = focused
+}
+
+func (df *_display_test_focusable) OnKeyEvent(ke KeyEvent) bool {
+	df.received = append(df.received, ke)
+	return df.consume
+}
+
+func Test_display_setFocus(t *testing.T) {
+	d := newDisplay(DefaultDisplayConfig(), &testProxy{})
+	var c _display_test_container
+	var f1, f2 _display_test_focusable
+	assert.NotNil(t, d.setFocus(&f1)) // not initialized
+	assert.Nil(t, c.Init(&d.root, &c))
+	assert.Nil(t, f1.Init(&d.root, &f1))
+	assert.Nil(t, f2.Init(&d.root, &f2))
+
+	assert.NotNil(t, d.setFocus(&c)) // not Focusable
+	assert.Nil(t, d.setFocus(&f1))
+	assert.True(t, f1.focused)
+	assert.Equal(t, &f1, d.focused)
+	assert.Nil(t, d.setFocus(&f2))
+	assert.False(t, f1.focused)
+	assert.True(t, f2.focused)
+	assert.Nil(t, d.setFocus(nil))
+	assert.False(t, f2.focused)
+	assert.Nil(t, d.focused)
+
+	assert.Nil(t, d.setFocus(&f1))
+	f1.SetVisible(false)
+	d.checkFocus()
+	assert.Nil(t, d.focused)
+	assert.False(t, f1.focused)
+
+	assert.Nil(t, d.setFocus(&f2))
+	f2.Close()
+	d.checkFocus()
+	assert.Nil(t, d.focused)
+}
+
+func Test_display_focusBelowModal(t *testing.T) {
+	d := newDisplay(DefaultDisplayConfig(), &testProxy{})
+	var c _display_test_container
+	var f1, f2 _display_test_focusable
+	var m _display_test_modal
+	assert.Nil(t, c.Init(&d.root, &c))
+	assert.Nil(t, f1.Init(&c, &f1))
+	assert.Nil(t, d.setFocus(&f1))
+	assert.Nil(t, m.Init(&d.root, &m))
+	assert.Nil(t, f2.Init(&m, &f2))
+
+	// the key is not delivered to the focused component below the modal one
+	d.onKeyEvent(KeyEvent{Char: 'a'})
+	assert.Nil(t, f1.received)
+	assert.Nil(t, d.focused)
+	assert.False(t, f1.focused)
+	assert.ErrorIs(t, d.setFocus(&f1), errors.ErrInvalid)
+	assert.Nil(t, d.setFocus(&f2))
+
+	m.Close()
+	assert.Nil(t, d.setFocus(&f1))
+	d.onKeyEvent(KeyEvent{Char: 'b'})
+	assert.Equal(t, []KeyEvent{{Char: 'b'}}, f1.received)
+}
+
+func Test_display_onKeyEvent(t *testing.T) {
+	d := newDisplay(DefaultDisplayConfig(), &testProxy{})
+	var c _display_test_container
+	var f1, f2, f3 _display_test_focusable
+	assert.Nil(t, f1.Init(&d.root, &f1))
+	assert.Nil(t, c.Init(&d.root, &c))
+	assert.Nil(t, f2.Init(&c, &f2))
+	assert.Nil(t, f3.Init(&d.root, &f3))
+
+	d.onKeyEvent(KeyEvent{Key: rl.KeyTab})
+	assert.Same(t, &f1, d.focused)
+	assert.Equal(t, 0, len(f1.received))
+	d.onKeyEvent(KeyEvent{Key: rl.KeyTab})
+	assert.Same(t, &f2, d.focused)
+	assert.Equal(t, 1, len(f1.received))
+	d.onKeyEvent(KeyEvent{Key: rl.KeyDown})
+	assert.Same(t, &f3, d.focused)
+	d.onKeyEvent(KeyEvent{Key: rl.KeyRight})
+	assert.Same(t, &f1, d.focused)
+	d.onKeyEvent(KeyEvent{Key: rl.KeyTab, Modifiers: KeyModShift})
+	assert.Same(t, &f3, d.focused)
+	d.onKeyEvent(KeyEvent{Key: rl.KeyUp})
+	assert.Same(t, &f2, d.focused)
+
+	// invisible containers are skipped
+	c.SetVisible(false)
+	d.onKeyEvent(KeyEvent{Key: rl.KeyLeft})
+	assert.Same(t, &f3, d.focused)
+	d.onKeyEvent(KeyEvent{Key: rl.KeyRight})
+	assert.Same(t, &f1, d.focused)
+
+	// the focused component consumes the navigation keys
+	f1.consume = true
+	d.onKeyEvent(KeyEvent{Key: rl.KeyTab})
+	d.onKeyEvent(KeyEvent{Char: 'a'})
+	assert.Same(t, &f1, d.focused)
+	assert.Equal(t, KeyEvent{Char: 'a'}, f1.received[len(f1.received)-1])
+
+	assert.Nil(t, d.setFocus(nil))
+	d.onKeyEvent(KeyEvent{Key: rl.KeyUp})
+	assert.Same(t, &f3, d.focused)
+}
+
+func Test_display_formFrameKeys(t *testing.T) {
+	pxy := &testProxy{}
+	d := newDisplay(DefaultDisplayConfig(), pxy)
+	var f _display_test_focusable
+	assert.Nil(t, f.Init(&d.root, &f))
+	f.consume = true
+	assert.Nil(t, d.setFocus(&f))
+	pxy.keys = []int32{rl.KeyBackspace}
+	pxy.chars = []int32{'z'}
+	d.formFrame(10)
+	assert.Equal(t, []KeyEvent{{Key: rl.KeyBackspace, Millis: 10}, {Char: 'z', Millis: 10}}, f.received)
+}
+
+func Test_display_formFrameClaimedRelease(t *testing.T) {
+	pxy := &testProxy{}
+	d := newDisplay(DefaultDisplayConfig(), pxy)
+	var parent, child _display_test_container
+	assert.Nil(t, parent.Init(&d.root, &parent))
+	parent.SetBounds(rl.RectangleInt32{X: 0, Y: 0, Width: 100, Height: 100})
+	assert.Nil(t, child.Init(&parent, &child))
+	child.SetBounds(rl.RectangleInt32{X: 0, Y: 0, Width: 10, Height: 10})
+	parent.onTPSResult = OnTPSResultLocked
+
+	pxy.mousePos = rl.Vector2{X: 4, Y: 5}
+	d.formFrame(1)
+	assert.Equal(t, 1, child.ontpsstate)
+	assert.Equal(t, 1, parent.ontpsstate)
+	assert.Same(t, &parent, d.tpsAcceptor)
+
+	pxy.mouseDiff = rl.Vector2{X: 20, Y: 0}
+	pxy.mousePos = rl.Vector2{X: 24, Y: 5}
+	d.formFrame(2)
+	assert.Equal(t, 1, child.ontpsstate)
+	assert.Equal(t, 2, parent.ontpsstate)
+
+	// the release is seen by the acceptor only
+	parent.onTPSResult = OnTPSResultNA
+	pxy.mouseDiff = rl.Vector2{}
+	pxy.mousePos = rl.Vector2{}
+	d.formFrame(3)
+	assert.Equal(t, 1, child.ontpsstate)
+	assert.Equal(t, 3, parent.ontpsstate)
+	assert.Nil(t, d.tpsAcceptor)
+}
+
+type _display_test_key_listener struct {
+	BaseComponent
+	keys []int32
+}
+
+func (kl *_display_test_key_listener) OnUnhandledKey(ke KeyEvent) bool {
+	kl.keys = append(kl.keys, ke.Key)
+	return ke.Key == rl.KeyEscape
+}
+
+func Test_display_walkForKeyListener(t *testing.T) {
+	d := newDisplay(DefaultDisplayConfig(), &testProxy{})
+	var kl1, kl2 _display_test_key_listener
+	assert.Nil(t, kl1.Init(&d.root, &kl1))
+	assert.Nil(t, kl2.Init(&d.root, &kl2))
+	d.onKeyEvent(KeyEvent{Key: rl.KeyEscape})
+	assert.Nil(t, kl1.keys)
+	assert.Equal(t, []int32{rl.KeyEscape}, kl2.keys)
+
+	d.onKeyEvent(KeyEvent{Key: rl.KeyA})
+	assert.Equal(t, []int32{rl.KeyA}, kl1.keys)
+	assert.Equal(t, []int32{rl.KeyEscape, rl.KeyA}, kl2.keys)
+
+	kl2.SetVisible(false)
+	d.onKeyEvent(KeyEvent{Key: rl.KeyEscape})
+	assert.Equal(t, []int32{rl.KeyA, rl.KeyEscape}, kl1.keys)
+	assert.Equal(t, 2, len(kl2.keys))
+
+	// the focus moving keys are not offered
+	d.onKeyEvent(KeyEvent{Key: rl.KeyTab})
+	assert.Equal(t, 2, len(kl1.keys))
+}
+
+type _display_test_modal struct {
+	_display_test_container
+	keys []int32
+}
+
+func (dm *_display_test_modal) IsModal() bool {
+	return true
+}
+
+func (dm *_display_test_modal) OnUnhandledKey(ke KeyEvent) bool {
+	dm.keys = append(dm.keys, ke.Key)
+	return false
+}
+
+func Test_display_modal(t *testing.T) {
+	d := newDisplay(DefaultDisplayConfig(), &testProxy{})
+	var below _display_test_container
+	var m _display_test_modal
+	var f1, f2 _display_test_focusable
+	var kl _display_test_key_listener
+	assert.Nil(t, kl.Init(&d.root, &kl))
+	assert.Nil(t, below.Init(&d.root, &below))
+	below.SetBounds(rl.RectangleInt32{X: 0, Y: 0, Width: 100, Height: 100})
+	below.onTPSResult = OnTPSResultStop
+	assert.Nil(t, f1.Init(&d.root, &f1))
+	assert.Nil(t, m.Init(&d.root, &m))
+	m.SetBounds(rl.RectangleInt32{X: 10, Y: 10, Width: 20, Height: 20})
+	assert.Nil(t, f2.Init(&m, &f2))
+
+	// the touch outside of the modal is consumed
+	d.tp.pos = rl.Vector2{X: 50, Y: 50}
+	assert.Equal(t, OnTPSResultStop, d.walkForTouchPadChildren(&d.root))
+	assert.Equal(t, 0, below.ontpsstate)
+	assert.Equal(t, 0, m.ontpsstate)
+
+	// the touch within the modal is not passed below, even not accepted
+	d.tp.pos = rl.Vector2{X: 15, Y: 15}
+	assert.Equal(t, OnTPSResultStop, d.walkForTouchPadChildren(&d.root))
+	assert.Equal(t, 0, below.ontpsstate)
+	assert.Equal(t, 1, m.ontpsstate)
+
+	// the focus is moved within the modal only
+	d.onKeyEvent(KeyEvent{Key: rl.KeyTab})
+	assert.Same(t, &f2, d.focused)
+	d.onKeyEvent(KeyEvent{Key: rl.KeyTab})
+	assert.Same(t, &f2, d.focused)
+
+	// the key listeners below don't receive the keys
+	d.onKeyEvent(KeyEvent{Key: rl.KeyEscape})
+	assert.Equal(t, []int32{rl.KeyEscape}, m.keys)
+	assert.Nil(t, kl.keys)
+
+	m.SetVisible(false)
+	assert.Equal(t, OnTPSResultStop, d.walkForTouchPadChildren(&d.root))
+	assert.Equal(t, 1, below.ontpsstate)
+	d.onKeyEvent(KeyEvent{Key: rl.KeyTab})
+	assert.Same(t, &f1, d.focused)
+	d.onKeyEvent(KeyEvent{Key: rl.KeyEscape})
+	assert.Equal(t, []int32{rl.KeyEscape}, kl.keys)
+}
+
+type _display_test_overlay struct {
+	_display_test_container
+}
+
+func (do *_display_test_overlay) IsOverlay() bool {
+	return true
+}
+
+func Test_display_overlay(t *testing.T) {
+	d := newDisplay(DefaultDisplayConfig(), &testProxy{})
+	var c _display_test_container
+	var o _display_test_overlay
+	var m _display_test_modal
+	assert.Nil(t, c.Init(&d.root, &c))
+	assert.Nil(t, o.Init(&d.root, &o))
+	assert.Nil(t, m.Init(&d.root, &m))
+	children := d.root.Children()
+	assert.Equal(t, []Component{&c, &m, &o}, inputOrder(children))
+	assert.Equal(t, []Component{&c, &o, &m}, children)
+	assert.Equal(t, []Component{&c}, inputOrder([]Component{&c}))
+
+	// the overlay is above the modal component
+	assert.False(t, isBelowModal(&o))
+	assert.True(t, isBelowModal(&c))
+}
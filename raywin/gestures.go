@@ -0,0 +1,452 @@
+package raywin
+
+// Copyright 2025 Dmitry Spasibenko
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+import (
+	rl "github.com/gen2brain/raylib-go/raylib"
+	"math"
+)
+
+type (
+	// GestureState is the state of a GestureRecognizer reported for every TPState
+	GestureState int
+
+	// GestureRecognizer is the interface implemented by the gesture recognizers. The recognizers
+	// are fed by the TPState notifications and call their callbacks when a gesture is recognized.
+	// A recognizer tracks the primary touch point only, and only the touch sequences it has
+	// observed from the press (TPStatePressed). Several recognizers may be composed by Gestures.
+	GestureRecognizer interface {
+		// OnGesture feeds the recognizer by the touchpad state tps and returns the recognizer state
+		OnGesture(tps TPState) GestureState
+
+		// Reset drops the current recognizer state, so it will not recognize the current
+		// touch sequence. It is called by Gestures when another recognizer wins.
+		Reset()
+	}
+
+	// Gestures composes several recognizers and implements Touchpadable, so a component may
+	// embed it or call its OnTPState from the component OnTPState. The recognizers are fed in
+	// the order they are provided. The first recognizer which reports GestureStateActive or
+	// GestureStateRecognized wins the touch sequence: all other recognizers are reset, and the
+	// active recognizer becomes the only one fed until it leaves GestureStateActive.
+	//
+	// Gestures locks the touchpad (OnTPSResultLocked) while any of its recognizers is possible
+	// or active, so the components below don't receive the touch sequence. The recognizers that
+	// are not sure anymore (e.g. the point is moved out of a LongPressRecognizer radius) report
+	// GestureStateNA and unlock the touchpad, so the touch sequence may be claimed by a parent
+	// component, like ScrollableContainer. A parent, which must win over its children, may
+	// feed its Gestures from TouchInterceptor.InterceptTPState.
+	Gestures struct {
+		recognizers []GestureRecognizer
+		winner      GestureRecognizer
+	}
+
+	// LongPressRecognizer recognizes the point is pressed and held within the radius for
+	// the delay time
+	LongPressRecognizer struct {
+		gestureTracker
+		radius      float32
+		delayMillis int64
+		onLongPress func(pos rl.Vector2)
+		fired       bool
+	}
+
+	// DoubleTapRecognizer recognizes two taps made within the interval and the radius
+	DoubleTapRecognizer struct {
+		gestureTracker
+		radius         float32
+		intervalMillis int64
+		onDoubleTap    func(pos rl.Vector2)
+		tapped         bool
+		tapMillis      int64
+		tapPos         rl.Vector2
+	}
+
+	// SwipeRecognizer recognizes the quick move of the point in one of the four directions.
+	// The swipe is claimed (GestureStateActive) when the point moves for more than minDistance,
+	// and it is reported when the point is released with the velocity at least minVelocity
+	// pixels per second.
+	SwipeRecognizer struct {
+		gestureTracker
+		minDistance float32
+		minVelocity float32
+		onSwipe     func(dir int, velocity rl.Vector2)
+		active      bool
+	}
+
+	// DragRecognizer recognizes dragging of the point. The drag starts when the point moves for
+	// more than threshold pixels, and it is reported by the onStart, onMove and onEnd callbacks.
+	DragRecognizer struct {
+		gestureTracker
+		threshold float32
+		onStart   func(pos rl.Vector2)
+		onMove    func(pos, delta rl.Vector2)
+		onEnd     func(pos rl.Vector2)
+		dragging  bool
+		lastPos   rl.Vector2
+	}
+
+	// FlingRecognizer recognizes the point is released while it is moving with the velocity
+	// at least minVelocity pixels per second. Unlike SwipeRecognizer it never claims the touch
+	// sequence before the release.
+	FlingRecognizer struct {
+		gestureTracker
+		minVelocity float32
+		onFling     func(velocity rl.Vector2)
+	}
+
+	gestureTracker struct {
+		tracking    bool
+		pressSeq    int64
+		startPos    rl.Vector2
+		startMillis int64
+		samples     []gestureSample
+	}
+
+	gestureSample struct {
+		pos    rl.Vector2
+		millis int64
+	}
+)
+
+const (
+	// GestureStateNA indicates that the recognizer doesn't track the touch sequence
+	GestureStateNA = GestureState(iota)
+	// GestureStatePossible indicates that the recognizer tracks the touch sequence, and
+	// the gesture may be recognized
+	GestureStatePossible
+	// GestureStateActive indicates that the continuous gesture is recognized and it is in progress
+	GestureStateActive
+	// GestureStateRecognized indicates that the gesture is recognized and completed in the frame
+	GestureStateRecognized
+)
+
+const (
+	// SwipeLeft is the direction of the swipe to the left
+	SwipeLeft = iota
+	// SwipeRight is the direction of the swipe to the right
+	SwipeRight
+	// SwipeUp is the direction of the swipe up
+	SwipeUp
+	// SwipeDown is the direction of the swipe down
+	SwipeDown
+)
+
+// velocityWindowMillis is the time window used for the velocity calculation
+const velocityWindowMillis = 100
+
+var _ Touchpadable = (*Gestures)(nil)
+
+// InitGestures sets up the recognizers for the Gestures
+func (g *Gestures) InitGestures(recognizers ...GestureRecognizer) {
+	g.recognizers = recognizers
+	g.winner = nil
+}
+
+// OnTPState implements Touchpadable
+func (g *Gestures) OnTPState(tps TPState) OnTPSResult {
+	if g.winner != nil {
+		st := g.winner.OnGesture(tps)
+		if st == GestureStateActive {
+			return OnTPSResultLocked
+		}
+		g.winner = nil
+		if st == GestureStateRecognized {
+			return OnTPSResultStop
+		}
+		return OnTPSResultNA
+	}
+	possible := false
+	for _, r := range g.recognizers {
+		st := r.OnGesture(tps)
+		switch st {
+		case GestureStateActive, GestureStateRecognized:
+			for _, r1 := range g.recognizers {
+				if r1 != r {
+					r1.Reset()
+				}
+			}
+			if st == GestureStateActive {
+				g.winner = r
+				return OnTPSResultLocked
+			}
+			return OnTPSResultStop
+		case GestureStatePossible:
+			possible = true
+		}
+	}
+	if possible && (tps.State == TPStatePressed || tps.State == TPStateMoving) {
+		return OnTPSResultLocked
+	}
+	return OnTPSResultNA
+}
+
+// NewLongPressRecognizer creates the LongPressRecognizer, the onLongPress is called with
+// the press position when the point is held for delayMillis
+func NewLongPressRecognizer(radius float32, delayMillis int64, onLongPress func(pos rl.Vector2)) *LongPressRecognizer {
+	return &LongPressRecognizer{radius: radius, delayMillis: delayMillis, onLongPress: onLongPress}
+}
+
+// OnGesture implements GestureRecognizer
+func (lp *LongPressRecognizer) OnGesture(tps TPState) GestureState {
+	if !lp.track(tps) || tps.State == TPStateReleased {
+		lp.Reset()
+		return GestureStateNA
+	}
+	if lp.fired {
+		return GestureStateActive
+	}
+	if distance(lp.startPos, tps.Pos) > lp.radius {
+		lp.Reset()
+		return GestureStateNA
+	}
+	if tps.Millis-lp.startMillis >= lp.delayMillis {
+		lp.fired = true
+		if lp.onLongPress != nil {
+			lp.onLongPress(lp.startPos)
+		}
+		return GestureStateActive
+	}
+	return GestureStatePossible
+}
+
+// Reset implements GestureRecognizer
+func (lp *LongPressRecognizer) Reset() {
+	lp.gestureTracker.reset()
+	lp.fired = false
+}
+
+// NewDoubleTapRecognizer creates the DoubleTapRecognizer, the onDoubleTap is called with
+// the second tap position
+func NewDoubleTapRecognizer(radius float32, intervalMillis int64, onDoubleTap func(pos rl.Vector2)) *DoubleTapRecognizer {
+	return &DoubleTapRecognizer{radius: radius, intervalMillis: intervalMillis, onDoubleTap: onDoubleTap}
+}
+
+// OnGesture implements GestureRecognizer
+func (dt *DoubleTapRecognizer) OnGesture(tps TPState) GestureState {
+	if dt.tapped && tps.Millis-dt.tapMillis > dt.intervalMillis {
+		dt.tapped = false
+	}
+	if tps.State == TPStateReleased && dt.tracking {
+		dt.gestureTracker.reset()
+		if !dt.tapped {
+			dt.tapped = true
+			dt.tapMillis = tps.Millis
+			dt.tapPos = tps.Pos
+			return GestureStatePossible
+		}
+		dt.tapped = false
+		if dt.onDoubleTap != nil {
+			dt.onDoubleTap(tps.Pos)
+		}
+		return GestureStateRecognized
+	}
+	if !dt.track(tps) {
+		return GestureStateNA
+	}
+	if distance(dt.startPos, tps.Pos) > dt.radius || (dt.tapped && distance(dt.tapPos, dt.startPos) > dt.radius) {
+		dt.Reset()
+		return GestureStateNA
+	}
+	return GestureStatePossible
+}
+
+// Reset implements GestureRecognizer
+func (dt *DoubleTapRecognizer) Reset() {
+	dt.gestureTracker.reset()
+	dt.tapped = false
+}
+
+// NewSwipeRecognizer creates the SwipeRecognizer. The onSwipe is called with the swipe
+// direction (SwipeLeft etc.) and the velocity in pixels per second
+func NewSwipeRecognizer(minDistance, minVelocity float32, onSwipe func(dir int, velocity rl.Vector2)) *SwipeRecognizer {
+	return &SwipeRecognizer{minDistance: minDistance, minVelocity: minVelocity, onSwipe: onSwipe}
+}
+
+// OnGesture implements GestureRecognizer
+func (sr *SwipeRecognizer) OnGesture(tps TPState) GestureState {
+	if tps.State == TPStateReleased && sr.tracking {
+		sr.track(tps)
+		v := sr.velocity()
+		active := sr.active
+		sr.Reset()
+		if !active || length(v) < sr.minVelocity {
+			return GestureStateNA
+		}
+		if sr.onSwipe != nil {
+			sr.onSwipe(swipeDirection(v), v)
+		}
+		return GestureStateRecognized
+	}
+	if !sr.track(tps) {
+		return GestureStateNA
+	}
+	if !sr.active && distance(sr.startPos, tps.Pos) >= sr.minDistance {
+		sr.active = true
+	}
+	if sr.active {
+		return GestureStateActive
+	}
+	return GestureStatePossible
+}
+
+// Reset implements GestureRecognizer
+func (sr *SwipeRecognizer) Reset() {
+	sr.gestureTracker.reset()
+	sr.active = false
+}
+
+// NewDragRecognizer creates the DragRecognizer. onStart is called with the position where
+// the drag starts, onMove is called with the new position and the move since the previous
+// call, and onEnd is called with the position where the point is released. Any of the
+// callbacks may be nil.
+func NewDragRecognizer(threshold float32, onStart func(pos rl.Vector2), onMove func(pos, delta rl.Vector2), onEnd func(pos rl.Vector2)) *DragRecognizer {
+	return &DragRecognizer{threshold: threshold, onStart: onStart, onMove: onMove, onEnd: onEnd}
+}
+
+// OnGesture implements GestureRecognizer
+func (dr *DragRecognizer) OnGesture(tps TPState) GestureState {
+	if tps.State == TPStateReleased && dr.tracking {
+		dragging := dr.dragging
+		dr.Reset()
+		if !dragging {
+			return GestureStateNA
+		}
+		if dr.onEnd != nil {
+			dr.onEnd(tps.Pos)
+		}
+		return GestureStateRecognized
+	}
+	if !dr.track(tps) {
+		return GestureStateNA
+	}
+	if !dr.dragging {
+		if distance(dr.startPos, tps.Pos) < dr.threshold {
+			return GestureStatePossible
+		}
+		dr.dragging = true
+		dr.lastPos = tps.Pos
+		if dr.onStart != nil {
+			dr.onStart(tps.Pos)
+		}
+		return GestureStateActive
+	}
+	if tps.Pos != dr.lastPos && dr.onMove != nil {
+		dr.onMove(tps.Pos, VectorDiff(tps.Pos, dr.lastPos))
+	}
+	dr.lastPos = tps.Pos
+	return GestureStateActive
+}
+
+// Reset implements GestureRecognizer
+func (dr *DragRecognizer) Reset() {
+	dr.gestureTracker.reset()
+	dr.dragging = false
+}
+
+// NewFlingRecognizer creates the FlingRecognizer. The onFling is called with the velocity
+// in pixels per second
+func NewFlingRecognizer(minVelocity float32, onFling func(velocity rl.Vector2)) *FlingRecognizer {
+	return &FlingRecognizer{minVelocity: minVelocity, onFling: onFling}
+}
+
+// OnGesture implements GestureRecognizer
+func (fr *FlingRecognizer) OnGesture(tps TPState) GestureState {
+	if tps.State == TPStateReleased && fr.tracking {
+		fr.track(tps)
+		v := fr.velocity()
+		fr.Reset()
+		if length(v) < fr.minVelocity {
+			return GestureStateNA
+		}
+		if fr.onFling != nil {
+			fr.onFling(v)
+		}
+		return GestureStateRecognized
+	}
+	if !fr.track(tps) {
+		return GestureStateNA
+	}
+	return GestureStatePossible
+}
+
+// Reset implements GestureRecognizer
+func (fr *FlingRecognizer) Reset() {
+	fr.gestureTracker.reset()
+}
+
+// track starts tracking on a new press and records the point position. It returns
+// whether the touch sequence is tracked
+func (gt *gestureTracker) track(tps TPState) bool {
+	if tps.State == TPStatePressed && tps.Sequence != gt.pressSeq {
+		gt.reset()
+		gt.tracking = true
+		gt.pressSeq = tps.Sequence
+		gt.startPos = tps.Pos
+		gt.startMillis = tps.Millis
+	}
+	if !gt.tracking || tps.State == TPStateNA {
+		gt.tracking = false
+		return false
+	}
+	// keep one sample older than the window to measure the velocity for the whole window
+	idx := 0
+	for idx < len(gt.samples)-1 && tps.Millis-gt.samples[idx+1].millis >= velocityWindowMillis {
+		idx++
+	}
+	gt.samples = append(gt.samples[idx:], gestureSample{pos: tps.Pos, millis: tps.Millis})
+	return true
+}
+
+// velocity returns the point velocity (pixels per second) measured for the last samples
+func (gt *gestureTracker) velocity() rl.Vector2 {
+	if len(gt.samples) < 2 {
+		return rl.Vector2{}
+	}
+	first := gt.samples[0]
+	last := gt.samples[len(gt.samples)-1]
+	dt := float32(last.millis-first.millis) / 1000.0
+	if dt <= 0 {
+		return rl.Vector2{}
+	}
+	d := VectorDiff(last.pos, first.pos)
+	return rl.Vector2{X: d.X / dt, Y: d.Y / dt}
+}
+
+func (gt *gestureTracker) reset() {
+	gt.tracking = false
+	gt.samples = gt.samples[:0]
+}
+
+func swipeDirection(v rl.Vector2) int {
+	if math.Abs(float64(v.X)) >= math.Abs(float64(v.Y)) {
+		if v.X < 0 {
+			return SwipeLeft
+		}
+		return SwipeRight
+	}
+	if v.Y < 0 {
+		return SwipeUp
+	}
+	return SwipeDown
+}
+
+func distance(v1, v2 rl.Vector2) float32 {
+	return length(VectorDiff(v1, v2))
+}
+
+func length(v rl.Vector2) float32 {
+	return float32(math.Sqrt(float64(v.X*v.X) + float64(v.Y*v.Y)))
+}
@@ -0,0 +1,157 @@
+package raywin
+
+// Copyright 2025 Dmitry Spasibenko
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+import (
+	rl "github.com/gen2brain/raylib-go/raylib"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestLongPressRecognizer(t *testing.T) {
+	var pos []rl.Vector2
+	lp := NewLongPressRecognizer(10, 500, func(p rl.Vector2) { pos = append(pos, p) })
+	assert.Equal(t, GestureStateNA, lp.OnGesture(TPState{State: TPStateMoving, Millis: 1}))
+	assert.Equal(t, GestureStatePossible, lp.OnGesture(TPState{State: TPStatePressed, Pos: rl.Vector2{X: 5, Y: 5}, Millis: 10, Sequence: 1}))
+	assert.Equal(t, GestureStatePossible, lp.OnGesture(TPState{State: TPStateMoving, Pos: rl.Vector2{X: 8, Y: 5}, Millis: 100, Sequence: 2}))
+	assert.Equal(t, GestureStateActive, lp.OnGesture(TPState{State: TPStateMoving, Pos: rl.Vector2{X: 8, Y: 5}, Millis: 510, Sequence: 2}))
+	assert.Equal(t, []rl.Vector2{{X: 5, Y: 5}}, pos)
+	assert.Equal(t, GestureStateActive, lp.OnGesture(TPState{State: TPStateMoving, Pos: rl.Vector2{X: 8, Y: 5}, Millis: 600, Sequence: 2}))
+	assert.Equal(t, GestureStateNA, lp.OnGesture(TPState{State: TPStateReleased, Millis: 700, Sequence: 3}))
+	assert.Equal(t, 1, len(pos))
+
+	// moved out of the radius
+	assert.Equal(t, GestureStatePossible, lp.OnGesture(TPState{State: TPStatePressed, Millis: 800, Sequence: 4}))
+	assert.Equal(t, GestureStateNA, lp.OnGesture(TPState{State: TPStateMoving, Pos: rl.Vector2{X: 20}, Millis: 900, Sequence: 5}))
+	assert.Equal(t, GestureStateNA, lp.OnGesture(TPState{State: TPStateMoving, Pos: rl.Vector2{X: 0}, Millis: 1400, Sequence: 6}))
+	assert.Equal(t, 1, len(pos))
+}
+
+func TestDoubleTapRecognizer(t *testing.T) {
+	taps := 0
+	dt := NewDoubleTapRecognizer(10, 300, func(p rl.Vector2) { taps++ })
+	assert.Equal(t, GestureStatePossible, dt.OnGesture(TPState{State: TPStatePressed, Millis: 10, Sequence: 1}))
+	assert.Equal(t, GestureStatePossible, dt.OnGesture(TPState{State: TPStateReleased, Millis: 50, Sequence: 2}))
+	assert.Equal(t, GestureStateNA, dt.OnGesture(TPState{State: TPStateNA, Millis: 100, Sequence: 2}))
+	assert.Equal(t, GestureStatePossible, dt.OnGesture(TPState{State: TPStatePressed, Pos: rl.Vector2{X: 3}, Millis: 150, Sequence: 3}))
+	assert.Equal(t, GestureStateRecognized, dt.OnGesture(TPState{State: TPStateReleased, Pos: rl.Vector2{X: 3}, Millis: 200, Sequence: 4}))
+	assert.Equal(t, 1, taps)
+
+	// too slow
+	assert.Equal(t, GestureStatePossible, dt.OnGesture(TPState{State: TPStatePressed, Millis: 1000, Sequence: 5}))
+	assert.Equal(t, GestureStatePossible, dt.OnGesture(TPState{State: TPStateReleased, Millis: 1050, Sequence: 6}))
+	assert.Equal(t, GestureStatePossible, dt.OnGesture(TPState{State: TPStatePressed, Millis: 1400, Sequence: 7}))
+	assert.Equal(t, GestureStatePossible, dt.OnGesture(TPState{State: TPStateReleased, Millis: 1450, Sequence: 8}))
+	assert.Equal(t, 1, taps)
+
+	// too far from the first tap
+	assert.Equal(t, GestureStateNA, dt.OnGesture(TPState{State: TPStatePressed, Pos: rl.Vector2{X: 50}, Millis: 1500, Sequence: 9}))
+	assert.Equal(t, GestureStateNA, dt.OnGesture(TPState{State: TPStateReleased, Pos: rl.Vector2{X: 50}, Millis: 1550, Sequence: 10}))
+	assert.Equal(t, 1, taps)
+
+	// the release of a not observed press is ignored
+	dt.Reset()
+	assert.Equal(t, GestureStateNA, dt.OnGesture(TPState{State: TPStateReleased, Millis: 2000, Sequence: 11}))
+	assert.Equal(t, GestureStateNA, dt.OnGesture(TPState{State: TPStateReleased, Millis: 2100, Sequence: 12}))
+	assert.Equal(t, 1, taps)
+}
+
+func TestSwipeRecognizer(t *testing.T) {
+	dir := -1
+	sr := NewSwipeRecognizer(20, 100, func(d int, v rl.Vector2) { dir = d })
+	assert.Equal(t, GestureStatePossible, sr.OnGesture(TPState{State: TPStatePressed, Pos: rl.Vector2{X: 100, Y: 100}, Millis: 0, Sequence: 1}))
+	assert.Equal(t, GestureStatePossible, sr.OnGesture(TPState{State: TPStateMoving, Pos: rl.Vector2{X: 90, Y: 100}, Millis: 20, Sequence: 2}))
+	assert.Equal(t, GestureStateActive, sr.OnGesture(TPState{State: TPStateMoving, Pos: rl.Vector2{X: 70, Y: 105}, Millis: 40, Sequence: 3}))
+	assert.Equal(t, GestureStateRecognized, sr.OnGesture(TPState{State: TPStateReleased, Pos: rl.Vector2{X: 60, Y: 105}, Millis: 60, Sequence: 4}))
+	assert.Equal(t, SwipeLeft, dir)
+
+	// too slow
+	dir = -1
+	assert.Equal(t, GestureStatePossible, sr.OnGesture(TPState{State: TPStatePressed, Pos: rl.Vector2{X: 100, Y: 100}, Millis: 1000, Sequence: 5}))
+	assert.Equal(t, GestureStateActive, sr.OnGesture(TPState{State: TPStateMoving, Pos: rl.Vector2{X: 100, Y: 150}, Millis: 1100, Sequence: 6}))
+	assert.Equal(t, GestureStateActive, sr.OnGesture(TPState{State: TPStateMoving, Pos: rl.Vector2{X: 100, Y: 150}, Millis: 1500, Sequence: 6}))
+	assert.Equal(t, GestureStateNA, sr.OnGesture(TPState{State: TPStateReleased, Pos: rl.Vector2{X: 100, Y: 150}, Millis: 1600, Sequence: 7}))
+	assert.Equal(t, -1, dir)
+
+	assert.Equal(t, SwipeDown, swipeDirection(rl.Vector2{X: 1, Y: 2}))
+	assert.Equal(t, SwipeUp, swipeDirection(rl.Vector2{X: 1, Y: -2}))
+	assert.Equal(t, SwipeRight, swipeDirection(rl.Vector2{X: 3, Y: -2}))
+}
+
+func TestDragRecognizer(t *testing.T) {
+	var events []string
+	dr := NewDragRecognizer(10, func(p rl.Vector2) { events = append(events, "start") },
+		func(p, d rl.Vector2) {
+			assert.Equal(t, rl.Vector2{X: 5}, d)
+			events = append(events, "move")
+		},
+		func(p rl.Vector2) { events = append(events, "end") })
+	assert.Equal(t, GestureStatePossible, dr.OnGesture(TPState{State: TPStatePressed, Millis: 0, Sequence: 1}))
+	assert.Equal(t, GestureStatePossible, dr.OnGesture(TPState{State: TPStateMoving, Pos: rl.Vector2{X: 5}, Millis: 10, Sequence: 2}))
+	assert.Equal(t, GestureStateActive, dr.OnGesture(TPState{State: TPStateMoving, Pos: rl.Vector2{X: 10}, Millis: 20, Sequence: 3}))
+	assert.Equal(t, GestureStateActive, dr.OnGesture(TPState{State: TPStateMoving, Pos: rl.Vector2{X: 15}, Millis: 30, Sequence: 4}))
+	assert.Equal(t, GestureStateActive, dr.OnGesture(TPState{State: TPStateMoving, Pos: rl.Vector2{X: 15}, Millis: 40, Sequence: 4}))
+	assert.Equal(t, GestureStateRecognized, dr.OnGesture(TPState{State: TPStateReleased, Pos: rl.Vector2{X: 15}, Millis: 50, Sequence: 5}))
+	assert.Equal(t, []string{"start", "move", "end"}, events)
+
+	assert.Equal(t, GestureStatePossible, dr.OnGesture(TPState{State: TPStatePressed, Millis: 100, Sequence: 6}))
+	assert.Equal(t, GestureStateNA, dr.OnGesture(TPState{State: TPStateReleased, Millis: 110, Sequence: 7}))
+	assert.Equal(t, 3, len(events))
+}
+
+func TestFlingRecognizer(t *testing.T) {
+	var vel rl.Vector2
+	fr := NewFlingRecognizer(500, func(v rl.Vector2) { vel = v })
+	assert.Equal(t, GestureStatePossible, fr.OnGesture(TPState{State: TPStatePressed, Millis: 0, Sequence: 1}))
+	assert.Equal(t, GestureStatePossible, fr.OnGesture(TPState{State: TPStateMoving, Pos: rl.Vector2{Y: 10}, Millis: 100, Sequence: 2}))
+	assert.Equal(t, GestureStatePossible, fr.OnGesture(TPState{State: TPStateMoving, Pos: rl.Vector2{Y: 40}, Millis: 150, Sequence: 3}))
+	assert.Equal(t, GestureStateRecognized, fr.OnGesture(TPState{State: TPStateReleased, Pos: rl.Vector2{Y: 70}, Millis: 200, Sequence: 4}))
+	// the velocity is measured for the last 100ms
+	assert.Equal(t, rl.Vector2{Y: 600}, vel)
+
+	vel = rl.Vector2{}
+	assert.Equal(t, GestureStatePossible, fr.OnGesture(TPState{State: TPStatePressed, Millis: 1000, Sequence: 5}))
+	assert.Equal(t, GestureStateNA, fr.OnGesture(TPState{State: TPStateReleased, Pos: rl.Vector2{Y: 10}, Millis: 1100, Sequence: 6}))
+	assert.Equal(t, rl.Vector2{}, vel)
+}
+
+func TestGestures_OnTPState(t *testing.T) {
+	var events []string
+	var g Gestures
+	g.InitGestures(
+		NewDoubleTapRecognizer(10, 300, func(p rl.Vector2) { events = append(events, "double") }),
+		NewDragRecognizer(10, func(p rl.Vector2) { events = append(events, "start") }, nil,
+			func(p rl.Vector2) { events = append(events, "end") }),
+		NewFlingRecognizer(100, func(v rl.Vector2) { events = append(events, "fling") }))
+
+	assert.Equal(t, OnTPSResultLocked, g.OnTPState(TPState{State: TPStatePressed, Millis: 0, Sequence: 1}))
+	assert.Equal(t, OnTPSResultNA, g.OnTPState(TPState{State: TPStateReleased, Millis: 50, Sequence: 2}))
+	assert.Equal(t, OnTPSResultLocked, g.OnTPState(TPState{State: TPStatePressed, Millis: 100, Sequence: 3}))
+	// the drag wins, so the double tap and the fling are not reported
+	assert.Equal(t, OnTPSResultLocked, g.OnTPState(TPState{State: TPStateMoving, Pos: rl.Vector2{X: 50}, Millis: 120, Sequence: 4}))
+	assert.Equal(t, OnTPSResultLocked, g.OnTPState(TPState{State: TPStateMoving, Pos: rl.Vector2{X: 100}, Millis: 140, Sequence: 5}))
+	assert.Equal(t, OnTPSResultStop, g.OnTPState(TPState{State: TPStateReleased, Pos: rl.Vector2{X: 150}, Millis: 160, Sequence: 6}))
+	assert.Equal(t, []string{"start", "end"}, events)
+
+	// double tap
+	events = nil
+	assert.Equal(t, OnTPSResultLocked, g.OnTPState(TPState{State: TPStatePressed, Millis: 1000, Sequence: 7}))
+	assert.Equal(t, OnTPSResultNA, g.OnTPState(TPState{State: TPStateReleased, Millis: 1050, Sequence: 8}))
+	assert.Equal(t, OnTPSResultLocked, g.OnTPState(TPState{State: TPStatePressed, Millis: 1100, Sequence: 9}))
+	assert.Equal(t, OnTPSResultStop, g.OnTPState(TPState{State: TPStateReleased, Millis: 1150, Sequence: 10}))
+	assert.Equal(t, []string{"double"}, events)
+
+	assert.Equal(t, OnTPSResultNA, g.OnTPState(TPState{State: TPStateNA, Millis: 1200, Sequence: 10}))
+}
@@ -0,0 +1,145 @@
+package raywin
+
+// Copyright 2025 Dmitry Spasibenko
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+import (
+	rl "github.com/gen2brain/raylib-go/raylib"
+)
+
+type (
+	// KeyEvent describes a keyboard event read in a frame. There are two kinds of
+	// the events: key events (Key != 0) and character events (Char != 0). A key event
+	// is reported when a key is pressed or auto-repeated while it is held down. A character
+	// event is reported for every character typed, taking into account the keyboard layout
+	// and modifiers, so the text input components should use the character events for
+	// typing and the key events for the control keys (Backspace, arrows etc.)
+	KeyEvent struct {
+		// Key contains the raylib key code (rl.KeyEnter, rl.KeyA etc.) or 0 if the
+		// event is a character event
+		Key int32
+		// Char contains the typed unicode character or 0 if the event is a key event
+		Char rune
+		// Repeat is true if the key event is generated by the key auto-repeat
+		Repeat bool
+		// Modifiers contains the modifier keys (KeyModShift etc.) held when the event is read
+		Modifiers int
+		// Millis contains the timestamp of the frame where the event is read
+		Millis int64
+	}
+
+	// Focusable interface maybe implemented by a component to let raywin-go know
+	// that the component can hold the input focus and receive the keyboard events.
+	// Only one component may hold the focus at a time (see SetFocus()). The focus
+	// can be moved between the visible Focusable components by Tab (Shift+Tab) and
+	// arrow keys, if the focused component does not consume them.
+	Focusable interface {
+		// OnFocusChanged is called when the component gains (focused == true) or
+		// loses (focused == false) the input focus.
+		OnFocusChanged(focused bool)
+
+		// OnKeyEvent is called for the focused component for every KeyEvent read
+		// in the frame. The function must return true if the event is consumed by
+		// the component, and false if raywin may handle the event (e.g. to move
+		// the focus to the next component)
+		OnKeyEvent(ke KeyEvent) bool
+	}
+
+	// KeyListener interface maybe implemented by a component to receive the key events,
+	// which are not consumed by the focused component and not used for the focus moving.
+	// The visible listeners are walked from the topmost component to the bottom one, until
+	// a listener consumes the event. This is how the Navigator handles the back key.
+	KeyListener interface {
+		// OnUnhandledKey is called for the not consumed key event, it returns true if
+		// the event is consumed by the listener
+		OnUnhandledKey(ke KeyEvent) bool
+	}
+)
+
+const (
+	// KeyModShift indicates that a Shift key is held
+	KeyModShift = 1 << iota
+	// KeyModControl indicates that a Control key is held
+	KeyModControl
+	// KeyModAlt indicates that an Alt key is held
+	KeyModAlt
+	// KeyModSuper indicates that a Super (Command, Windows) key is held
+	KeyModSuper
+)
+
+// IsChar returns whether the event is a character event
+func (ke KeyEvent) IsChar() bool {
+	return ke.Char != 0
+}
+
+type keyboard struct {
+	// held contains the keys pressed and not released yet
+	held   []int32
+	events []KeyEvent
+}
+
+var modKeys = []struct {
+	key int32
+	mod int
+}{
+	{rl.KeyLeftShift, KeyModShift}, {rl.KeyRightShift, KeyModShift},
+	{rl.KeyLeftControl, KeyModControl}, {rl.KeyRightControl, KeyModControl},
+	{rl.KeyLeftAlt, KeyModAlt}, {rl.KeyRightAlt, KeyModAlt},
+	{rl.KeyLeftSuper, KeyModSuper}, {rl.KeyRightSuper, KeyModSuper},
+}
+
+// onNewFrame reads the keyboard events for the frame. The returned slice is valid
+// until the next call of onNewFrame.
+func (kb *keyboard) onNewFrame(millis int64, proxy RlProxy) []KeyEvent {
+	kb.events = kb.events[:0]
+	mods := 0
+	for _, mk := range modKeys {
+		if proxy.IsKeyDown(mk.key) {
+			mods |= mk.mod
+		}
+	}
+
+	held := kb.held[:0]
+	for _, k := range kb.held {
+		if !proxy.IsKeyDown(k) {
+			continue
+		}
+		held = append(held, k)
+		if proxy.IsKeyPressedRepeat(k) {
+			kb.events = append(kb.events, KeyEvent{Key: k, Repeat: true, Modifiers: mods, Millis: millis})
+		}
+	}
+	kb.held = held
+
+	for k := proxy.GetKeyPressed(); k != 0; k = proxy.GetKeyPressed() {
+		kb.events = append(kb.events, KeyEvent{Key: k, Modifiers: mods, Millis: millis})
+		if !kb.isHeld(k) {
+			kb.held = append(kb.held, k)
+		}
+	}
+
+	for ch := proxy.GetCharPressed(); ch != 0; ch = proxy.GetCharPressed() {
+		kb.events = append(kb.events, KeyEvent{Char: rune(ch), Modifiers: mods, Millis: millis})
+	}
+	return kb.events
+}
+
+func (kb *keyboard) isHeld(k int32) bool {
+	for _, k1 := range kb.held {
+		if k1 == k {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,55 @@
+package raywin
+
+// Copyright 2025 Dmitry Spasibenko
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+import (
+	rl "github.com/gen2brain/raylib-go/raylib"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func Test_keyboard_onNewFrame(t *testing.T) {
+	var kb keyboard
+	pxy := &testProxy{keysDown: map[int32]bool{}, keysRepeat: map[int32]bool{}}
+	assert.Equal(t, 0, len(kb.onNewFrame(1, pxy)))
+
+	pxy.keys = []int32{rl.KeyA, rl.KeyB}
+	pxy.chars = []int32{'a', 'b'}
+	pxy.keysDown[rl.KeyA] = true
+	pxy.keysDown[rl.KeyLeftShift] = true
+	evs := kb.onNewFrame(2, pxy)
+	assert.Equal(t, []KeyEvent{
+		{Key: rl.KeyA, Modifiers: KeyModShift, Millis: 2},
+		{Key: rl.KeyB, Modifiers: KeyModShift, Millis: 2},
+		{Char: 'a', Modifiers: KeyModShift, Millis: 2},
+		{Char: 'b', Modifiers: KeyModShift, Millis: 2},
+	}, evs)
+	assert.False(t, evs[0].IsChar())
+	assert.True(t, evs[2].IsChar())
+	assert.Equal(t, []int32{rl.KeyA, rl.KeyB}, kb.held)
+
+	// KeyB is released, KeyA is repeated
+	delete(pxy.keysDown, rl.KeyLeftShift)
+	pxy.keysRepeat[rl.KeyA] = true
+	pxy.keysRepeat[rl.KeyB] = true
+	assert.Equal(t, []KeyEvent{{Key: rl.KeyA, Repeat: true, Millis: 3}}, kb.onNewFrame(3, pxy))
+	assert.Equal(t, []int32{rl.KeyA}, kb.held)
+
+	pxy.keysDown = map[int32]bool{rl.KeyRightControl: true, rl.KeyLeftAlt: true}
+	assert.Equal(t, 0, len(kb.onNewFrame(4, pxy)))
+	assert.Equal(t, 0, len(kb.held))
+	pxy.chars = []int32{'c'}
+	assert.Equal(t, []KeyEvent{{Char: 'c', Modifiers: KeyModControl | KeyModAlt, Millis: 5}}, kb.onNewFrame(5, pxy))
+}
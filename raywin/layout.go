@@ -0,0 +1,581 @@
+package raywin
+
+// Copyright 2025 Dmitry Spasibenko
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+import (
+	"fmt"
+	"github.com/dspasibenko/raywin-go/pkg/golibs/errors"
+	rl "github.com/gen2brain/raylib-go/raylib"
+	"sync"
+)
+
+type (
+	// Layouter interface may be implemented by a Container, which arranges its children
+	// bounds automatically. raywin calls Layout() before the frame is formed, if the container
+	// layout is requested (see BaseContainer.RequestLayout()). The children bounds are
+	// relative to the container, as usual.
+	Layouter interface {
+		// Layout is called to re-arrange the container children. It is called when a child
+		// is added or removed, a child visibility is changed or the container bounds are changed.
+		Layout()
+	}
+
+	// Insets defines the space between the container edges and its children
+	Insets struct {
+		Left, Top, Right, Bottom int32
+	}
+
+	// LayoutParams defines how a child is arranged by BoxLayout
+	LayoutParams struct {
+		// Weight is the flex weight of the child. The children with 0 weight keep their size
+		// along the box axis, and the rest of the space is shared between the children with
+		// positive weights proportionally to the weights.
+		Weight float32
+		// Align overrides the box alignment across the box axis for the child. The
+		// LayoutAlignDefault value means the box alignment is used.
+		Align int
+	}
+
+	// BoxLayout is the container, which arranges its visible children in a row (horizontal box)
+	// or in a column (vertical box) in the order they are added. The children are separated by
+	// the spacing, and the whole row is surrounded by the padding.
+	BoxLayout struct {
+		BaseContainer
+
+		cfg    BoxConfig
+		plock  sync.Mutex
+		params map[Component]LayoutParams
+		sizes  layoutSizes
+	}
+
+	// BoxConfig allows to specify the BoxLayout settings
+	BoxConfig struct {
+		vertical bool
+		spacing  int32
+		padding  Insets
+		align    int
+		justify  int
+		rect     rl.RectangleInt32
+	}
+
+	// GridLayout is the container, which arranges its visible children in the grid cells of
+	// the same size. The children fill the cells row by row in the order they are added. The
+	// children, which don't fit into the grid, are collapsed to the empty bounds.
+	GridLayout struct {
+		BaseContainer
+
+		cfg GridConfig
+	}
+
+	// GridConfig allows to specify the GridLayout settings
+	GridConfig struct {
+		columns  int
+		rows     int
+		hSpacing int32
+		vSpacing int32
+		padding  Insets
+		rect     rl.RectangleInt32
+	}
+
+	// StackLayout is the container, which places all its visible children one over another
+	// within its bounds reduced by the padding. The last child is on top.
+	StackLayout struct {
+		BaseContainer
+
+		cfg   StackConfig
+		sizes layoutSizes
+	}
+
+	// StackConfig allows to specify the StackLayout settings
+	StackConfig struct {
+		hAlign  int
+		vAlign  int
+		padding Insets
+		rect    rl.RectangleInt32
+	}
+
+	// layoutSizes remembers the preferred sizes of the children, so the children, which are
+	// shrunk by the layout to the available space, get their sizes back when the space grows.
+	// The child size, which is changed not by the layout, becomes its preferred size.
+	layoutSizes struct {
+		lock  sync.Mutex
+		sizes map[Component]layoutSize
+	}
+
+	layoutSize struct {
+		// pref is the preferred size, and laid is the size the layout set last time
+		pref rl.RectangleInt32
+		laid rl.RectangleInt32
+	}
+
+	// Anchors defines the child bounds in AnchorLayout relative to the layout edges. A nil
+	// value means the edge or the dimension is not specified. For every axis:
+	//   - if both edges are specified, the child is stretched between them;
+	//   - if one edge is specified, the child is attached to it, and the child size is
+	//     the specified dimension (Width or Height) or the child current size;
+	//   - if no edges are specified, the child is centered.
+	Anchors struct {
+		Left, Top, Right, Bottom Length
+		Width, Height            Length
+	}
+
+	// AnchorLayout is the container, which arranges its children by their Anchors declared in
+	// the physical units. The children without anchors are not touched by the layout.
+	AnchorLayout struct {
+		BaseContainer
+
+		alock   sync.Mutex
+		anchors map[Component]Anchors
+	}
+)
+
+const (
+	// LayoutAlignDefault means the container alignment is used (see LayoutParams)
+	LayoutAlignDefault = iota
+	// LayoutAlignStart places the child to the left or top of the available space
+	LayoutAlignStart
+	// LayoutAlignCenter places the child to the center of the available space
+	LayoutAlignCenter
+	// LayoutAlignEnd places the child to the right or bottom of the available space
+	LayoutAlignEnd
+	// LayoutAlignStretch makes the child occupy the whole available space
+	LayoutAlignStretch
+)
+
+var _ Layouter = (*BoxLayout)(nil)
+var _ Layouter = (*GridLayout)(nil)
+var _ Layouter = (*StackLayout)(nil)
+var _ Layouter = (*AnchorLayout)(nil)
+
+// DefaultBoxConfig returns the horizontal box config without spacing and padding, where
+// the children are stretched across the box axis and placed from the box start
+func DefaultBoxConfig() BoxConfig {
+	return BoxConfig{align: LayoutAlignStretch, justify: LayoutAlignStart}
+}
+
+// Vertical makes the box arrange its children in a column, instead of a row
+func (bcfg BoxConfig) Vertical(vertical bool) BoxConfig {
+	bcfg.vertical = vertical
+	return bcfg
+}
+
+// Spacing specifies the space between the children
+func (bcfg BoxConfig) Spacing(spacing int32) BoxConfig {
+	bcfg.spacing = spacing
+	return bcfg
+}
+
+// Padding specifies the space between the box edges and the children
+func (bcfg BoxConfig) Padding(padding Insets) BoxConfig {
+	bcfg.padding = padding
+	return bcfg
+}
+
+// Align specifies the children alignment across the box axis (LayoutAlignStart etc.)
+func (bcfg BoxConfig) Align(align int) BoxConfig {
+	bcfg.align = align
+	return bcfg
+}
+
+// Justify specifies how the children are placed along the box axis, if there are no
+// children with the flex weights, and the box has some free space (LayoutAlignStart,
+// LayoutAlignCenter or LayoutAlignEnd)
+func (bcfg BoxConfig) Justify(justify int) BoxConfig {
+	bcfg.justify = justify
+	return bcfg
+}
+
+// Rectangle specifies the box bounds
+func (bcfg BoxConfig) Rectangle(r rl.RectangleInt32) BoxConfig {
+	bcfg.rect = r
+	return bcfg
+}
+
+// NewBoxLayout creates the new BoxLayout owned by `owner` with the `cfg` settings
+func NewBoxLayout(owner Container, cfg BoxConfig) (*BoxLayout, error) {
+	bl := &BoxLayout{cfg: cfg}
+	if err := bl.Init(owner, bl); err != nil {
+		return nil, err
+	}
+	bl.SetBounds(cfg.rect)
+	return bl, nil
+}
+
+// SetLayoutParams specifies how the child c is arranged by the box
+func (bl *BoxLayout) SetLayoutParams(c Component, lp LayoutParams) error {
+	if lp.Weight < 0 {
+		return fmt.Errorf("the weight must not be negative, but it is %f: %w", lp.Weight, errors.ErrInvalid)
+	}
+	bl.plock.Lock()
+	defer bl.plock.Unlock()
+	if bl.params == nil {
+		bl.params = make(map[Component]LayoutParams)
+	}
+	bl.params[c] = lp
+	bl.RequestLayout()
+	return nil
+}
+
+// Layout implements Layouter
+func (bl *BoxLayout) Layout() {
+	children := visibleChildren(bl.Children())
+	bl.plock.Lock()
+	params := make([]LayoutParams, len(children))
+	for i, c := range children {
+		params[i] = bl.params[c]
+	}
+	if len(bl.params) > len(children) {
+		bl.pruneParams()
+	}
+	bl.plock.Unlock()
+	prefs := bl.sizes.preferred(children)
+
+	inner := innerRect(bl.Bounds(), bl.cfg.padding)
+	main, cross := inner.Width, inner.Height
+	if bl.cfg.vertical {
+		main, cross = cross, main
+	}
+	sizes := make([]int32, len(children))
+	weights := float32(0)
+	free := main - bl.cfg.spacing*int32(max(len(children)-1, 0))
+	for i := range children {
+		if params[i].Weight > 0 {
+			weights += params[i].Weight
+			continue
+		}
+		sizes[i] = mainSize(prefs[i], bl.cfg.vertical)
+		free -= sizes[i]
+	}
+	free = max(free, 0)
+
+	pos := int32(0)
+	if weights > 0 {
+		// share the free space, the last weighted child takes the rounding remainder
+		rest, restW := free, weights
+		for i := range children {
+			if params[i].Weight > 0 {
+				sizes[i] = int32(float32(rest) * params[i].Weight / restW)
+				rest -= sizes[i]
+				restW -= params[i].Weight
+			}
+		}
+	} else {
+		pos = alignOffset(free, bl.cfg.justify)
+	}
+
+	for i, c := range children {
+		align := params[i].Align
+		if align == LayoutAlignDefault {
+			align = bl.cfg.align
+		}
+		cs := cross
+		if align != LayoutAlignStretch {
+			cs = min(mainSize(prefs[i], !bl.cfg.vertical), cross)
+		}
+		co := alignOffset(cross-cs, align)
+		if bl.cfg.vertical {
+			bl.sizes.setBounds(c, rl.RectangleInt32{X: inner.X + co, Y: inner.Y + pos, Width: cs, Height: sizes[i]})
+		} else {
+			bl.sizes.setBounds(c, rl.RectangleInt32{X: inner.X + pos, Y: inner.Y + co, Width: sizes[i], Height: cs})
+		}
+		pos += sizes[i] + bl.cfg.spacing
+	}
+}
+
+// pruneParams removes the params for the children, which are not in the box anymore
+func (bl *BoxLayout) pruneParams() {
+	all := bl.Children()
+	for c := range bl.params {
+		if childIndex(all, c) == len(all) {
+			delete(bl.params, c)
+		}
+	}
+}
+
+// DefaultGridConfig returns the config for the grid with one column, the number of rows
+// is defined by the number of children
+func DefaultGridConfig() GridConfig {
+	return GridConfig{columns: 1}
+}
+
+// Columns specifies the number of the grid columns
+func (gcfg GridConfig) Columns(columns int) GridConfig {
+	gcfg.columns = columns
+	return gcfg
+}
+
+// Rows specifies the number of the grid rows. If the value is 0, the number of rows is
+// defined by the number of children
+func (gcfg GridConfig) Rows(rows int) GridConfig {
+	gcfg.rows = rows
+	return gcfg
+}
+
+// Spacing specifies the horizontal space between the columns and the vertical space
+// between the rows
+func (gcfg GridConfig) Spacing(hSpacing, vSpacing int32) GridConfig {
+	gcfg.hSpacing = hSpacing
+	gcfg.vSpacing = vSpacing
+	return gcfg
+}
+
+// Padding specifies the space between the grid edges and the cells
+func (gcfg GridConfig) Padding(padding Insets) GridConfig {
+	gcfg.padding = padding
+	return gcfg
+}
+
+// Rectangle specifies the grid bounds
+func (gcfg GridConfig) Rectangle(r rl.RectangleInt32) GridConfig {
+	gcfg.rect = r
+	return gcfg
+}
+
+// NewGridLayout creates the new GridLayout owned by `owner` with the `cfg` settings
+func NewGridLayout(owner Container, cfg GridConfig) (*GridLayout, error) {
+	if cfg.columns <= 0 || cfg.rows < 0 {
+		return nil, fmt.Errorf("the grid must have positive number of columns and not negative rows, but columns=%d, rows=%d: %w",
+			cfg.columns, cfg.rows, errors.ErrInvalid)
+	}
+	gl := &GridLayout{cfg: cfg}
+	if err := gl.Init(owner, gl); err != nil {
+		return nil, err
+	}
+	gl.SetBounds(cfg.rect)
+	return gl, nil
+}
+
+// Layout implements Layouter
+func (gl *GridLayout) Layout() {
+	children := visibleChildren(gl.Children())
+	cols := gl.cfg.columns
+	rows := gl.cfg.rows
+	if rows == 0 {
+		rows = (len(children) + cols - 1) / cols
+	}
+	inner := innerRect(gl.Bounds(), gl.cfg.padding)
+	xs := splitSpan(inner.X, inner.Width, cols, gl.cfg.hSpacing)
+	ys := splitSpan(inner.Y, inner.Height, rows, gl.cfg.vSpacing)
+	for i, c := range children {
+		if i >= cols*rows {
+			c.SetBounds(rl.RectangleInt32{})
+			continue
+		}
+		col, row := i%cols, i/cols
+		c.SetBounds(rl.RectangleInt32{X: xs[col][0], Y: ys[row][0], Width: xs[col][1], Height: ys[row][1]})
+	}
+}
+
+// DefaultStackConfig returns the config for the stack, where the children are stretched
+// over the whole stack
+func DefaultStackConfig() StackConfig {
+	return StackConfig{hAlign: LayoutAlignStretch, vAlign: LayoutAlignStretch}
+}
+
+// Align specifies the horizontal and vertical alignment of the children
+func (scfg StackConfig) Align(hAlign, vAlign int) StackConfig {
+	scfg.hAlign = hAlign
+	scfg.vAlign = vAlign
+	return scfg
+}
+
+// Padding specifies the space between the stack edges and the children
+func (scfg StackConfig) Padding(padding Insets) StackConfig {
+	scfg.padding = padding
+	return scfg
+}
+
+// Rectangle specifies the stack bounds
+func (scfg StackConfig) Rectangle(r rl.RectangleInt32) StackConfig {
+	scfg.rect = r
+	return scfg
+}
+
+// NewStackLayout creates the new StackLayout owned by `owner` with the `cfg` settings
+func NewStackLayout(owner Container, cfg StackConfig) (*StackLayout, error) {
+	sl := &StackLayout{cfg: cfg}
+	if err := sl.Init(owner, sl); err != nil {
+		return nil, err
+	}
+	sl.SetBounds(cfg.rect)
+	return sl, nil
+}
+
+// Layout implements Layouter
+func (sl *StackLayout) Layout() {
+	inner := innerRect(sl.Bounds(), sl.cfg.padding)
+	children := visibleChildren(sl.Children())
+	prefs := sl.sizes.preferred(children)
+	for i, c := range children {
+		w, h := inner.Width, inner.Height
+		if sl.cfg.hAlign != LayoutAlignStretch {
+			w = min(prefs[i].Width, w)
+		}
+		if sl.cfg.vAlign != LayoutAlignStretch {
+			h = min(prefs[i].Height, h)
+		}
+		sl.sizes.setBounds(c, rl.RectangleInt32{X: inner.X + alignOffset(inner.Width-w, sl.cfg.hAlign),
+			Y: inner.Y + alignOffset(inner.Height-h, sl.cfg.vAlign), Width: w, Height: h})
+	}
+}
+
+// NewAnchorLayout creates the new AnchorLayout owned by `owner` with the bounds r
+func NewAnchorLayout(owner Container, r rl.RectangleInt32) (*AnchorLayout, error) {
+	al := &AnchorLayout{}
+	if err := al.Init(owner, al); err != nil {
+		return nil, err
+	}
+	al.SetBounds(r)
+	return al, nil
+}
+
+// SetAnchors specifies the anchors for the child c
+func (al *AnchorLayout) SetAnchors(c Component, a Anchors) {
+	al.alock.Lock()
+	defer al.alock.Unlock()
+	if al.anchors == nil {
+		al.anchors = make(map[Component]Anchors)
+	}
+	al.anchors[c] = a
+	al.RequestLayout()
+}
+
+// Layout implements Layouter
+func (al *AnchorLayout) Layout() {
+	al.layout(AppOf(al).displayConfig())
+}
+
+func (al *AnchorLayout) layout(dc DisplayConfig) {
+	children := al.Children()
+	al.alock.Lock()
+	defer al.alock.Unlock()
+	for c := range al.anchors {
+		if childIndex(children, c) == len(children) {
+			delete(al.anchors, c)
+		}
+	}
+	b := al.Bounds()
+	for _, c := range children {
+		a, ok := al.anchors[c]
+		if !ok {
+			continue
+		}
+		r := c.Bounds()
+		r.X, r.Width = anchorSpan(dc, b.Width, r.Width, a.Left, a.Right, a.Width)
+		r.Y, r.Height = anchorSpan(dc, b.Height, r.Height, a.Top, a.Bottom, a.Height)
+		c.SetBounds(r)
+	}
+}
+
+// anchorSpan calculates the child position and size on one axis, where the container size
+// is size, and the child current size is cur
+func anchorSpan(dc DisplayConfig, size, cur int32, start, end, dim Length) (int32, int32) {
+	if dim != nil {
+		cur = dc.Pixels(dim)
+	}
+	switch {
+	case start != nil && end != nil:
+		pos := dc.Pixels(start)
+		return pos, max(size-pos-dc.Pixels(end), 0)
+	case start != nil:
+		return dc.Pixels(start), cur
+	case end != nil:
+		return size - dc.Pixels(end) - cur, cur
+	}
+	return (size - cur) / 2, cur
+}
+
+// preferred returns the preferred sizes of the children. The sizes of the children, which
+// are not arranged by the layout yet, or which sizes are changed since the last layout, are
+// their current sizes. The sizes of the children, which are not in the list, are forgotten.
+func (ls *layoutSizes) preferred(children []Component) []rl.RectangleInt32 {
+	ls.lock.Lock()
+	defer ls.lock.Unlock()
+	sizes := make(map[Component]layoutSize, len(children))
+	res := make([]rl.RectangleInt32, len(children))
+	for i, c := range children {
+		b := c.Bounds()
+		cur := rl.RectangleInt32{Width: b.Width, Height: b.Height}
+		s, ok := ls.sizes[c]
+		if !ok || s.laid != cur {
+			s = layoutSize{pref: cur, laid: cur}
+		}
+		sizes[c] = s
+		res[i] = s.pref
+	}
+	ls.sizes = sizes
+	return res
+}
+
+// setBounds sets the child c bounds and remembers its size set by the layout
+func (ls *layoutSizes) setBounds(c Component, r rl.RectangleInt32) {
+	c.SetBounds(r)
+	b := c.Bounds()
+	ls.lock.Lock()
+	defer ls.lock.Unlock()
+	if s, ok := ls.sizes[c]; ok {
+		s.laid = rl.RectangleInt32{Width: b.Width, Height: b.Height}
+		ls.sizes[c] = s
+	}
+}
+
+func visibleChildren(children []Component) []Component {
+	res := make([]Component, 0, len(children))
+	for _, c := range children {
+		if c.IsVisible() {
+			res = append(res, c)
+		}
+	}
+	return res
+}
+
+// innerRect returns the container area (in the container coordinates) reduced by the padding
+func innerRect(bounds rl.RectangleInt32, padding Insets) rl.RectangleInt32 {
+	return rl.RectangleInt32{X: padding.Left, Y: padding.Top,
+		Width:  max(bounds.Width-padding.Left-padding.Right, 0),
+		Height: max(bounds.Height-padding.Top-padding.Bottom, 0)}
+}
+
+func mainSize(r rl.RectangleInt32, vertical bool) int32 {
+	if vertical {
+		return r.Height
+	}
+	return r.Width
+}
+
+func alignOffset(free int32, align int) int32 {
+	switch align {
+	case LayoutAlignCenter:
+		return free / 2
+	case LayoutAlignEnd:
+		return free
+	}
+	return 0
+}
+
+// splitSpan splits the span of the size length started from the start position to n
+// parts separated by spacing. It returns the list of the parts start positions and sizes.
+// The rounding remainder is distributed between the parts, so they cover the whole span.
+func splitSpan(start, size int32, n int, spacing int32) [][2]int32 {
+	res := make([][2]int32, n)
+	avail := max(size-spacing*int32(n-1), 0)
+	prev := int32(0)
+	for i := 0; i < n; i++ {
+		end := avail * int32(i+1) / int32(n)
+		res[i] = [2]int32{start + prev + spacing*int32(i), end - prev}
+		prev = end
+	}
+	return res
+}
@@ -0,0 +1,144 @@
+package raywin
+
+// Copyright 2025 Dmitry Spasibenko
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+import (
+	"github.com/dspasibenko/raywin-go/pkg/golibs/errors"
+	rl "github.com/gen2brain/raylib-go/raylib"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func newTestChild(t *testing.T, owner Container, w, h int32) *BaseComponent {
+	c := &BaseComponent{}
+	assert.Nil(t, c.Init(owner, c))
+	c.SetBounds(rl.RectangleInt32{Width: w, Height: h})
+	return c
+}
+
+func TestBoxLayout(t *testing.T) {
+	d := newDisplay(DefaultDisplayConfig(), &testProxy{})
+	bl, err := NewBoxLayout(&d.root, DefaultBoxConfig().Spacing(10).Padding(Insets{Left: 5, Top: 5, Right: 5, Bottom: 5}).
+		Rectangle(rl.RectangleInt32{Width: 200, Height: 50}))
+	assert.Nil(t, err)
+	c1 := newTestChild(t, bl, 30, 10)
+	c2 := newTestChild(t, bl, 40, 10)
+	d.walkForFC(&d.root, 1)
+	assert.Equal(t, rl.RectangleInt32{X: 5, Y: 5, Width: 30, Height: 40}, c1.Bounds())
+	assert.Equal(t, rl.RectangleInt32{X: 45, Y: 5, Width: 40, Height: 40}, c2.Bounds())
+
+	// flex
+	c3 := newTestChild(t, bl, 0, 10)
+	assert.Nil(t, bl.SetLayoutParams(c3, LayoutParams{Weight: 1, Align: LayoutAlignCenter}))
+	assert.ErrorIs(t, bl.SetLayoutParams(c3, LayoutParams{Weight: -1}), errors.ErrInvalid)
+	d.walkForFC(&d.root, 2)
+	assert.Equal(t, rl.RectangleInt32{X: 95, Y: 20, Width: 100, Height: 10}, c3.Bounds())
+
+	// the bounds change
+	bl.SetBounds(rl.RectangleInt32{Width: 300, Height: 50})
+	d.walkForFC(&d.root, 3)
+	assert.Equal(t, rl.RectangleInt32{X: 95, Y: 20, Width: 200, Height: 10}, c3.Bounds())
+
+	// remove and hide
+	c1.Close()
+	c2.SetVisible(false)
+	d.walkForFC(&d.root, 4)
+	assert.Equal(t, rl.RectangleInt32{X: 5, Y: 20, Width: 290, Height: 10}, c3.Bounds())
+	assert.Equal(t, 1, len(bl.params))
+
+	// nothing changed, so the bounds are kept
+	c3.SetBounds(rl.RectangleInt32{})
+	d.walkForFC(&d.root, 5)
+	assert.Equal(t, rl.RectangleInt32{}, c3.Bounds())
+}
+
+func TestBoxLayout_VerticalJustify(t *testing.T) {
+	d := newDisplay(DefaultDisplayConfig(), &testProxy{})
+	bl, err := NewBoxLayout(&d.root, DefaultBoxConfig().Vertical(true).Justify(LayoutAlignEnd).Align(LayoutAlignEnd).
+		Rectangle(rl.RectangleInt32{Width: 100, Height: 100}))
+	assert.Nil(t, err)
+	c1 := newTestChild(t, bl, 30, 10)
+	c2 := newTestChild(t, bl, 40, 20)
+	bl.Layout()
+	assert.Equal(t, rl.RectangleInt32{X: 70, Y: 70, Width: 30, Height: 10}, c1.Bounds())
+	assert.Equal(t, rl.RectangleInt32{X: 60, Y: 80, Width: 40, Height: 20}, c2.Bounds())
+}
+
+func TestGridLayout(t *testing.T) {
+	d := newDisplay(DefaultDisplayConfig(), &testProxy{})
+	_, err := NewGridLayout(&d.root, DefaultGridConfig().Columns(0))
+	assert.ErrorIs(t, err, errors.ErrInvalid)
+
+	gl, err := NewGridLayout(&d.root, DefaultGridConfig().Columns(2).Rows(2).Spacing(10, 5).
+		Rectangle(rl.RectangleInt32{Width: 111, Height: 105}))
+	assert.Nil(t, err)
+	var cs []*BaseComponent
+	for i := 0; i < 5; i++ {
+		cs = append(cs, newTestChild(t, gl, 1, 1))
+	}
+	gl.Layout()
+	assert.Equal(t, rl.RectangleInt32{X: 0, Y: 0, Width: 50, Height: 50}, cs[0].Bounds())
+	assert.Equal(t, rl.RectangleInt32{X: 60, Y: 0, Width: 51, Height: 50}, cs[1].Bounds())
+	assert.Equal(t, rl.RectangleInt32{X: 0, Y: 55, Width: 50, Height: 50}, cs[2].Bounds())
+	assert.Equal(t, rl.RectangleInt32{X: 60, Y: 55, Width: 51, Height: 50}, cs[3].Bounds())
+	assert.Equal(t, rl.RectangleInt32{}, cs[4].Bounds())
+}
+
+func TestStackLayout(t *testing.T) {
+	d := newDisplay(DefaultDisplayConfig(), &testProxy{})
+	sl, err := NewStackLayout(&d.root, DefaultStackConfig().Rectangle(rl.RectangleInt32{Width: 100, Height: 80}).
+		Padding(Insets{Left: 10, Top: 10, Right: 10, Bottom: 10}))
+	assert.Nil(t, err)
+	c1 := newTestChild(t, sl, 30, 10)
+	sl.Layout()
+	assert.Equal(t, rl.RectangleInt32{X: 10, Y: 10, Width: 80, Height: 60}, c1.Bounds())
+
+	sl.cfg = sl.cfg.Align(LayoutAlignCenter, LayoutAlignEnd)
+	c1.SetBounds(rl.RectangleInt32{Width: 30, Height: 10})
+	sl.Layout()
+	assert.Equal(t, rl.RectangleInt32{X: 35, Y: 60, Width: 30, Height: 10}, c1.Bounds())
+}
+
+func TestLayout_shrinkAndGrow(t *testing.T) {
+	d := newDisplay(DefaultDisplayConfig(), &testProxy{})
+	bl, err := NewBoxLayout(&d.root, DefaultBoxConfig().Align(LayoutAlignCenter).Rectangle(rl.RectangleInt32{Width: 100, Height: 50}))
+	assert.Nil(t, err)
+	bc := newTestChild(t, bl, 30, 40)
+	sl, err := NewStackLayout(&d.root, DefaultStackConfig().Align(LayoutAlignStart, LayoutAlignStart).Rectangle(rl.RectangleInt32{Width: 100, Height: 50}))
+	assert.Nil(t, err)
+	sc := newTestChild(t, sl, 60, 40)
+	d.walkForFC(&d.root, 1)
+	assert.Equal(t, rl.RectangleInt32{Y: 5, Width: 30, Height: 40}, bc.Bounds())
+	assert.Equal(t, rl.RectangleInt32{Width: 60, Height: 40}, sc.Bounds())
+
+	bl.SetBounds(rl.RectangleInt32{Width: 100, Height: 20})
+	sl.SetBounds(rl.RectangleInt32{Width: 50, Height: 20})
+	d.walkForFC(&d.root, 2)
+	assert.Equal(t, rl.RectangleInt32{Width: 30, Height: 20}, bc.Bounds())
+	assert.Equal(t, rl.RectangleInt32{Width: 50, Height: 20}, sc.Bounds())
+
+	// the children get their preferred sizes back
+	bl.SetBounds(rl.RectangleInt32{Width: 100, Height: 50})
+	sl.SetBounds(rl.RectangleInt32{Width: 100, Height: 50})
+	d.walkForFC(&d.root, 3)
+	assert.Equal(t, rl.RectangleInt32{Y: 5, Width: 30, Height: 40}, bc.Bounds())
+	assert.Equal(t, rl.RectangleInt32{Width: 60, Height: 40}, sc.Bounds())
+
+	// the size set not by the layout becomes the preferred one
+	sc.SetBounds(rl.RectangleInt32{Width: 10, Height: 10})
+	sl.SetBounds(rl.RectangleInt32{Width: 100, Height: 60})
+	d.walkForFC(&d.root, 4)
+	assert.Equal(t, rl.RectangleInt32{Width: 10, Height: 10}, sc.Bounds())
+}
@@ -0,0 +1,402 @@
+package raywin
+
+// Copyright 2025 Dmitry Spasibenko
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+import (
+	"fmt"
+	"github.com/dspasibenko/raywin-go/pkg/golibs/errors"
+	rl "github.com/gen2brain/raylib-go/raylib"
+)
+
+type (
+	// Transition defines how the screens are changed by the Navigator
+	Transition int
+
+	// ScreenLifecycle interface maybe implemented by a screen managed by Navigator to be
+	// notified about the screen appearance changes. The "will" functions are called before
+	// the transition is started, and the "did" ones are called when it is over.
+	ScreenLifecycle interface {
+		OnWillAppear()
+		OnDidAppear()
+		OnWillDisappear()
+		OnDidDisappear()
+	}
+
+	// Navigator is the container, which shows one of its children (screens) at a time
+	// and keeps the back stack of the screens. A screen is a component owned by the
+	// Navigator, it is hidden when it is added to the Navigator, and it becomes visible
+	// when it is pushed to the stack (see Push). The screens are stretched over the whole
+	// Navigator area.
+	//
+	// The top screen is popped by the back key (Escape or the Android back button), if it is
+	// not consumed by the focused component, or by the swipe to the right started from the
+	// Navigator left edge. The touches started at the edge are not received by the screens.
+	Navigator struct {
+		BaseContainer
+
+		cfg   NavigatorConfig
+		stack []Component
+		trans *navTransition
+		swipe *SwipeRecognizer
+		edge  bool
+	}
+
+	// NavigatorConfig allows to specify the Navigator settings
+	NavigatorConfig struct {
+		rect           rl.RectangleInt32
+		animation      AnimationConfig
+		backTransition Transition
+		edgeSwipe      Length
+		overlayColor   rl.Color
+	}
+
+	navTransition struct {
+		from, to Component
+		kind     Transition
+		back     bool
+		// closeFrom indicates that the from screen is closed when the transition is over
+		closeFrom bool
+		k         float32
+		anim      *Animation
+	}
+)
+
+const (
+	// TransitionNone changes the screens immediately
+	TransitionNone = Transition(iota)
+	// TransitionSlide slides the new screen in from the right, and the old one out to the
+	// left. The back transition slides the screens in the opposite direction.
+	TransitionSlide
+	// TransitionFade cross-fades the screens, both screens are visible during the transition.
+	// The overlay color is seen through the transparent parts of the screens.
+	TransitionFade
+)
+
+var _ Layouter = (*Navigator)(nil)
+var _ KeyListener = (*Navigator)(nil)
+var _ TouchInterceptor = (*Navigator)(nil)
+var _ ChildrenFader = (*Navigator)(nil)
+
+// DefaultNavigatorConfig returns the config with the 300ms slide transitions and
+// the 5mm edge for the back swipe
+func DefaultNavigatorConfig() NavigatorConfig {
+	return NavigatorConfig{
+		animation:      DefaultAnimationConfig().Duration(300).Easing(EaseOutCubic),
+		backTransition: TransitionSlide,
+		edgeSwipe:      Mm(5),
+		overlayColor:   rl.Black,
+	}
+}
+
+// Rectangle specifies the Navigator bounds
+func (ncfg NavigatorConfig) Rectangle(r rl.RectangleInt32) NavigatorConfig {
+	ncfg.rect = r
+	return ncfg
+}
+
+// Animation specifies the transitions timing. The loops settings are ignored.
+func (ncfg NavigatorConfig) Animation(acfg AnimationConfig) NavigatorConfig {
+	ncfg.animation = acfg
+	return ncfg
+}
+
+// BackTransition specifies the transition used when the screen is popped by the back
+// key or the edge swipe
+func (ncfg NavigatorConfig) BackTransition(tr Transition) NavigatorConfig {
+	ncfg.backTransition = tr
+	return ncfg
+}
+
+// EdgeSwipe specifies the width of the left edge area, where the back swipe may be started.
+// nil disables the back swipe.
+func (ncfg NavigatorConfig) EdgeSwipe(width Length) NavigatorConfig {
+	ncfg.edgeSwipe = width
+	return ncfg
+}
+
+// OverlayColor specifies the color the screens are cross-faded over by TransitionFade
+func (ncfg NavigatorConfig) OverlayColor(col rl.Color) NavigatorConfig {
+	ncfg.overlayColor = col
+	return ncfg
+}
+
+// NewNavigator creates the new Navigator owned by `owner` with the `cfg` settings
+func NewNavigator(owner Container, cfg NavigatorConfig) (*Navigator, error) {
+	n := &Navigator{cfg: cfg}
+	n.cfg.animation = n.cfg.animation.Loops(1).AutoReverse(false).OnDone(nil)
+	if err := n.Init(owner, n); err != nil {
+		return nil, err
+	}
+	n.swipe = NewSwipeRecognizer(AppOf(n).PxF(Mm(10)), 300, func(dir int, _ rl.Vector2) {
+		if dir == SwipeRight && n.edge {
+			n.Pop(n.cfg.backTransition)
+		}
+	})
+	n.SetBounds(cfg.rect)
+	return n, nil
+}
+
+// OnAddChild hides the added screen, it becomes visible when it is pushed
+func (n *Navigator) OnAddChild(c Component, children []Component) ([]Component, error) {
+	c.SetVisible(false)
+	return n.BaseContainer.OnAddChild(c, children)
+}
+
+// Push makes the screen be the top one. The screen must be owned by the Navigator, and
+// it must not be in the stack already. The previous top screen is kept in the stack.
+func (n *Navigator) Push(screen Component, tr Transition) error {
+	if err := n.checkScreen(screen); err != nil {
+		return err
+	}
+	n.finishTransition()
+	from := n.Top()
+	n.stack = append(n.stack, screen)
+	n.startTransition(&navTransition{from: from, to: screen, kind: tr})
+	return nil
+}
+
+// Replace replaces the top screen by the screen. The replaced screen is closed when the
+// transition is over.
+func (n *Navigator) Replace(screen Component, tr Transition) error {
+	if err := n.checkScreen(screen); err != nil {
+		return err
+	}
+	n.finishTransition()
+	from := n.Top()
+	if from == nil {
+		n.stack = append(n.stack, screen)
+	} else {
+		n.stack[len(n.stack)-1] = screen
+	}
+	n.startTransition(&navTransition{from: from, to: screen, kind: tr, closeFrom: true})
+	return nil
+}
+
+// Pop removes the top screen from the stack and shows the previous one. The removed screen
+// is closed when the transition is over. Pop returns an error if there is no previous screen.
+func (n *Navigator) Pop(tr Transition) error {
+	n.finishTransition()
+	if len(n.stack) < 2 {
+		return fmt.Errorf("no screen to go back to: %w", errors.ErrNotExist)
+	}
+	from := n.stack[len(n.stack)-1]
+	n.stack = n.stack[:len(n.stack)-1]
+	n.startTransition(&navTransition{from: from, to: n.Top(), kind: tr, back: true, closeFrom: true})
+	return nil
+}
+
+// Top returns the top screen or nil, if the stack is empty
+func (n *Navigator) Top() Component {
+	if len(n.stack) == 0 {
+		return nil
+	}
+	return n.stack[len(n.stack)-1]
+}
+
+// Depth returns the number of screens in the stack
+func (n *Navigator) Depth() int {
+	return len(n.stack)
+}
+
+// Layout implements Layouter
+func (n *Navigator) Layout() {
+	b := n.Bounds()
+	full := rl.RectangleInt32{Width: b.Width, Height: b.Height}
+	for _, c := range n.Children() {
+		c.SetBounds(full)
+	}
+	if n.trans != nil {
+		n.applyTransition(n.trans.k)
+		return
+	}
+	// drop the screens closed outside the navigator
+	stack := n.stack[:0]
+	for _, s := range n.stack {
+		if !s.baseComponent().isClosed() {
+			stack = append(stack, s)
+		}
+	}
+	n.stack = stack
+	if top := n.Top(); top != nil {
+		top.SetVisible(true)
+	}
+}
+
+// OnUnhandledKey implements KeyListener
+func (n *Navigator) OnUnhandledKey(ke KeyEvent) bool {
+	if (ke.Key == rl.KeyEscape || ke.Key == rl.KeyBack) && len(n.stack) > 1 {
+		return n.Pop(n.cfg.backTransition) == nil
+	}
+	return false
+}
+
+// InterceptTPState implements TouchInterceptor. The touches started at the left edge are
+// claimed for the back swipe before the screens receive them, so the swipe is recognized
+// over the touchable screen components too.
+func (n *Navigator) InterceptTPState(tps TPState) OnTPSResult {
+	return n.OnTPState(tps)
+}
+
+// OnTPState implements Touchpadable to recognize the back swipe
+func (n *Navigator) OnTPState(tps TPState) OnTPSResult {
+	if n.cfg.edgeSwipe == nil || len(n.stack) < 2 {
+		return OnTPSResultNA
+	}
+	if tps.State == TPStatePressed && tps.Sequence != n.swipe.pressSeq {
+		x, _ := absolutePos(n)
+		n.edge = tps.Pos.X-float32(x) < float32(AppOf(n).Px(n.cfg.edgeSwipe))
+	}
+	if !n.edge {
+		return OnTPSResultNA
+	}
+	switch n.swipe.OnGesture(tps) {
+	case GestureStatePossible, GestureStateActive:
+		if tps.State != TPStateReleased {
+			return OnTPSResultLocked
+		}
+	case GestureStateRecognized:
+		return OnTPSResultStop
+	}
+	return OnTPSResultNA
+}
+
+// Draw implements Component to draw the overlay color below the screens, which are
+// cross-faded by the fade transition (see ChildAlpha)
+func (n *Navigator) Draw(cc *CanvasContext) {
+	t := n.trans
+	if t == nil || t.kind != TransitionFade {
+		return
+	}
+	b := n.Bounds()
+	x, y := cc.PhysicalPointXY(0, 0)
+	cc.DrawRectangle(rl.RectangleInt32{X: x, Y: y, Width: b.Width, Height: b.Height}, n.cfg.overlayColor)
+}
+
+// ChildAlpha implements ChildrenFader for the fade transition. The screen, which is drawn
+// first, stays opaque, and the other one is blended over it: the new screen fades in, or
+// the old one fades out, so the opaque screens are mixed in the transition progress ratio
+func (n *Navigator) ChildAlpha(c Component) float32 {
+	t := n.trans
+	if t == nil || t.kind != TransitionFade {
+		return 1.0
+	}
+	children := n.Children()
+	fromAbove := childIndex(children, t.from) > childIndex(children, t.to)
+	switch {
+	case c == t.from && fromAbove:
+		return 1.0 - t.k
+	case c == t.to && !fromAbove:
+		return t.k
+	}
+	return 1.0
+}
+
+func (n *Navigator) checkScreen(screen Component) error {
+	if screen == nil || screen.baseComponent().owner != &n.BaseContainer {
+		return fmt.Errorf("the screen %s must be owned by the navigator: %w", screen, errors.ErrInvalid)
+	}
+	if childIndex(n.stack, screen) < len(n.stack) {
+		return fmt.Errorf("the screen %s is already in the navigator stack: %w", screen, errors.ErrInvalid)
+	}
+	return nil
+}
+
+func (n *Navigator) startTransition(t *navTransition) {
+	if ls, ok := t.to.(ScreenLifecycle); ok {
+		ls.OnWillAppear()
+	}
+	if ls, ok := t.from.(ScreenLifecycle); ok {
+		ls.OnWillDisappear()
+	}
+	t.to.SetVisible(true)
+	n.trans = t
+	n.RequestLayout()
+	if t.kind == TransitionNone || t.from == nil {
+		n.finishTransition()
+		return
+	}
+	n.applyTransition(0)
+	t.anim = NewAnimation(n, n.cfg.animation.OnDone(n.finishTransition), n.applyTransition)
+	if t.anim.Start() != nil {
+		// raywin is not running, nothing to animate
+		n.finishTransition()
+	}
+}
+
+// applyTransition sets the screens positions for the transition progress k. The fade
+// transition is drawn with the progress (see ChildAlpha)
+func (n *Navigator) applyTransition(k float32) {
+	t := n.trans
+	if t == nil {
+		return
+	}
+	t.k = k
+	b := n.Bounds()
+	switch t.kind {
+	case TransitionSlide:
+		dir := int32(1)
+		if t.back {
+			dir = -1
+		}
+		shift := int32(float32(b.Width) * k)
+		t.from.SetBounds(rl.RectangleInt32{X: -dir * shift, Width: b.Width, Height: b.Height})
+		t.to.SetBounds(rl.RectangleInt32{X: dir * (b.Width - shift), Width: b.Width, Height: b.Height})
+	}
+}
+
+// finishTransition completes the current transition, if any
+func (n *Navigator) finishTransition() {
+	t := n.trans
+	if t == nil {
+		return
+	}
+	n.trans = nil
+	if t.anim != nil {
+		t.anim.Cancel()
+	}
+	b := n.Bounds()
+	full := rl.RectangleInt32{Width: b.Width, Height: b.Height}
+	t.to.SetVisible(true)
+	t.to.SetBounds(full)
+	if t.from != nil {
+		t.from.SetVisible(false)
+		t.from.SetBounds(full)
+		if ls, ok := t.from.(ScreenLifecycle); ok {
+			ls.OnDidDisappear()
+		}
+		if t.closeFrom {
+			t.from.Close()
+		}
+	}
+	if ls, ok := t.to.(ScreenLifecycle); ok {
+		ls.OnDidAppear()
+	}
+}
+
+// absolutePos returns the component position in the display coordinates. The scroll
+// offsets of the owners are not taken into account.
+func absolutePos(c Component) (int32, int32) {
+	var x, y int32
+	for bc := c.baseComponent(); bc != nil && bc.this != nil; {
+		b := bc.this.Bounds()
+		x += b.X
+		y += b.Y
+		if bc.owner == nil {
+			break
+		}
+		bc = &bc.owner.BaseComponent
+	}
+	return x, y
+}
@@ -0,0 +1,235 @@
+package raywin
+
+// Copyright 2025 Dmitry Spasibenko
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+import (
+	"github.com/dspasibenko/raywin-go/pkg/golibs/errors"
+	rl "github.com/gen2brain/raylib-go/raylib"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+type _navigator_test_screen struct {
+	BaseContainer
+	name   string
+	events *[]string
+}
+
+func (s *_navigator_test_screen) OnWillAppear() { *s.events = append(*s.events, s.name+":willAppear") }
+func (s *_navigator_test_screen) OnDidAppear()  { *s.events = append(*s.events, s.name+":didAppear") }
+func (s *_navigator_test_screen) OnWillDisappear() {
+	*s.events = append(*s.events, s.name+":willDisappear")
+}
+func (s *_navigator_test_screen) OnDidDisappear() {
+	*s.events = append(*s.events, s.name+":didDisappear")
+}
+
+func newTestScreen(t *testing.T, n *Navigator, name string, events *[]string) *_navigator_test_screen {
+	s := &_navigator_test_screen{name: name, events: events}
+	assert.Nil(t, s.Init(n, s))
+	return s
+}
+
+func newTestNavigator(t *testing.T) (*display, *Navigator) {
+	d := newDisplay(DefaultDisplayConfig(), &testProxy{})
+	c = &App{disp: d}
+	n, err := NewNavigator(&d.root, DefaultNavigatorConfig().Animation(DefaultAnimationConfig().Duration(100).Easing(EaseLinear)).
+		Rectangle(rl.RectangleInt32{Width: 400, Height: 300}))
+	assert.Nil(t, err)
+	return d, n
+}
+
+func TestNavigator_PushPop(t *testing.T) {
+	defer func() { c = &App{} }()
+	d, n := newTestNavigator(t)
+	var events []string
+	s1 := newTestScreen(t, n, "s1", &events)
+	s2 := newTestScreen(t, n, "s2", &events)
+	assert.False(t, s1.IsVisible())
+	assert.False(t, s2.IsVisible())
+
+	assert.Nil(t, n.Push(s1, TransitionSlide))
+	assert.ErrorIs(t, n.Push(s1, TransitionSlide), errors.ErrInvalid)
+	assert.ErrorIs(t, n.Push(&d.root, TransitionSlide), errors.ErrInvalid)
+	assert.True(t, s1.IsVisible())
+	assert.Equal(t, []string{"s1:willAppear", "s1:didAppear"}, events)
+	d.formFrame(0)
+	assert.Equal(t, rl.RectangleInt32{Width: 400, Height: 300}, s1.Bounds())
+
+	events = nil
+	assert.Nil(t, n.Push(s2, TransitionSlide))
+	assert.Equal(t, []string{"s2:willAppear", "s1:willDisappear"}, events)
+	d.formFrame(10)
+	d.formFrame(60)
+	assert.True(t, s1.IsVisible())
+	assert.True(t, s2.IsVisible())
+	assert.Equal(t, rl.RectangleInt32{X: -200, Width: 400, Height: 300}, s1.Bounds())
+	assert.Equal(t, rl.RectangleInt32{X: 200, Width: 400, Height: 300}, s2.Bounds())
+	d.formFrame(110)
+	assert.False(t, s1.IsVisible())
+	assert.Equal(t, rl.RectangleInt32{Width: 400, Height: 300}, s2.Bounds())
+	assert.Equal(t, []string{"s2:willAppear", "s1:willDisappear", "s1:didDisappear", "s2:didAppear"}, events)
+	assert.Equal(t, 2, n.Depth())
+	assert.Same(t, s2, n.Top())
+
+	// back key
+	events = nil
+	assert.True(t, n.OnUnhandledKey(KeyEvent{Key: rl.KeyEscape}))
+	d.formFrame(120)
+	d.formFrame(170)
+	assert.Equal(t, rl.RectangleInt32{X: -200, Width: 400, Height: 300}, s1.Bounds())
+	assert.Equal(t, rl.RectangleInt32{X: 200, Width: 400, Height: 300}, s2.Bounds())
+	d.formFrame(220)
+	assert.True(t, s2.isClosed())
+	assert.Same(t, s1, n.Top())
+	assert.Equal(t, []string{"s1:willAppear", "s2:willDisappear", "s2:didDisappear", "s1:didAppear"}, events)
+	assert.False(t, n.OnUnhandledKey(KeyEvent{Key: rl.KeyEscape}))
+	assert.ErrorIs(t, n.Pop(TransitionNone), errors.ErrNotExist)
+}
+
+func TestNavigator_ReplaceFade(t *testing.T) {
+	defer func() { c = &App{} }()
+	d, n := newTestNavigator(t)
+	var events []string
+	s1 := newTestScreen(t, n, "s1", &events)
+	s2 := newTestScreen(t, n, "s2", &events)
+	assert.Nil(t, n.Replace(s1, TransitionFade))
+	assert.Nil(t, n.Replace(s2, TransitionFade))
+	d.formFrame(0)
+	d.formFrame(40)
+	// the screens are cross-faded
+	assert.True(t, s1.IsVisible())
+	assert.True(t, s2.IsVisible())
+	assert.Equal(t, float32(1.0), n.ChildAlpha(s1))
+	assert.InDelta(t, 0.4, n.ChildAlpha(s2), 0.01)
+	d.formFrame(60)
+	assert.True(t, s1.IsVisible())
+	assert.True(t, s2.IsVisible())
+	assert.InDelta(t, 0.6, n.ChildAlpha(s2), 0.01)
+
+	// the new transition completes the current one
+	s3 := newTestScreen(t, n, "s3", &events)
+	assert.Nil(t, n.Push(s3, TransitionNone))
+	assert.True(t, s1.isClosed())
+	assert.False(t, s2.IsVisible())
+	assert.True(t, s3.IsVisible())
+	assert.Equal(t, 2, n.Depth())
+}
+
+func TestNavigator_FadeDraw(t *testing.T) {
+	defer func() { c = &App{} }()
+	sp := NewSoftProxy()
+	cfg := DefaultDisplayConfig()
+	cfg.Width, cfg.Height = 40, 30
+	d := newDisplay(cfg, sp)
+	c = &App{disp: d}
+	n, err := NewNavigator(&d.root, DefaultNavigatorConfig().Animation(DefaultAnimationConfig().Duration(100).Easing(EaseLinear)).
+		Rectangle(rl.RectangleInt32{Width: 40, Height: 30}))
+	assert.Nil(t, err)
+	s1 := &_softproxy_test_box{col: rl.Red}
+	assert.Nil(t, s1.Init(n, s1))
+	s2 := &_softproxy_test_box{col: rl.Blue}
+	assert.Nil(t, s2.Init(n, s2))
+	assert.Nil(t, n.Push(s1, TransitionNone))
+	assert.Nil(t, n.Push(s2, TransitionFade))
+
+	// both screens are seen in the middle of the transitions
+	d.formFrame(0)
+	d.formFrame(50)
+	col := sp.Image().RGBAAt(38, 28)
+	assert.InDelta(t, (int(rl.Red.R)+int(rl.Blue.R))/2, col.R, 3)
+	assert.InDelta(t, (int(rl.Red.B)+int(rl.Blue.B))/2, col.B, 3)
+	d.formFrame(110)
+	assert.Equal(t, rl.Blue.R, sp.Image().RGBAAt(38, 28).R)
+
+	// the popped screen is above the shown one
+	assert.Nil(t, n.Pop(TransitionFade))
+	d.formFrame(120)
+	d.formFrame(145)
+	col = sp.Image().RGBAAt(38, 28)
+	assert.InDelta(t, (int(rl.Red.R)+3*int(rl.Blue.R))/4, col.R, 3)
+	d.formFrame(230)
+	assert.Equal(t, rl.Red.R, sp.Image().RGBAAt(38, 28).R)
+}
+
+func TestNavigator_EdgeSwipe(t *testing.T) {
+	defer func() { c = &App{} }()
+	_, n := newTestNavigator(t)
+	var events []string
+	s1 := newTestScreen(t, n, "s1", &events)
+	s2 := newTestScreen(t, n, "s2", &events)
+	assert.Nil(t, n.Push(s1, TransitionNone))
+	assert.Nil(t, n.Push(s2, TransitionNone))
+
+	// not from the edge
+	assert.Equal(t, OnTPSResultNA, n.OnTPState(TPState{State: TPStatePressed, Pos: rl.Vector2{X: 100}, Sequence: 1}))
+	assert.Equal(t, OnTPSResultNA, n.OnTPState(TPState{State: TPStateMoving, Pos: rl.Vector2{X: 300}, Millis: 50, Sequence: 2}))
+	assert.Equal(t, OnTPSResultNA, n.OnTPState(TPState{State: TPStateReleased, Pos: rl.Vector2{X: 300}, Millis: 60, Sequence: 3}))
+	assert.Equal(t, 2, n.Depth())
+
+	assert.Equal(t, OnTPSResultLocked, n.OnTPState(TPState{State: TPStatePressed, Pos: rl.Vector2{X: 5}, Millis: 100, Sequence: 4}))
+	assert.Equal(t, OnTPSResultLocked, n.OnTPState(TPState{State: TPStateMoving, Pos: rl.Vector2{X: 200}, Millis: 150, Sequence: 5}))
+	assert.Equal(t, OnTPSResultStop, n.OnTPState(TPState{State: TPStateReleased, Pos: rl.Vector2{X: 250}, Millis: 160, Sequence: 6}))
+	assert.Equal(t, 1, n.Depth())
+}
+
+type _navigator_test_pad struct {
+	BaseComponent
+	states int
+}
+
+func (p *_navigator_test_pad) OnTPState(tps TPState) OnTPSResult {
+	p.states++
+	if tps.State == TPStateReleased {
+		return OnTPSResultStop
+	}
+	return OnTPSResultLocked
+}
+
+func TestNavigator_EdgeSwipeOverScreen(t *testing.T) {
+	defer func() { c = &App{} }()
+	tp := &testProxy{}
+	d := newDisplay(DefaultDisplayConfig(), tp)
+	c = &App{disp: d}
+	n, err := NewNavigator(&d.root, DefaultNavigatorConfig().Rectangle(rl.RectangleInt32{Width: 400, Height: 300}))
+	assert.Nil(t, err)
+	var events []string
+	s1 := newTestScreen(t, n, "s1", &events)
+	s2 := newTestScreen(t, n, "s2", &events)
+	assert.Nil(t, n.Push(s1, TransitionNone))
+	assert.Nil(t, n.Push(s2, TransitionNone))
+	// the screen is covered by the component, which claims all the touches
+	pad := &_navigator_test_pad{}
+	assert.Nil(t, pad.Init(s2, pad))
+	pad.SetBounds(rl.RectangleInt32{Width: 400, Height: 300})
+	d.formFrame(0)
+
+	tp.touches = []testTouch{{id: 1, pos: rl.Vector2{X: 100, Y: 100}}}
+	d.formFrame(10)
+	tp.touches = nil
+	d.formFrame(20)
+	assert.NotZero(t, pad.states)
+
+	pad.states = 0
+	for i, x := range []float32{5, 100, 200, 300} {
+		tp.touches = []testTouch{{id: 2, pos: rl.Vector2{X: x, Y: 100}}}
+		d.formFrame(int64(100 + i*16))
+	}
+	tp.touches = nil
+	d.formFrame(170)
+	assert.Equal(t, 0, pad.states)
+	assert.Equal(t, 1, n.Depth())
+	assert.Same(t, s1, n.Top())
+}
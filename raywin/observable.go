@@ -0,0 +1,196 @@
+package raywin
+
+// Copyright 2025 Dmitry Spasibenko
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+import (
+	"fmt"
+	"github.com/dspasibenko/raywin-go/pkg/golibs/errors"
+	"sync"
+	"sync/atomic"
+)
+
+type (
+	// Observable holds a value, which changes are reported to the subscribers. The value
+	// may be read and changed from any goroutine, but the subscribers are always notified
+	// by the drawing loop goroutine (see Post), so they may update the components directly.
+	//
+	// The notifications are coalesced: if the value is changed several times before the
+	// subscriber is notified, the subscriber is called once with the latest value.
+	Observable[T comparable] struct {
+		lock sync.Mutex
+		v    T
+		subs []*observer[T]
+	}
+
+	// Subscription is the handle of the Observable subscriber. The subscription may have
+	// the owner component, it is released automatically when the owner is closed.
+	Subscription struct {
+		owner     Component
+		cancelled atomic.Bool
+		// release removes the subscriber from the Observable
+		release func()
+	}
+
+	// Enabler is implemented by the components, which may be disabled. A disabled
+	// component doesn't react on the user input.
+	Enabler interface {
+		// SetEnabled enables or disables the component
+		SetEnabled(enabled bool)
+		// IsEnabled returns whether the component is enabled
+		IsEnabled() bool
+	}
+
+	observer[T comparable] struct {
+		*Subscription
+		app     *App
+		f       func(v T)
+		pending atomic.Bool
+	}
+)
+
+// NewObservable returns the new Observable with the initial value v
+func NewObservable[T comparable](v T) *Observable[T] {
+	return &Observable[T]{v: v}
+}
+
+// Get returns the current value
+func (o *Observable[T]) Get() T {
+	o.lock.Lock()
+	defer o.lock.Unlock()
+	return o.v
+}
+
+// Set changes the value and notifies the subscribers, if the new value differs from
+// the current one
+func (o *Observable[T]) Set(v T) {
+	o.lock.Lock()
+	if o.v == v {
+		o.lock.Unlock()
+		return
+	}
+	o.v = v
+	alive := make([]*observer[T], 0, len(o.subs))
+	for _, ob := range o.subs {
+		if !ob.isDead() {
+			alive = append(alive, ob)
+		}
+	}
+	o.subs = alive
+	o.lock.Unlock()
+
+	for _, ob := range alive {
+		o.notify(ob)
+	}
+}
+
+// Subscribe adds the subscriber f, which is called with the current value in the next
+// frame of the App the owner belongs to (see AppOf), and then every time the value is
+// changed. The subscription is removed when the owner is closed, errors.ErrClosed is
+// returned if the owner is closed already. The owner may be nil, the subscriber is
+// notified in the default App then and the subscription is active until it is cancelled.
+func (o *Observable[T]) Subscribe(owner Component, f func(v T)) (*Subscription, error) {
+	if f == nil {
+		return nil, fmt.Errorf("the subscriber function must not be nil: %w", errors.ErrInvalid)
+	}
+	if owner != nil && owner.baseComponent().isClosed() {
+		return nil, fmt.Errorf("the owner %v is closed: %w", owner, errors.ErrClosed)
+	}
+	app := AppOf(owner)
+	if app.disp == nil {
+		return nil, fmt.Errorf("raywin is not initialized, Init() must be called first: %w", errors.ErrInvalid)
+	}
+	ob := &observer[T]{Subscription: &Subscription{owner: owner}, app: app, f: f}
+	ob.release = func() { o.unsubscribe(ob) }
+	if owner != nil {
+		bc := owner.baseComponent()
+		id, ok := bc.addCloseHook(ob.release)
+		if !ok {
+			return nil, fmt.Errorf("the owner %v is closed: %w", owner, errors.ErrClosed)
+		}
+		ob.release = func() {
+			bc.removeCloseHook(id)
+			o.unsubscribe(ob)
+		}
+	}
+	o.lock.Lock()
+	o.subs = append(o.subs, ob)
+	o.lock.Unlock()
+	o.notify(ob)
+	return ob.Subscription, nil
+}
+
+// notify posts the notification of the subscriber ob, unless it is posted already. If
+// the App queue is full, the notification is deferred till the next frame, so the
+// subscriber gets the latest value anyway.
+func (o *Observable[T]) notify(ob *observer[T]) {
+	if ob.pending.Swap(true) {
+		return
+	}
+	err := ob.app.postOrDefer(func() {
+		ob.pending.Store(false)
+		if !ob.isDead() {
+			ob.f(o.Get())
+		}
+	})
+	if err != nil {
+		ob.pending.Store(false)
+	}
+}
+
+// unsubscribe removes the subscriber ob
+func (o *Observable[T]) unsubscribe(ob *observer[T]) {
+	ob.cancelled.Store(true)
+	o.lock.Lock()
+	defer o.lock.Unlock()
+	for i, ob1 := range o.subs {
+		if ob1 == ob {
+			o.subs = append(o.subs[:i:i], o.subs[i+1:]...)
+			return
+		}
+	}
+}
+
+// Cancel releases the subscription, the subscriber is not notified after that
+func (s *Subscription) Cancel() {
+	s.cancelled.Store(true)
+	if s.release != nil {
+		s.release()
+	}
+}
+
+// IsActive returns whether the subscription is not cancelled and its owner is not closed
+func (s *Subscription) IsActive() bool {
+	return !s.isDead()
+}
+
+func (s *Subscription) isDead() bool {
+	return s.cancelled.Load() || (s.owner != nil && s.owner.baseComponent().isClosed())
+}
+
+// BindVisible makes the visibility of the component c follow the obs value. The binding
+// is released when c is closed.
+func BindVisible(c Component, obs *Observable[bool]) (*Subscription, error) {
+	return obs.Subscribe(c, c.SetVisible)
+}
+
+// BindEnabled makes the enabled state of the component c follow the obs value. The
+// component must implement Enabler. The binding is released when c is closed.
+func BindEnabled(c Component, obs *Observable[bool]) (*Subscription, error) {
+	e, ok := c.(Enabler)
+	if !ok {
+		return nil, fmt.Errorf("the component %v doesn't implement Enabler: %w", c, errors.ErrInvalid)
+	}
+	return obs.Subscribe(c, e.SetEnabled)
+}
@@ -0,0 +1,137 @@
+package raywin
+
+// Copyright 2025 Dmitry Spasibenko
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+import (
+	"github.com/dspasibenko/raywin-go/pkg/golibs/errors"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestObservable_Subscribe(t *testing.T) {
+	app := newTestTimerApp(t)
+	var bc BaseComponent
+	assert.Nil(t, bc.Init(app.RootContainer(), &bc))
+
+	obs := NewObservable(1)
+	var res []int
+	sub, err := obs.Subscribe(&bc, func(v int) { res = append(res, v) })
+	assert.Nil(t, err)
+	assert.Nil(t, res)
+	assert.Nil(t, app.RunFrame(10))
+	assert.Equal(t, []int{1}, res)
+
+	// the changes are coalesced
+	obs.Set(2)
+	obs.Set(3)
+	assert.Equal(t, 3, obs.Get())
+	assert.Nil(t, app.RunFrame(20))
+	assert.Equal(t, []int{1, 3}, res)
+
+	obs.Set(3)
+	assert.Nil(t, app.RunFrame(30))
+	assert.Equal(t, []int{1, 3}, res)
+
+	obs.Set(4)
+	bc.Close()
+	assert.False(t, sub.IsActive())
+	// the subscription is removed with its owner
+	assert.Equal(t, 0, len(obs.subs))
+	assert.Nil(t, app.RunFrame(40))
+	assert.Equal(t, []int{1, 3}, res)
+	_, err = obs.Subscribe(&bc, func(v int) {})
+	assert.ErrorIs(t, err, errors.ErrClosed)
+
+	var bc2 BaseComponent
+	assert.Nil(t, bc2.Init(app.RootContainer(), &bc2))
+	sub, err = obs.Subscribe(&bc2, func(v int) { res = append(res, v) })
+	assert.Nil(t, err)
+	sub.Cancel()
+	assert.False(t, sub.IsActive())
+	assert.Equal(t, 0, len(obs.subs))
+	assert.Equal(t, 0, len(bc2.closeHooks))
+	assert.Equal(t, 1, app.DispatcherStats().Queued)
+	assert.Nil(t, app.RunFrame(50))
+	assert.Equal(t, []int{1, 3}, res)
+
+	_, err = obs.Subscribe(&bc2, nil)
+	assert.ErrorIs(t, err, errors.ErrInvalid)
+	// the default App is not initialized
+	_, err = obs.Subscribe(nil, func(v int) {})
+	assert.ErrorIs(t, err, errors.ErrInvalid)
+}
+
+func TestObservable_queueFull(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.Proxy = &testProxy{}
+	cfg.DispatchQueueSize = 1
+	app, err := NewApp(cfg)
+	assert.Nil(t, err)
+	var bc BaseComponent
+	assert.Nil(t, bc.Init(app.RootContainer(), &bc))
+	obs := NewObservable(1)
+	var res []int
+	_, err = obs.Subscribe(&bc, func(v int) { res = append(res, v) })
+	assert.Nil(t, err)
+	assert.Nil(t, app.RunFrame(10))
+
+	// the notification doesn't fit into the queue, but it is not lost
+	assert.Nil(t, app.Post(func() {}))
+	obs.Set(2)
+	obs.Set(3)
+	assert.Equal(t, DispatcherStats{Queued: 2, Executed: 1}, app.DispatcherStats())
+	assert.Nil(t, app.RunFrame(20))
+	assert.Equal(t, []int{1, 3}, res)
+	assert.Equal(t, DispatcherStats{Executed: 3}, app.DispatcherStats())
+}
+
+type _observable_test_pressor struct {
+	BaseComponent
+	Pressor
+}
+
+func TestBindEnabled(t *testing.T) {
+	app := newTestTimerApp(t)
+	tp := &_observable_test_pressor{}
+	clicks := 0
+	tp.InitPressor(10, 0, func() { clicks++ })
+	assert.Nil(t, tp.Init(app.RootContainer(), tp))
+
+	enabled, visible := NewObservable(false), NewObservable(true)
+	_, err := BindEnabled(tp, enabled)
+	assert.Nil(t, err)
+	_, err = BindVisible(tp, visible)
+	assert.Nil(t, err)
+	visible.Set(false)
+	assert.Nil(t, app.RunFrame(10))
+	assert.False(t, tp.IsEnabled())
+	assert.False(t, tp.IsVisible())
+
+	assert.Equal(t, OnTPSResultNA, tp.OnTPState(TPState{State: TPStatePressed, Sequence: 1}))
+	tp.OnTPState(TPState{State: TPStateReleased, Sequence: 1})
+	assert.Equal(t, 0, clicks)
+
+	enabled.Set(true)
+	assert.Nil(t, app.RunFrame(20))
+	assert.True(t, tp.IsEnabled())
+	assert.Equal(t, OnTPSResultLocked, tp.OnTPState(TPState{State: TPStatePressed, Sequence: 2}))
+	tp.OnTPState(TPState{State: TPStateReleased, Sequence: 2})
+	assert.Equal(t, 1, clicks)
+
+	var bc BaseComponent
+	assert.Nil(t, bc.Init(app.RootContainer(), &bc))
+	_, err = BindEnabled(&bc, enabled)
+	assert.ErrorIs(t, err, errors.ErrInvalid)
+}
@@ -24,6 +24,7 @@ type Pressor struct {
 	presSeq         int64
 
 	pressed    bool
+	disabled   bool
 	onReleaseF func()
 }
 
@@ -35,8 +36,24 @@ func (p *Pressor) InitPressor(radius float32, pressDelay int64, onReleaseF func(
 	p.onReleaseF = onReleaseF
 }
 
+// SetEnabled implements Enabler. The disabled Pressor ignores the touchpad
+func (p *Pressor) SetEnabled(enabled bool) {
+	p.disabled = !enabled
+	if p.disabled {
+		p.pressed = false
+	}
+}
+
+// IsEnabled implements Enabler
+func (p *Pressor) IsEnabled() bool {
+	return !p.disabled
+}
+
 // OnTPState implements Touchpadable
 func (p *Pressor) OnTPState(tps TPState) OnTPSResult {
+	if p.disabled {
+		return OnTPSResultNA
+	}
 	switch tps.State {
 	case TPStatePressed:
 		if tps.Sequence != p.presSeq {
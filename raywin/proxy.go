@@ -27,15 +27,41 @@ type (
 		WindowShouldClose() bool
 		BeginDrawing()
 		EndDrawing()
+		SetEventWaiting(enabled bool)
+		WakeUp()
 		BeginScissorMode(r rl.RectangleInt32)
 		EndScissorMode()
 		ClearBackground(color rl.Color)
 		DrawTexture(texture rl.Texture2D, pos Vector2Int32, color rl.Color)
+		DrawRectangle(r rl.RectangleInt32, color rl.Color)
+		DrawRectangleRec(r rl.Rectangle, color rl.Color)
+		DrawRectangleLines(r rl.Rectangle, lineThick float32, color rl.Color)
+		DrawRectangleRounded(r rl.Rectangle, roundness float32, segments int32, color rl.Color)
+		DrawRectangleRoundedLines(r rl.Rectangle, roundness float32, segments int32, lineThick float32, color rl.Color)
+		DrawCircle(center rl.Vector2, radius float32, color rl.Color)
+		DrawCircleLines(center rl.Vector2, radius float32, color rl.Color)
+		DrawCircleSector(center rl.Vector2, radius, startAngle, endAngle float32, segments int32, color rl.Color)
+		DrawText(font rl.Font, text string, pos rl.Vector2, fontSize, spacing float32, color rl.Color)
+		MeasureText(font rl.Font, text string, fontSize, spacing float32) rl.Vector2
 		IsMouseButtonDown(mb rl.MouseButton) bool
 		GetMouseDelta() rl.Vector2
 		GetMousePosition() rl.Vector2
+		GetTouchPointCount() int32
+		GetTouchPointId(index int32) int32
+		GetTouchPosition(index int32) rl.Vector2
+		GetKeyPressed() int32
+		GetCharPressed() int32
+		IsKeyDown(key int32) bool
+		IsKeyPressedRepeat(key int32) bool
 		LoadTextureFromImage(image *rl.Image) rl.Texture2D
 		LoadFontEx(fileName string, size int32) rl.Font
+		UnloadTexture(texture rl.Texture2D)
+		UnloadFont(font rl.Font)
+		LoadRenderTexture(width, height int32) rl.RenderTexture2D
+		UnloadRenderTexture(target rl.RenderTexture2D)
+		BeginTextureMode(target rl.RenderTexture2D)
+		EndTextureMode()
+		DrawTexturePro(texture rl.Texture2D, src, dst rl.Rectangle, origin rl.Vector2, rotation float32, color rl.Color)
 		SetTextureFilter(texture rl.Texture2D, filterMode rl.TextureFilterMode)
 	}
 
@@ -44,13 +70,24 @@ type (
 		shouldWindowCLose atomic.Bool
 		mousePos          rl.Vector2
 		mouseDiff         rl.Vector2
+		keys              []int32
+		chars             []int32
+		keysDown          map[int32]bool
+		keysRepeat        map[int32]bool
+		touches           []testTouch
+	}
+
+	testTouch struct {
+		id  int32
+		pos rl.Vector2
 	}
 )
 
 func (rp *realProxy) Init(cfg DisplayConfig) {
 	rl.SetConfigFlags(rl.FlagMsaa4xHint)
-	rl.EnableEventWaiting()
 	rl.InitWindow(int32(cfg.Width), int32(cfg.Height), "")
+	// the Escape key is the back key (see Navigator and Dialog), so it must not close the window
+	rl.SetExitKey(0)
 	rl.SetTargetFPS(int32(cfg.FPS))
 }
 
@@ -62,6 +99,22 @@ func (rp *realProxy) WindowShouldClose() bool {
 	return rl.WindowShouldClose()
 }
 
+// SetEventWaiting enables or disables waiting for the input events in EndDrawing(). The
+// waiting is not enabled, if the waiting drawing loop could not be woken up (see WakeUp)
+func (rp *realProxy) SetEventWaiting(enabled bool) {
+	if enabled && eventWaitingSupported {
+		rl.EnableEventWaiting()
+		return
+	}
+	rl.DisableEventWaiting()
+}
+
+// WakeUp makes EndDrawing(), which waits for the events, to return. It may be called
+// from any goroutine
+func (rp *realProxy) WakeUp() {
+	postEmptyEvent()
+}
+
 func (rp *realProxy) BeginDrawing() {
 	rl.BeginDrawing()
 }
@@ -82,6 +135,46 @@ func (rp *realProxy) DrawTexture(texture rl.Texture2D, pos Vector2Int32, color r
 	rl.DrawTexture(texture, pos.X, pos.Y, color)
 }
 
+func (rp *realProxy) DrawRectangle(r rl.RectangleInt32, color rl.Color) {
+	rl.DrawRectangle(r.X, r.Y, r.Width, r.Height, color)
+}
+
+func (rp *realProxy) DrawRectangleRec(r rl.Rectangle, color rl.Color) {
+	rl.DrawRectangleRec(r, color)
+}
+
+func (rp *realProxy) DrawRectangleLines(r rl.Rectangle, lineThick float32, color rl.Color) {
+	rl.DrawRectangleLinesEx(r, lineThick, color)
+}
+
+func (rp *realProxy) DrawRectangleRounded(r rl.Rectangle, roundness float32, segments int32, color rl.Color) {
+	rl.DrawRectangleRounded(r, roundness, segments, color)
+}
+
+func (rp *realProxy) DrawRectangleRoundedLines(r rl.Rectangle, roundness float32, segments int32, lineThick float32, color rl.Color) {
+	rl.DrawRectangleRoundedLinesEx(r, roundness, segments, lineThick, color)
+}
+
+func (rp *realProxy) DrawCircle(center rl.Vector2, radius float32, color rl.Color) {
+	rl.DrawCircleV(center, radius, color)
+}
+
+func (rp *realProxy) DrawCircleLines(center rl.Vector2, radius float32, color rl.Color) {
+	rl.DrawCircleLinesV(center, radius, color)
+}
+
+func (rp *realProxy) DrawCircleSector(center rl.Vector2, radius, startAngle, endAngle float32, segments int32, color rl.Color) {
+	rl.DrawCircleSector(center, radius, startAngle, endAngle, segments, color)
+}
+
+func (rp *realProxy) DrawText(font rl.Font, text string, pos rl.Vector2, fontSize, spacing float32, color rl.Color) {
+	rl.DrawTextEx(font, text, pos, fontSize, spacing, color)
+}
+
+func (rp *realProxy) MeasureText(font rl.Font, text string, fontSize, spacing float32) rl.Vector2 {
+	return rl.MeasureTextEx(font, text, fontSize, spacing)
+}
+
 func (rp *realProxy) ClearBackground(color rl.Color) {
 	rl.ClearBackground(color)
 }
@@ -98,12 +191,73 @@ func (rp *realProxy) GetMousePosition() rl.Vector2 {
 	return rl.GetMousePosition()
 }
 
+func (rp *realProxy) GetTouchPointCount() int32 {
+	return rl.GetTouchPointCount()
+}
+
+func (rp *realProxy) GetTouchPointId(index int32) int32 {
+	return rl.GetTouchPointId(index)
+}
+
+func (rp *realProxy) GetTouchPosition(index int32) rl.Vector2 {
+	return rl.GetTouchPosition(index)
+}
+
+func (rp *realProxy) GetKeyPressed() int32 {
+	return rl.GetKeyPressed()
+}
+
+func (rp *realProxy) GetCharPressed() int32 {
+	return rl.GetCharPressed()
+}
+
+func (rp *realProxy) IsKeyDown(key int32) bool {
+	return rl.IsKeyDown(key)
+}
+
+func (rp *realProxy) IsKeyPressedRepeat(key int32) bool {
+	return rl.IsKeyPressedRepeat(key)
+}
+
 func (rp *realProxy) LoadTextureFromImage(image *rl.Image) rl.Texture2D {
 	return rl.LoadTextureFromImage(image)
 }
 
 func (rp *realProxy) LoadFontEx(fileName string, fontSize int32) rl.Font {
-	return rl.LoadFontEx(fileName, fontSize, nil)
+	f := rl.LoadFontEx(fileName, fontSize, nil)
+	if f.Texture.ID == rl.GetFontDefault().Texture.ID {
+		// raylib falls back to the default font, if the file could not be loaded
+		return rl.Font{}
+	}
+	return f
+}
+
+func (rp *realProxy) UnloadTexture(texture rl.Texture2D) {
+	rl.UnloadTexture(texture)
+}
+
+func (rp *realProxy) UnloadFont(font rl.Font) {
+	rl.UnloadFont(font)
+}
+
+func (rp *realProxy) LoadRenderTexture(width, height int32) rl.RenderTexture2D {
+	return rl.LoadRenderTexture(width, height)
+}
+
+func (rp *realProxy) UnloadRenderTexture(target rl.RenderTexture2D) {
+	rl.UnloadRenderTexture(target)
+}
+
+func (rp *realProxy) BeginTextureMode(target rl.RenderTexture2D) {
+	rl.BeginTextureMode(target)
+}
+
+func (rp *realProxy) EndTextureMode() {
+	rl.EndTextureMode()
+}
+
+func (rp *realProxy) DrawTexturePro(texture rl.Texture2D, src, dst rl.Rectangle, origin rl.Vector2, rotation float32, color rl.Color) {
+	rl.DrawTexturePro(texture, src, dst, origin, rotation, color)
 }
 
 func (rp *realProxy) SetTextureFilter(texture rl.Texture2D, filterMode rl.TextureFilterMode) {
@@ -138,6 +292,37 @@ func (rp *testProxy) EndScissorMode() {
 func (rp *testProxy) DrawTexture(texture rl.Texture2D, pos Vector2Int32, color rl.Color) {
 }
 
+func (rp *testProxy) DrawRectangle(r rl.RectangleInt32, color rl.Color) {
+}
+
+func (rp *testProxy) DrawRectangleRec(r rl.Rectangle, color rl.Color) {
+}
+
+func (rp *testProxy) DrawRectangleLines(r rl.Rectangle, lineThick float32, color rl.Color) {
+}
+
+func (rp *testProxy) DrawRectangleRounded(r rl.Rectangle, roundness float32, segments int32, color rl.Color) {
+}
+
+func (rp *testProxy) DrawRectangleRoundedLines(r rl.Rectangle, roundness float32, segments int32, lineThick float32, color rl.Color) {
+}
+
+func (rp *testProxy) DrawCircle(center rl.Vector2, radius float32, color rl.Color) {
+}
+
+func (rp *testProxy) DrawCircleLines(center rl.Vector2, radius float32, color rl.Color) {
+}
+
+func (rp *testProxy) DrawCircleSector(center rl.Vector2, radius, startAngle, endAngle float32, segments int32, color rl.Color) {
+}
+
+func (rp *testProxy) DrawText(font rl.Font, text string, pos rl.Vector2, fontSize, spacing float32, color rl.Color) {
+}
+
+func (rp *testProxy) MeasureText(font rl.Font, text string, fontSize, spacing float32) rl.Vector2 {
+	return softMeasureText(text, fontSize, spacing)
+}
+
 func (rp *testProxy) ClearBackground(color rl.Color) {
 }
 
@@ -153,12 +338,71 @@ func (rp *testProxy) GetMousePosition() rl.Vector2 {
 	return rp.mousePos
 }
 
+func (rp *testProxy) GetTouchPointCount() int32 {
+	return int32(len(rp.touches))
+}
+
+func (rp *testProxy) GetTouchPointId(index int32) int32 {
+	return rp.touches[index].id
+}
+
+func (rp *testProxy) GetTouchPosition(index int32) rl.Vector2 {
+	return rp.touches[index].pos
+}
+
+func (rp *testProxy) GetKeyPressed() int32 {
+	if len(rp.keys) == 0 {
+		return 0
+	}
+	k := rp.keys[0]
+	rp.keys = rp.keys[1:]
+	return k
+}
+
+func (rp *testProxy) GetCharPressed() int32 {
+	if len(rp.chars) == 0 {
+		return 0
+	}
+	ch := rp.chars[0]
+	rp.chars = rp.chars[1:]
+	return ch
+}
+
+func (rp *testProxy) IsKeyDown(key int32) bool {
+	return rp.keysDown[key]
+}
+
+func (rp *testProxy) IsKeyPressedRepeat(key int32) bool {
+	return rp.keysRepeat[key]
+}
+
 func (rp *testProxy) LoadFontEx(fileName string, fontSize int32) rl.Font {
 	return rl.Font{BaseSize: fontSize, CharsCount: int32(len(fileName))}
 }
 
 func (rp *testProxy) SetTextureFilter(texture rl.Texture2D, filterMode rl.TextureFilterMode) {}
 
+func (rp *testProxy) UnloadTexture(texture rl.Texture2D) {}
+
+func (rp *testProxy) UnloadFont(font rl.Font) {}
+
+func (rp *testProxy) SetEventWaiting(enabled bool) {}
+
+func (rp *testProxy) WakeUp() {}
+
+func (rp *testProxy) LoadRenderTexture(width, height int32) rl.RenderTexture2D {
+	return rl.RenderTexture2D{ID: 1, Texture: rl.Texture2D{ID: 1, Width: width, Height: height}}
+}
+
+func (rp *testProxy) UnloadRenderTexture(target rl.RenderTexture2D) {}
+
+func (rp *testProxy) BeginTextureMode(target rl.RenderTexture2D) {}
+
+func (rp *testProxy) EndTextureMode() {}
+
+func (rp *testProxy) DrawTexturePro(texture rl.Texture2D, src, dst rl.Rectangle, origin rl.Vector2, rotation float32, color rl.Color) {
+}
+
 func (rp *testProxy) LoadTextureFromImage(image *rl.Image) rl.Texture2D {
 	res := rl.Texture2D{}
 	if image != nil {
@@ -0,0 +1,32 @@
+//go:build cgo && !rgfw && !sdl && !sdl3 && !drm && !android
+
+package raywin
+
+// Copyright 2025 Dmitry Spasibenko
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+/*
+// GLFW is built into the raylib package on the desktop platforms
+void glfwPostEmptyEvent(void);
+*/
+import "C"
+
+// eventWaitingSupported is true, if the waiting drawing loop may be woken up by postEmptyEvent
+const eventWaitingSupported = true
+
+// postEmptyEvent wakes up the drawing loop goroutine waiting for the events in EndDrawing,
+// it may be called from any goroutine
+func postEmptyEvent() {
+	C.glfwPostEmptyEvent()
+}
@@ -0,0 +1,24 @@
+//go:build !cgo || rgfw || sdl || sdl3 || drm || android
+
+package raywin
+
+// Copyright 2025 Dmitry Spasibenko
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// eventWaitingSupported is false, the drawing loop could not be woken up on the platform, so
+// the event waiting is never enabled there
+const eventWaitingSupported = false
+
+// postEmptyEvent does nothing on the platform
+func postEmptyEvent() {}
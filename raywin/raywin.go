@@ -31,54 +31,111 @@ import (
 	"sync/atomic"
 )
 
-// Init should be called before the Run() to initialize the raywin-go
+// Init should be called before the Run() to initialize the raywin-go. It initializes the
+// default App, which is used by the package level functions (Run, RootContainer etc.)
 func Init(cfg Config) error {
+	if cfg.Proxy != nil {
+		return c.initConfig(cfg, cfg.Proxy)
+	}
 	return c.initConfig(cfg, p)
 }
 
-// Run runs the drawing cycle and rendering the main window. It will be stopped when
-// the context ctx is closed
+// Run runs the drawing cycle of the default App and rendering the main window. It will
+// be stopped when the context ctx is closed
 func Run(ctx context.Context) error {
-	return c.disp.run(ctx)
+	return c.Run(ctx)
 }
 
-// RootContainer returns the container for the display
+// RunFrame forms and renders one frame of the default App (see App.RunFrame)
+func RunFrame(millis int64) error {
+	return c.RunFrame(millis)
+}
+
+// Close closes the default App (see App.Close), so Init() may be called again
+func Close() error {
+	return c.Close()
+}
+
+// Default returns the default App, which is initialized by Init()
+func Default() *App {
+	return c
+}
+
+// AppOf returns the App the component comp belongs to. The App is resolved by the root
+// container of the components tree, so comp must be added to the tree. If it is not
+// (e.g. comp is not initialized yet or it is closed), the default App is returned.
+func AppOf(comp Component) *App {
+	if comp == nil {
+		return c
+	}
+	bc := comp.baseComponent()
+	for bc.owner != nil {
+		bc = &bc.owner.BaseComponent
+	}
+	if r, ok := bc.this.(*rootContainer); ok && r.app != nil {
+		return r.app
+	}
+	return c
+}
+
+// RootContainer returns the container for the display of the default App
 func RootContainer() Container {
-	return &c.disp.root
+	return c.RootContainer()
+}
+
+// SetFocus moves the input focus to the component comp within the App the component belongs
+// to (see AppOf). The component must implement Focusable interface. Providing nil as comp
+// removes the focus from the focused component of the default App.
+// The function should be called from the raywin callbacks (OnTPState, OnNewFrame etc.)
+// or before Run()
+func SetFocus(comp Component) error {
+	return AppOf(comp).SetFocus(comp)
 }
 
-// SystmeFont returns the default system font
+// Focused returns the component which holds the input focus in the default App or nil,
+// if there is no such one
+func Focused() Component {
+	return c.Focused()
+}
+
+// SystmeFont returns the default system font of the default App
 func SystemFont(size int) rl.Font {
-	return Font(c.cfg.RegularFontFileName, size)
+	return c.SystemFont(size)
 }
 
-// Millis returns the current raywin timestamp. This is not the clock time,
+// Millis returns the current raywin timestamp of the default App. This is not the clock time,
 // but a reference time used in raywin functions
 func Millis() int64 {
-	return c.disp.millis.Load()
+	return c.Millis()
 }
 
-// SystemItalicFont returns the Italic version of the system font
+// SystemItalicFont returns the Italic version of the system font of the default App
 func SystemItalicFont(size int) rl.Font {
-	return Font(c.cfg.ItalicFontFileName, size)
+	return c.SystemItalicFont(size)
 }
 
 const fontCacheScaleFactor = 97
 
-// Font returns the rl.Font for the requested size points (1/72")
+// Font returns the rl.Font of the default App for the requested size points (1/72")
 func Font(fontFile string, size int) rl.Font {
-	f := fmt.Sprintf("%s%%%d", fontFile, size/fontCacheScaleFactor)
-	font, _ := c.fontsCache.GetOrCreate(f)
-	return font
+	return c.Font(fontFile, size)
 }
 
-// GetIcon returns the icon by its name without the extension. If the file name is
-// "picture.png" it can be obtained by "picture". See Config
+// GetIcon returns the icon of the default App by its name without the extension. If the
+// file name is "picture.png" it can be obtained by "picture". See Config
 func GetIcon(in string) (rl.Texture2D, error) {
-	return c.getIcon(in)
+	return c.GetIcon(in)
 }
 
-type controller struct {
+// App is the raywin instance. It owns the configuration, the fonts cache, the icons, the
+// display with its root container and the drawing loop. The package level functions
+// (Init, Run, RootContainer etc.) are the wrappers over the default App, the additional
+// App instances may be created by NewApp, for example, to run the independent tests in
+// parallel with SoftProxy, or to re-create the UI after the display reconfiguration.
+//
+// raylib supports only one window per process, so only one App may use the raylib
+// window (the nil Config.Proxy) at a time.
+type App struct {
 	logger     logging.Logger
 	lock       sync.Mutex
 	resources  atomic.Value
@@ -86,10 +143,129 @@ type controller struct {
 	disp       *display
 	valid      atomic.Bool
 	fontsCache *lru.Cache[string, rl.Font]
+	// fontFiles contains the font files requested by Font()
+	fontFiles map[string]struct{}
+	// fonts contains all the fonts loaded by Font() by their cache keys. The font stays
+	// loaded when it is evicted from fontsCache, it is unloaded on Close, or replaced by
+	// the fonts reload in the dev mode
+	fonts map[string]rl.Font
+	// devw is not nil in the dev mode (see Config.DevMode)
+	devw *devWatcher
 }
 
 var p RlProxy = &realProxy{}
-var c = &controller{}
+var c = &App{}
+
+// NewApp creates the new App and initializes it by cfg. The raylib window is used if
+// cfg.Proxy is nil.
+func NewApp(cfg Config) (*App, error) {
+	a := &App{}
+	proxy := cfg.Proxy
+	if proxy == nil {
+		proxy = &realProxy{}
+	}
+	if err := a.initConfig(cfg, proxy); err != nil {
+		return nil, err
+	}
+	return a, nil
+}
+
+// Run runs the drawing cycle and rendering the main window. It will be stopped when
+// the context ctx is closed
+func (c *App) Run(ctx context.Context) error {
+	if !c.valid.Load() {
+		return fmt.Errorf("Run: raywin is not initialized: %w", errors.ErrInvalid)
+	}
+	return c.disp.run(ctx)
+}
+
+// RunFrame forms and renders one frame with the timestamp millis. It allows to drive
+// the drawing cycle without Run(), for example, in tests (see raywintest package) or by
+// a custom loop. The millis must increase from call to call. The function must not be
+// called while Run() is running.
+func (c *App) RunFrame(millis int64) error {
+	if !c.valid.Load() {
+		return fmt.Errorf("RunFrame: raywin is not initialized: %w", errors.ErrInvalid)
+	}
+	if atomic.LoadInt32(&c.disp.running) != 0 {
+		return fmt.Errorf("RunFrame: Run() is running: %w", errors.ErrConflict)
+	}
+	c.disp.step(millis)
+	return nil
+}
+
+// Close closes all the components and the window, if they are not closed by Run() yet,
+// and makes the App uninitialized, so the default App may be initialized by Init() again.
+// Close must not be called while Run() is running.
+func (c *App) Close() error {
+	if !c.valid.Load() {
+		return nil
+	}
+	if atomic.LoadInt32(&c.disp.running) != 0 {
+		return fmt.Errorf("Close: Run() is running: %w", errors.ErrConflict)
+	}
+	c.disp.close()
+	c.valid.Store(false)
+	return nil
+}
+
+// Config returns the config the App is initialized with
+func (c *App) Config() Config {
+	return c.cfg
+}
+
+// RootContainer returns the container for the display
+func (c *App) RootContainer() Container {
+	return &c.disp.root
+}
+
+// SetFocus moves the input focus to the component comp. The component must implement
+// Focusable interface. Providing nil as comp removes the focus from the focused component.
+// The function should be called from the raywin callbacks (OnTPState, OnNewFrame etc.)
+// or before Run()
+func (c *App) SetFocus(comp Component) error {
+	return c.disp.setFocus(comp)
+}
+
+// Focused returns the component which holds the input focus or nil, if there is no such one
+func (c *App) Focused() Component {
+	return c.disp.focused
+}
+
+// SystemFont returns the default system font
+func (c *App) SystemFont(size int) rl.Font {
+	return c.Font(c.cfg.RegularFontFileName, size)
+}
+
+// SystemItalicFont returns the Italic version of the system font
+func (c *App) SystemItalicFont(size int) rl.Font {
+	return c.Font(c.cfg.ItalicFontFileName, size)
+}
+
+// Millis returns the current App timestamp. This is not the clock time,
+// but a reference time used in raywin functions
+func (c *App) Millis() int64 {
+	return c.disp.millis.Load()
+}
+
+// Font returns the rl.Font for the requested size points (1/72"). The zero font is
+// returned if the App is not initialized. The fonts are owned by the App and stay loaded
+// until the App is closed. In the dev mode the fonts are unloaded when they are reloaded,
+// so the font should be requested when the text is drawn rather than stored.
+func (c *App) Font(fontFile string, size int) rl.Font {
+	if c.fontsCache == nil {
+		return rl.Font{}
+	}
+	f := fmt.Sprintf("%s%%%d", fontFile, size/fontCacheScaleFactor)
+	font, _ := c.fontsCache.GetOrCreate(f)
+	return font
+}
+
+// GetIcon returns the icon by its name without the extension. If the file name is
+// "picture.png" it can be obtained by "picture". See Config
+func (c *App) GetIcon(in string) (rl.Texture2D, error) {
+	return c.getIcon(in)
+}
 
 func assertInitialized() {
 	if !c.valid.Load() {
@@ -97,27 +273,50 @@ func assertInitialized() {
 	}
 }
 
-func (c *controller) initConfig(cfg Config, proxy RlProxy) error {
+func (c *App) initConfig(cfg Config, proxy RlProxy) error {
+	if !cfg.DisplayConfig.Rotation.IsValid() {
+		return fmt.Errorf("initConfig: the display rotation %d must be 0, 90, 180 or 270: %w", cfg.DisplayConfig.Rotation, errors.ErrInvalid)
+	}
+	if err := cfg.DisplayConfig.TouchFilter.validate(); err != nil {
+		return fmt.Errorf("initConfig: %w", err)
+	}
 	if !c.valid.CompareAndSwap(false, true) {
 		return fmt.Errorf("initConfig: already initialized: %w", errors.ErrInvalid)
 	}
 	c.logger = logging.NewLogger("raywin")
 	c.disp = newDisplay(cfg.DisplayConfig, proxy)
+	c.disp.root.app = c
 	c.disp.frmListener = cfg.FrameListener
+	c.disp.tpRecorder = cfg.TouchRecorder
+	c.disp.tpReplay = cfg.TouchReplay
+	if cfg.Clock != nil {
+		c.disp.clock = cfg.Clock
+	}
+	c.disp.dsp = newDispatcher(cfg.DispatchQueueSize, proxy.WakeUp)
 	c.resources.Store(map[string]any{})
 	c.cfg = cfg
+	c.fontFiles = map[string]struct{}{cfg.RegularFontFileName: {}, cfg.ItalicFontFileName: {}}
+	c.fonts = map[string]rl.Font{}
 	c.fontsCache, _ = lru.NewCache[string, rl.Font](20, func(cacheKey string) (rl.Font, error) {
-		s := strings.Split(cacheKey, "%")
-		if len(s) != 2 {
-			return rl.Font{}, fmt.Errorf("invalid cache key: %s, expecting \"fileName%%size\"", cacheKey)
+		c.lock.Lock()
+		f, ok := c.fonts[cacheKey]
+		c.lock.Unlock()
+		if ok {
+			// the font was evicted from the cache, but it is still loaded
+			return f, nil
 		}
-		sz, err := strconv.Atoi(s[1])
+		f, err := c.newFont(cacheKey)
 		if err != nil {
-			return rl.Font{}, fmt.Errorf("invalid cache key: %s, expecting \"fileName%%size\", size=%s cannot be parsed as int", cacheKey, s[1])
+			// the zero font is cached to not load the file every frame, raylib draws the text
+			// with its default font then. The font may be fixed by the reload in the dev mode
+			c.logger.Errorf("could not load the font: %v", err)
 		}
-		sz = max(1, sz)
-		return c.loadFont("system italic font", cfg.ResourceDir, s[0], int32(sz*fontCacheScaleFactor))
+		c.lock.Lock()
+		c.fonts[cacheKey] = f
+		c.lock.Unlock()
+		return f, nil
 	}, nil)
+	c.disp.onClose = c.closeFonts
 	img, err := c.loadImage("wallpaper", cfg.ResourceDir, cfg.WallpaperFileName)
 	if err != nil {
 		return err
@@ -129,10 +328,13 @@ func (c *controller) initConfig(cfg Config, proxy RlProxy) error {
 	if err := c.loadIcons(cfg.ResourceDir, cfg.IconsDir); err != nil {
 		return err
 	}
+	if cfg.DevMode {
+		return c.startDevMode()
+	}
 	return nil
 }
 
-func (c *controller) loadImage(comment, dir, fn string) (*rl.Image, error) {
+func (c *App) loadImage(comment, dir, fn string) (*rl.Image, error) {
 	if fn == "" {
 		c.logger.Infof("%s image file is not specified, skip it", comment)
 		return nil, nil
@@ -149,7 +351,7 @@ func (c *controller) loadImage(comment, dir, fn string) (*rl.Image, error) {
 	return img, nil
 }
 
-func (c *controller) loadIcons(dir, fn string) error {
+func (c *App) loadIcons(dir, fn string) error {
 	if fn == "" {
 		c.logger.Warnf("no icons to load, the file dir name is not provided")
 		return nil
@@ -158,22 +360,50 @@ func (c *controller) loadIcons(dir, fn string) error {
 	if err != nil {
 		return fmt.Errorf("could not open icons dir: %w", err)
 	}
-	files := files.ListDir(fn)
-	for _, f := range files {
+	c.storeIcons(c.readIcons(fn))
+	return nil
+}
+
+// readIcons loads the PNG icons from the dir and returns their textures by the resource
+// names. The icons, which could not be loaded, are skipped.
+func (c *App) readIcons(dir string) map[string]rl.Texture2D {
+	res := map[string]rl.Texture2D{}
+	for _, f := range files.ListDir(dir) {
 		ext := filepath.Ext(f.Name())
 		if ext != ".png" {
 			c.logger.Warnf("don't support icon format %s, skipping it", f.Name())
 			continue
 		}
-		img := rl.LoadImage(filepath.Join(fn, f.Name()))
-		icoName := f.Name()[:len(f.Name())-len(ext)]
-		tx := c.disp.proxy.LoadTextureFromImage(img)
-		c.addResouce("ico_"+icoName, tx)
+		img := rl.LoadImage(filepath.Join(dir, f.Name()))
+		if img == nil || img.Width == 0 || img.Height == 0 {
+			c.logger.Errorf("could not load icon %s, skipping it", f.Name())
+			continue
+		}
+		res["ico_"+f.Name()[:len(f.Name())-len(ext)]] = c.disp.proxy.LoadTextureFromImage(img)
+		rl.UnloadImage(img)
 	}
-	return nil
+	return res
+}
+
+// storeIcons adds the icons textures to the resources and returns the textures of the
+// icons with the same names, which are replaced
+func (c *App) storeIcons(icons map[string]rl.Texture2D) []rl.Texture2D {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	m := container.CopyMap(c.resources.Load().(map[string]any))
+	var replaced []rl.Texture2D
+	for name, tx := range icons {
+		if old, ok := m[name].(rl.Texture2D); ok {
+			replaced = append(replaced, old)
+		}
+		m[name] = tx
+	}
+	c.resources.Store(m)
+	return replaced
 }
 
-func (c *controller) getIcon(in string) (rl.Texture2D, error) {
+func (c *App) getIcon(in string) (rl.Texture2D, error) {
 	r := c.resource("ico_" + in)
 	if r == nil {
 		return rl.Texture2D{}, errors.ErrNotExist
@@ -181,7 +411,7 @@ func (c *controller) getIcon(in string) (rl.Texture2D, error) {
 	return r.(rl.Texture2D), nil
 }
 
-func (c *controller) loadFont(comment, dir, fn string, fontSize int32) (rl.Font, error) {
+func (c *App) loadFont(comment, dir, fn string, fontSize int32) (rl.Font, error) {
 	if fn == "" {
 		c.logger.Infof("%s font is not specified, skip it", comment)
 		return rl.Font{}, nil
@@ -192,11 +422,51 @@ func (c *controller) loadFont(comment, dir, fn string, fontSize int32) (rl.Font,
 	}
 	c.logger.Infof("loading %s from %s", comment, fn)
 	f := c.disp.proxy.LoadFontEx(fn, fontSize)
+	if f.BaseSize <= 0 {
+		return rl.Font{}, fmt.Errorf("%s file %s could not be loaded: %w", comment, fn, errors.ErrInvalid)
+	}
 	c.disp.proxy.SetTextureFilter(f.Texture, rl.FilterBilinear)
 	return f, nil
 }
 
-func (c *controller) checkFileName(dir, fn string) (string, error) {
+// newFont loads the font by its cache key "fileName%size" (see Font)
+func (c *App) newFont(cacheKey string) (rl.Font, error) {
+	s := strings.Split(cacheKey, "%")
+	if len(s) != 2 {
+		return rl.Font{}, fmt.Errorf("invalid cache key: %s, expecting \"fileName%%size\"", cacheKey)
+	}
+	sz, err := strconv.Atoi(s[1])
+	if err != nil {
+		return rl.Font{}, fmt.Errorf("invalid cache key: %s, expecting \"fileName%%size\", size=%s cannot be parsed as int", cacheKey, s[1])
+	}
+	sz = max(1, sz)
+	c.lock.Lock()
+	c.fontFiles[s[0]] = struct{}{}
+	c.lock.Unlock()
+	return c.loadFont("system italic font", c.cfg.ResourceDir, s[0], int32(sz*fontCacheScaleFactor))
+}
+
+// closeFonts unloads all the fonts loaded by Font()
+func (c *App) closeFonts() {
+	c.lock.Lock()
+	fonts := c.fonts
+	c.fonts = map[string]rl.Font{}
+	c.lock.Unlock()
+	c.fontsCache.Clear()
+	unloadFonts(c.disp.proxy, fonts)
+}
+
+// unloadFonts unloads the fonts, the zero fonts are cached for the not specified font
+// files, so they are skipped
+func unloadFonts(proxy RlProxy, fonts map[string]rl.Font) {
+	for _, f := range fonts {
+		if f.BaseSize > 0 {
+			proxy.UnloadFont(f)
+		}
+	}
+}
+
+func (c *App) checkFileName(dir, fn string) (string, error) {
 	if _, err := os.Stat(fn); err != nil {
 		fn1 := filepath.Join(dir, fn)
 		c.logger.Warnf("could not open file %s, will check %s: %v", fn, fn1, err)
@@ -209,12 +479,12 @@ func (c *controller) checkFileName(dir, fn string) (string, error) {
 	return fn, nil
 }
 
-func (c *controller) resource(name string) any {
+func (c *App) resource(name string) any {
 	m := c.resources.Load().(map[string]any)
 	return m[name]
 }
 
-func (c *controller) addResouce(name string, v any) {
+func (c *App) addResouce(name string, v any) {
 	c.lock.Lock()
 	defer c.lock.Unlock()
 
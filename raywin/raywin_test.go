@@ -18,8 +18,10 @@ import (
 	"context"
 	"fmt"
 	"github.com/dspasibenko/raywin-go/pkg/golibs/errors"
+	rl "github.com/gen2brain/raylib-go/raylib"
 	"github.com/stretchr/testify/assert"
 	"path/filepath"
+	"sync/atomic"
 	"testing"
 	"time"
 )
@@ -31,7 +33,7 @@ func Test_assertInitialized(t *testing.T) {
 }
 
 func TestInit(t *testing.T) {
-	c = &controller{}
+	c = &App{}
 	p = &testProxy{}
 	assert.Nil(t, Init(DefaultConfig()))
 	assert.True(t, c.valid.Load())
@@ -48,7 +50,7 @@ func (tfl *testFrameListener) OnNewFrame(millis int64) {
 }
 
 func TestRunAndMisc(t *testing.T) {
-	c = &controller{}
+	c = &App{}
 	p = &testProxy{}
 	cfg := DefaultConfig()
 	fmt.Println(cfg)
@@ -68,7 +70,72 @@ func TestRunAndMisc(t *testing.T) {
 	assert.Equal(t, errors.ErrNotExist, err)
 }
 
-func Test_controller_initConfig(t *testing.T) {
+func TestRunFrameAndClose(t *testing.T) {
+	c = &App{}
+	p = &testProxy{}
+	assert.ErrorIs(t, RunFrame(10), errors.ErrInvalid)
+	assert.Nil(t, Close())
+
+	tfl := &testFrameListener{}
+	cfg := DefaultConfig()
+	cfg.FrameListener = tfl
+	assert.Nil(t, Init(cfg))
+	assert.Nil(t, RunFrame(10))
+	assert.Equal(t, int64(10), tfl.ms)
+	assert.Equal(t, int64(10), Millis())
+
+	atomic.StoreInt32(&c.disp.running, 1)
+	assert.ErrorIs(t, RunFrame(20), errors.ErrConflict)
+	assert.ErrorIs(t, Close(), errors.ErrConflict)
+	atomic.StoreInt32(&c.disp.running, 0)
+
+	var bc BaseComponent
+	assert.Nil(t, bc.Init(RootContainer(), &bc))
+	assert.Nil(t, Close())
+	assert.NotNil(t, bc.AssertInitialized())
+	assert.True(t, p.WindowShouldClose())
+	assert.Nil(t, Init(cfg))
+}
+
+func TestNewApp(t *testing.T) {
+	c = &App{}
+	cfg := DefaultConfig()
+	cfg.Proxy = &testProxy{}
+	a1, err := NewApp(cfg)
+	assert.Nil(t, err)
+	cfg.Proxy = &testProxy{}
+	cfg.DisplayConfig.FPS = 30
+	a2, err := NewApp(cfg)
+	assert.Nil(t, err)
+	assert.NotSame(t, a1.RootContainer(), a2.RootContainer())
+	assert.Equal(t, 30, a2.Config().DisplayConfig.FPS)
+
+	var bc1, bc2 BaseContainer
+	assert.Nil(t, bc1.Init(a1.RootContainer(), &bc1))
+	assert.Nil(t, bc2.Init(&bc1, &bc2))
+	assert.Same(t, a1, AppOf(&bc2))
+	assert.Same(t, c, AppOf(nil))
+	var bc3 BaseComponent
+	assert.Same(t, c, AppOf(&bc3))
+
+	var f _display_test_focusable
+	assert.Nil(t, f.Init(a2.RootContainer(), &f))
+	assert.Nil(t, SetFocus(&f))
+	assert.Same(t, &f, a2.Focused())
+	assert.Nil(t, a1.Focused())
+
+	assert.Nil(t, a1.RunFrame(10))
+	assert.Equal(t, int64(10), a1.Millis())
+	assert.Equal(t, int64(0), a2.Millis())
+
+	assert.Nil(t, a1.Close())
+	assert.NotNil(t, bc2.AssertInitialized())
+	assert.Nil(t, f.AssertInitialized())
+	assert.ErrorIs(t, a1.Run(context.Background()), errors.ErrInvalid)
+	assert.Equal(t, rl.Font{}, c.SystemFont(10))
+}
+
+func TestApp_initConfig(t *testing.T) {
 	cfg := Config{
 		DisplayConfig:       DefaultDisplayConfig(),
 		WallpaperFileName:   filepath.FromSlash("testdata/images/wallpaper800x.png"),
@@ -76,9 +143,9 @@ func Test_controller_initConfig(t *testing.T) {
 		ItalicFontFileName:  filepath.FromSlash("testdata/fonts/Roboto/Roboto-MediumItalic.ttf"),
 		IconsDir:            filepath.FromSlash("testdata/icons"),
 	}
-	c = &controller{}
+	c = &App{}
 	defer func() {
-		c = &controller{}
+		c = &App{}
 	}()
 	assert.Nil(t, c.initConfig(cfg, &testProxy{}))
 	assert.NotNil(t, c.initConfig(cfg, &testProxy{}))
@@ -101,11 +168,11 @@ func Test_controller_initConfig(t *testing.T) {
 	assert.NotEqual(t, f, f1)
 }
 
-func Test_controller_checkFileName(t *testing.T) {
+func TestApp_checkFileName(t *testing.T) {
 	cfg := Config{
 		DisplayConfig: DefaultDisplayConfig(),
 	}
-	c := &controller{}
+	c := &App{}
 	assert.Nil(t, c.initConfig(cfg, &testProxy{}))
 	filename := filepath.FromSlash("testdata/icons/airplane-green.png")
 	fn, err := c.checkFileName("", filename)
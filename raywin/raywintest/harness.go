@@ -0,0 +1,265 @@
+package raywintest
+
+// Copyright 2025 Dmitry Spasibenko
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+import (
+	"fmt"
+	"github.com/dspasibenko/raywin-go/pkg/golibs/errors"
+	"github.com/dspasibenko/raywin-go/raywin"
+	rl "github.com/gen2brain/raylib-go/raylib"
+	"image"
+)
+
+// Harness drives the raywin.App frame by frame for testing the UI code. It creates the App
+// with the Proxy, which renders the frames in memory and which input is injected by
+// the Harness functions (Tap, Drag, Fling etc.). The frames are formed only when the
+// Harness is stepped, every frame advances the App clock by the frame duration
+// (see SetFrameMillis), so the time-dependent behavior is deterministic.
+//
+// Every Harness has its own App, so the tests may run in parallel, if they don't change
+// the global state (e.g. the components style).
+type Harness struct {
+	app         *raywin.App
+	proxy       *Proxy
+	millis      int64
+	frameMillis int64
+}
+
+// Node describes a component in the components tree
+type Node struct {
+	// Component is the component itself
+	Component raywin.Component
+	// Depth is the component depth in the tree, the root container has 0 depth
+	Depth int
+	// Bounds is the component rectangle in the display (physical) coordinates
+	Bounds rl.RectangleInt32
+	// Region is the visible part of Bounds, which is not clipped by the owners
+	Region rl.RectangleInt32
+	// Visible is true if the component and all its owners are visible
+	Visible bool
+}
+
+// DragHoldMillis is the time the touch is held still at the end of Drag() before it is
+// released, so the scrolling components don't take the drag for a fling
+const DragHoldMillis = 300
+
+// NewHarness creates the new raywin.App with the cfg and the new Proxy (cfg.Proxy is
+// ignored) and returns the Harness for it. No frames are formed until the Harness is
+// stepped. The components under test should be added to the App().RootContainer().
+func NewHarness(cfg raywin.Config) (*Harness, error) {
+	h := &Harness{proxy: NewProxy(), frameMillis: 1000 / int64(max(1, cfg.DisplayConfig.FPS))}
+	cfg.Proxy = h.proxy
+	app, err := raywin.NewApp(cfg)
+	if err != nil {
+		return nil, err
+	}
+	h.app = app
+	return h, nil
+}
+
+// Close closes the App and all its components
+func (h *Harness) Close() error {
+	return h.app.Close()
+}
+
+// App returns the App driven by the Harness
+func (h *Harness) App() *raywin.App {
+	return h.app
+}
+
+// Proxy returns the Proxy, which may be used for the direct input injection
+func (h *Harness) Proxy() *Proxy {
+	return h.proxy
+}
+
+// Image returns the last rendered frame
+func (h *Harness) Image() *image.RGBA {
+	return h.proxy.Image()
+}
+
+// Millis returns the timestamp of the last formed frame
+func (h *Harness) Millis() int64 {
+	return h.millis
+}
+
+// SetFrameMillis specifies the frame duration, it is 1000/FPS by default
+func (h *Harness) SetFrameMillis(ms int64) {
+	h.frameMillis = max(1, ms)
+}
+
+// Step forms n frames
+func (h *Harness) Step(n int) error {
+	for i := 0; i < n; i++ {
+		h.millis += h.frameMillis
+		if err := h.app.RunFrame(h.millis); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// StepMillis forms the frames until ms milliseconds pass, at least one frame is formed
+func (h *Harness) StepMillis(ms int64) error {
+	return h.Step(int(max(1, (ms+h.frameMillis-1)/h.frameMillis)))
+}
+
+// Tap touches the display at pos for one frame and releases it. The release is
+// delivered by the next frame.
+func (h *Harness) Tap(pos rl.Vector2) error {
+	return h.LongPress(pos, 1)
+}
+
+// LongPress touches the display at pos for ms milliseconds and releases it
+func (h *Harness) LongPress(pos rl.Vector2, ms int64) error {
+	h.proxy.Touch(0, pos)
+	if err := h.StepMillis(ms); err != nil {
+		return err
+	}
+	h.proxy.Release(0)
+	return h.Step(1)
+}
+
+// Drag touches the display at from, moves the touch point to the position to within the
+// frames number of frames, holds it there for DragHoldMillis and releases it
+func (h *Harness) Drag(from, to rl.Vector2, frames int) error {
+	if err := h.move(from, to, frames); err != nil {
+		return err
+	}
+	if err := h.StepMillis(DragHoldMillis); err != nil {
+		return err
+	}
+	h.proxy.Release(0)
+	return h.Step(1)
+}
+
+// Fling touches the display at from, moves the touch point to the position to within the
+// frames number of frames and releases it immediately, so the scrolling components keep
+// the movement inertia
+func (h *Harness) Fling(from, to rl.Vector2, frames int) error {
+	if err := h.move(from, to, frames); err != nil {
+		return err
+	}
+	h.proxy.Release(0)
+	return h.Step(1)
+}
+
+// TapComponent taps the center of the visible region of the component c
+func (h *Harness) TapComponent(c raywin.Component) error {
+	n, ok := h.Node(c)
+	if !ok {
+		return fmt.Errorf("the component %v is not found in the tree: %w", c, errors.ErrNotExist)
+	}
+	if !n.Visible || n.Region.Width == 0 || n.Region.Height == 0 {
+		return fmt.Errorf("the component %v is not visible: %w", c, errors.ErrInvalid)
+	}
+	return h.Tap(rl.Vector2{X: float32(n.Region.X + n.Region.Width/2), Y: float32(n.Region.Y + n.Region.Height/2)})
+}
+
+func (h *Harness) move(from, to rl.Vector2, frames int) error {
+	h.proxy.Touch(0, from)
+	if err := h.Step(1); err != nil {
+		return err
+	}
+	frames = max(1, frames)
+	for i := 1; i <= frames; i++ {
+		k := float32(i) / float32(frames)
+		h.proxy.Touch(0, rl.Vector2{X: from.X + (to.X-from.X)*k, Y: from.Y + (to.Y-from.Y)*k})
+		if err := h.Step(1); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Nodes returns all the components of the tree in the depth-first order, starting
+// from the root container
+func (h *Harness) Nodes() []Node {
+	root := h.app.RootContainer().(raywin.Component)
+	n := Node{Component: root, Bounds: root.Bounds(), Region: root.Bounds(), Visible: true}
+	return walk(n, nil)
+}
+
+// Find returns the nodes for which the pred returns true
+func (h *Harness) Find(pred func(n Node) bool) []Node {
+	var res []Node
+	for _, n := range h.Nodes() {
+		if pred(n) {
+			res = append(res, n)
+		}
+	}
+	return res
+}
+
+// FindByID returns the node of the first component with the identifier id (see
+// raywin.BaseComponent.SetID)
+func (h *Harness) FindByID(id string) (Node, bool) {
+	res := h.Find(func(n Node) bool {
+		idc, ok := n.Component.(interface{ ID() string })
+		return ok && idc.ID() == id
+	})
+	if len(res) == 0 {
+		return Node{}, false
+	}
+	return res[0], true
+}
+
+// Node returns the node for the component c
+func (h *Harness) Node(c raywin.Component) (Node, bool) {
+	res := h.Find(func(n Node) bool { return n.Component == c })
+	if len(res) == 0 {
+		return Node{}, false
+	}
+	return res[0], true
+}
+
+// FindByType returns all the components of the type T in the depth-first order
+func FindByType[T raywin.Component](h *Harness) []T {
+	var res []T
+	for _, n := range h.Nodes() {
+		if c, ok := n.Component.(T); ok {
+			res = append(res, c)
+		}
+	}
+	return res
+}
+
+func walk(n Node, res []Node) []Node {
+	res = append(res, n)
+	cont, ok := n.Component.(raywin.Container)
+	if !ok {
+		return res
+	}
+	var offs raywin.Vector2Int32
+	if s, ok := n.Component.(raywin.Scrollable); ok {
+		offs = s.Offset()
+	}
+	for _, chld := range cont.Children() {
+		b := chld.Bounds()
+		b.X += n.Bounds.X - offs.X
+		b.Y += n.Bounds.Y - offs.Y
+		res = walk(Node{Component: chld, Depth: n.Depth + 1, Bounds: b, Region: intersect(b, n.Region),
+			Visible: n.Visible && chld.IsVisible()}, res)
+	}
+	return res
+}
+
+func intersect(a, b rl.RectangleInt32) rl.RectangleInt32 {
+	x, y := max(a.X, b.X), max(a.Y, b.Y)
+	r := rl.RectangleInt32{X: x, Y: y, Width: min(a.X+a.Width, b.X+b.Width) - x, Height: min(a.Y+a.Height, b.Y+b.Height) - y}
+	if r.Width <= 0 || r.Height <= 0 {
+		return rl.RectangleInt32{X: x, Y: y}
+	}
+	return r
+}
@@ -0,0 +1,152 @@
+package raywintest
+
+// Copyright 2025 Dmitry Spasibenko
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+import (
+	"github.com/dspasibenko/raywin-go/pkg/golibs/errors"
+	"github.com/dspasibenko/raywin-go/raywin"
+	"github.com/dspasibenko/raywin-go/raywin/components"
+	rl "github.com/gen2brain/raylib-go/raylib"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+type testTouchable struct {
+	raywin.BaseContainer
+	offs   raywin.Vector2Int32
+	states []raywin.TPState
+}
+
+func (tt *testTouchable) OnTPState(tps raywin.TPState) raywin.OnTPSResult {
+	tt.states = append(tt.states, tps)
+	return raywin.OnTPSResultLocked
+}
+
+func (tt *testTouchable) Offset() raywin.Vector2Int32 {
+	return tt.offs
+}
+
+func newTestHarness(t *testing.T) *Harness {
+	cfg := raywin.DefaultConfig()
+	cfg.DisplayConfig.Width, cfg.DisplayConfig.Height = 200, 100
+	cfg.FrameListener = components.DefaultStyleOutlet(cfg.DisplayConfig)
+	h, err := NewHarness(cfg)
+	assert.Nil(t, err)
+	t.Cleanup(func() { assert.Nil(t, h.Close()) })
+	return h
+}
+
+func TestHarness_TapButton(t *testing.T) {
+	h := newTestHarness(t)
+	clicks := 0
+	b, err := components.NewButton(h.App().RootContainer(), rl.RectangleInt32{X: 10, Y: 10, Width: 50, Height: 30}, "OK",
+		components.DialogButtonOkStyle(), func() { clicks++ })
+	assert.Nil(t, err)
+	b.SetID("ok")
+
+	assert.Nil(t, h.Step(2))
+	assert.Equal(t, int64(32), h.Millis())
+	assert.Nil(t, h.TapComponent(b))
+	assert.Nil(t, h.StepMillis(100))
+	assert.Equal(t, 1, clicks)
+
+	n, ok := h.FindByID("ok")
+	assert.True(t, ok)
+	assert.Same(t, b, n.Component)
+	assert.Equal(t, []*components.Button{b}, FindByType[*components.Button](h))
+	assert.Equal(t, 1, n.Depth)
+
+	b.SetVisible(false)
+	assert.ErrorIs(t, h.TapComponent(b), errors.ErrInvalid)
+	b.Close()
+	assert.ErrorIs(t, h.TapComponent(b), errors.ErrNotExist)
+	_, ok = h.FindByID("ok")
+	assert.False(t, ok)
+}
+
+func TestHarness_DragAndFling(t *testing.T) {
+	h := newTestHarness(t)
+	var tt testTouchable
+	assert.Nil(t, tt.Init(h.App().RootContainer(), &tt))
+	tt.SetBounds(rl.RectangleInt32{Width: 200, Height: 100})
+
+	assert.Nil(t, h.Drag(rl.Vector2{X: 10, Y: 10}, rl.Vector2{X: 50, Y: 10}, 4))
+	assert.Equal(t, raywin.TPStatePressed, tt.states[0].State)
+	assert.Equal(t, raywin.TPStateMoving, tt.states[1].State)
+	assert.Equal(t, rl.Vector2{X: 20, Y: 10}, tt.states[1].Pos)
+	last := tt.states[len(tt.states)-1]
+	assert.Equal(t, raywin.TPStateReleased, last.State)
+	assert.Equal(t, rl.Vector2{X: 50, Y: 10}, last.Pos)
+	assert.True(t, last.Millis-tt.states[5].Millis >= DragHoldMillis)
+
+	tt.states = nil
+	assert.Nil(t, h.Fling(rl.Vector2{X: 50, Y: 10}, rl.Vector2{X: 10, Y: 10}, 2))
+	assert.Equal(t, 4, len(tt.states))
+	assert.Equal(t, raywin.TPStateReleased, tt.states[3].State)
+	assert.Equal(t, tt.states[2].Millis+16, tt.states[3].Millis)
+}
+
+func TestHarness_Nodes(t *testing.T) {
+	h := newTestHarness(t)
+	var tt testTouchable
+	assert.Nil(t, tt.Init(h.App().RootContainer(), &tt))
+	tt.SetBounds(rl.RectangleInt32{X: 10, Y: 10, Width: 100, Height: 50})
+	tt.offs = raywin.Vector2Int32{X: 5, Y: 0}
+	var chld, hidden raywin.BaseComponent
+	assert.Nil(t, chld.Init(&tt, &chld))
+	chld.SetBounds(rl.RectangleInt32{X: 0, Y: 40, Width: 20, Height: 20})
+	assert.Nil(t, hidden.Init(&tt, &hidden))
+	hidden.SetVisible(false)
+
+	nodes := h.Nodes()
+	assert.Equal(t, 4, len(nodes))
+	assert.Equal(t, rl.RectangleInt32{Width: 200, Height: 100}, nodes[0].Bounds)
+	assert.Equal(t, rl.RectangleInt32{X: 5, Y: 50, Width: 20, Height: 20}, nodes[2].Bounds)
+	assert.Equal(t, rl.RectangleInt32{X: 10, Y: 50, Width: 15, Height: 10}, nodes[2].Region)
+	assert.Equal(t, 2, nodes[2].Depth)
+	assert.True(t, nodes[2].Visible)
+	assert.False(t, nodes[3].Visible)
+}
+
+func TestHarness_Keys(t *testing.T) {
+	h := newTestHarness(t)
+	eb, err := components.NewEditBox(h.App().RootContainer(), components.DefaultEditBoxConfig().
+		Rectangle(rl.RectangleInt32{X: 10, Y: 10, Width: 150, Height: 40}))
+	assert.Nil(t, err)
+	assert.Nil(t, h.TapComponent(eb))
+	assert.Same(t, eb, h.App().Focused())
+
+	h.Proxy().TypeText("abc")
+	assert.Nil(t, h.Step(1))
+	h.Proxy().PressKey(rl.KeyBackspace)
+	assert.Nil(t, h.Step(1))
+	assert.Equal(t, "ab", eb.Text())
+	assert.NotNil(t, h.Image())
+}
+
+func TestHarness_ScrollableContainer(t *testing.T) {
+	// the default App is not initialized, the container must be set up by the Harness App
+	assert.Nil(t, raywin.Default().Config().Proxy)
+	h := newTestHarness(t)
+	var sc components.ScrollableContainer
+	assert.Nil(t, sc.InitScrollableContainer(h.App().RootContainer(), &sc, raywin.ScrollVertical))
+	sc.SetBounds(rl.RectangleInt32{Width: 200, Height: 100})
+	sc.SetVirtualBounds(rl.RectangleInt32{Width: 200, Height: 400})
+	assert.Nil(t, h.Step(1))
+
+	assert.Nil(t, h.Drag(rl.Vector2{X: 100, Y: 90}, rl.Vector2{X: 100, Y: 30}, 6))
+	assert.Nil(t, h.Step(2))
+	assert.Greater(t, sc.Offset().Y, int32(0))
+}
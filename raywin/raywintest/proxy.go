@@ -0,0 +1,119 @@
+package raywintest
+
+// Copyright 2025 Dmitry Spasibenko
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+import (
+	"github.com/dspasibenko/raywin-go/raywin"
+	rl "github.com/gen2brain/raylib-go/raylib"
+	"slices"
+)
+
+// Proxy is the raywin.RlProxy, which renders the frames by raywin.SoftProxy and which
+// input is injected by the test: the touched points and the pressed keys.
+type Proxy struct {
+	*raywin.SoftProxy
+
+	touches []touch
+	keys    []int32
+	chars   []int32
+	down    map[int32]bool
+}
+
+type touch struct {
+	id  int32
+	pos rl.Vector2
+}
+
+var _ raywin.RlProxy = (*Proxy)(nil)
+
+// NewProxy returns the new Proxy without any touches and keys pressed
+func NewProxy() *Proxy {
+	return &Proxy{SoftProxy: raywin.NewSoftProxy(), down: map[int32]bool{}}
+}
+
+// Touch makes the point id touched at the position pos. The point stays touched until
+// Release() is called for it
+func (p *Proxy) Touch(id int32, pos rl.Vector2) {
+	for i := range p.touches {
+		if p.touches[i].id == id {
+			p.touches[i].pos = pos
+			return
+		}
+	}
+	p.touches = append(p.touches, touch{id: id, pos: pos})
+}
+
+// Release releases the touched point id
+func (p *Proxy) Release(id int32) {
+	p.touches = slices.DeleteFunc(p.touches, func(t touch) bool { return t.id == id })
+}
+
+// PressKey adds the key to the pressed keys, which are reported in the next frame before
+// the typed chars. The key is not reported as being held down (see SetKeyDown)
+func (p *Proxy) PressKey(key int32) {
+	p.keys = append(p.keys, key)
+}
+
+// TypeText adds the text runes to the typed chars, which are reported in the next frame
+func (p *Proxy) TypeText(text string) {
+	for _, r := range text {
+		p.chars = append(p.chars, r)
+	}
+}
+
+// SetKeyDown specifies whether the key is held down
+func (p *Proxy) SetKeyDown(key int32, down bool) {
+	p.down[key] = down
+}
+
+// GetTouchPointCount implements raywin.RlProxy
+func (p *Proxy) GetTouchPointCount() int32 {
+	return int32(len(p.touches))
+}
+
+// GetTouchPointId implements raywin.RlProxy
+func (p *Proxy) GetTouchPointId(index int32) int32 {
+	return p.touches[index].id
+}
+
+// GetTouchPosition implements raywin.RlProxy
+func (p *Proxy) GetTouchPosition(index int32) rl.Vector2 {
+	return p.touches[index].pos
+}
+
+// GetKeyPressed implements raywin.RlProxy
+func (p *Proxy) GetKeyPressed() int32 {
+	if len(p.keys) == 0 {
+		return 0
+	}
+	k := p.keys[0]
+	p.keys = p.keys[1:]
+	return k
+}
+
+// GetCharPressed implements raywin.RlProxy
+func (p *Proxy) GetCharPressed() int32 {
+	if len(p.chars) == 0 {
+		return 0
+	}
+	c := p.chars[0]
+	p.chars = p.chars[1:]
+	return c
+}
+
+// IsKeyDown implements raywin.RlProxy
+func (p *Proxy) IsKeyDown(key int32) bool {
+	return p.down[key]
+}
@@ -0,0 +1,536 @@
+package raywin
+
+// Copyright 2025 Dmitry Spasibenko
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+import (
+	"bufio"
+	"fmt"
+	"github.com/dspasibenko/raywin-go/pkg/golibs/errors"
+	"github.com/dspasibenko/raywin-go/pkg/golibs/xbinary"
+	rl "github.com/gen2brain/raylib-go/raylib"
+	"io"
+	"math"
+	"strings"
+	"sync"
+)
+
+type (
+	// DrawTracer maybe implemented by a RlProxy to be notified which component is drawn. The
+	// display calls BeginComponent before the component Draw(), and EndComponent after the
+	// component and all its children are drawn, so the calls are nested as the components are.
+	DrawTracer interface {
+		// BeginComponent is called with the component type name, its physical bounds and
+		// the visible (scissor) region of it
+		BeginComponent(name string, bounds, region rl.RectangleInt32)
+		// EndComponent is called when the component drawing is over
+		EndComponent()
+	}
+
+	// Painter is the set of the drawing primitives, which both RlProxy and CanvasContext
+	// provide. A recorded DrawFrame may be replayed to any of them.
+	Painter interface {
+		DrawRectangle(r rl.RectangleInt32, color rl.Color)
+		DrawRectangleRec(r rl.Rectangle, color rl.Color)
+		DrawRectangleLines(r rl.Rectangle, lineThick float32, color rl.Color)
+		DrawRectangleRounded(r rl.Rectangle, roundness float32, segments int32, color rl.Color)
+		DrawRectangleRoundedLines(r rl.Rectangle, roundness float32, segments int32, lineThick float32, color rl.Color)
+		DrawCircle(center rl.Vector2, radius float32, color rl.Color)
+		DrawCircleLines(center rl.Vector2, radius float32, color rl.Color)
+		DrawCircleSector(center rl.Vector2, radius, startAngle, endAngle float32, segments int32, color rl.Color)
+		DrawText(font rl.Font, text string, pos rl.Vector2, fontSize, spacing float32, color rl.Color)
+		DrawTexture(texture rl.Texture2D, pos Vector2Int32, color rl.Color)
+		BeginScissorMode(r rl.RectangleInt32)
+		EndScissorMode()
+	}
+
+	// RecordingProxy is the RlProxy decorator, which passes all calls to the decorated proxy and
+	// writes the draw calls of the selected frames (see RecordFrames) to the writer. The records
+	// contain the drawing component path, its bounds and visible region, the scissor changes and
+	// the primitives arguments. The records can be read by ReadDrawRecording and replayed or
+	// dumped as text (see cmd/rwreplay).
+	//
+	// To record the application frames, provide the proxy via Config.Proxy.
+	RecordingProxy struct {
+		RlProxy
+
+		lock sync.Mutex
+		ow   xbinary.ObjectsWriter
+		bw   *bufio.Writer
+		cfg  DisplayConfig
+		// left is the number of frames to be recorded, negative means all frames
+		left      int
+		recording bool
+		header    bool
+		frame     uint64
+		err       error
+	}
+
+	// DrawOp is the recorded draw command operation
+	DrawOp byte
+
+	// DrawCommand is the recorded draw call. The fields meaning depend on the Op:
+	//   - Rect is the rectangle for the rectangles, the component bounds, the scissor region, the
+	//     circle center and the text or the texture position (X, Y)
+	//   - Params are the other numeric arguments in the order of the Painter function arguments,
+	//     for DrawOpComponent they are the visible region (X, Y, Width, Height) of the component
+	//   - Text is the text for DrawOpText and the component type name for DrawOpComponent
+	DrawCommand struct {
+		Op      DrawOp
+		Path    string
+		Rect    rl.Rectangle
+		Params  []float32
+		Color   rl.Color
+		Text    string
+		Texture rl.Texture2D
+	}
+
+	// DrawFrame is the recorded frame
+	DrawFrame struct {
+		Number   uint64
+		Width    int32
+		Height   int32
+		Commands []DrawCommand
+	}
+
+	drReader struct {
+		buf []byte
+		err error
+	}
+)
+
+const (
+	DrawOpComponent DrawOp = iota + 1
+	DrawOpComponentEnd
+	DrawOpScissor
+	DrawOpScissorEnd
+	DrawOpClear
+	DrawOpTexture
+	DrawOpRectangle
+	DrawOpRectangleLines
+	DrawOpRectangleRounded
+	DrawOpRectangleRoundedLines
+	DrawOpCircle
+	DrawOpCircleLines
+	DrawOpCircleSector
+	DrawOpText
+
+	drawOpFrame    DrawOp = 100
+	drawOpFrameEnd DrawOp = 101
+)
+
+const drawRecordingMagic = "RWDR1"
+
+var drawOpNames = map[DrawOp]string{
+	DrawOpComponent:             "component",
+	DrawOpComponentEnd:          "component-end",
+	DrawOpScissor:               "scissor",
+	DrawOpScissorEnd:            "scissor-end",
+	DrawOpClear:                 "clear",
+	DrawOpTexture:               "texture",
+	DrawOpRectangle:             "rectangle",
+	DrawOpRectangleLines:        "rectangle-lines",
+	DrawOpRectangleRounded:      "rectangle-rounded",
+	DrawOpRectangleRoundedLines: "rectangle-rounded-lines",
+	DrawOpCircle:                "circle",
+	DrawOpCircleLines:           "circle-lines",
+	DrawOpCircleSector:          "circle-sector",
+	DrawOpText:                  "text",
+}
+
+var _ RlProxy = (*RecordingProxy)(nil)
+var _ DrawTracer = (*RecordingProxy)(nil)
+var _ Painter = (*CanvasContext)(nil)
+var _ Painter = (RlProxy)(nil)
+
+// NewRecordingProxy returns the new RecordingProxy, which decorates the proxy and writes the
+// records to w. If proxy is nil, the raylib window proxy is decorated. No frames are recorded
+// until RecordFrames() is called.
+func NewRecordingProxy(proxy RlProxy, w io.Writer) *RecordingProxy {
+	if proxy == nil {
+		proxy = &realProxy{}
+	}
+	rp := &RecordingProxy{RlProxy: proxy, bw: bufio.NewWriter(w)}
+	rp.ow.Writer = rp.bw
+	return rp
+}
+
+// RecordFrames makes the proxy record the next n frames, the negative n means all frames
+// until StopRecording() is called. The function may be called from any goroutine.
+func (rp *RecordingProxy) RecordFrames(n int) {
+	rp.lock.Lock()
+	defer rp.lock.Unlock()
+	rp.left = n
+}
+
+// StopRecording stops recording after the current frame
+func (rp *RecordingProxy) StopRecording() {
+	rp.RecordFrames(0)
+}
+
+// Err returns the first error the records writing failed with, if any
+func (rp *RecordingProxy) Err() error {
+	rp.lock.Lock()
+	defer rp.lock.Unlock()
+	return rp.err
+}
+
+// Init implements RlProxy
+func (rp *RecordingProxy) Init(cfg DisplayConfig) {
+	rp.lock.Lock()
+	rp.cfg = cfg
+	rp.lock.Unlock()
+	rp.RlProxy.Init(cfg)
+}
+
+// BeginDrawing implements RlProxy
+func (rp *RecordingProxy) BeginDrawing() {
+	rp.lock.Lock()
+	rp.frame++
+	rp.recording = rp.left != 0 && rp.err == nil
+	if rp.recording {
+		if !rp.header {
+			rp.header = true
+			rp.ow.WritePureString(drawRecordingMagic)
+			// the components are drawn in the logical (not rotated) area
+			w, h := rp.cfg.LogicalSize()
+			rp.ow.WriteUint(uint(w))
+			rp.ow.WriteUint(uint(h))
+		}
+		rp.writeOp(drawOpFrame)
+		rp.ow.WriteUint(uint(rp.frame))
+	}
+	rp.lock.Unlock()
+	rp.RlProxy.BeginDrawing()
+}
+
+// EndDrawing implements RlProxy
+func (rp *RecordingProxy) EndDrawing() {
+	rp.RlProxy.EndDrawing()
+	rp.lock.Lock()
+	defer rp.lock.Unlock()
+	if !rp.recording {
+		return
+	}
+	rp.writeOp(drawOpFrameEnd)
+	if err := rp.bw.Flush(); err != nil && rp.err == nil {
+		rp.err = err
+	}
+	rp.recording = false
+	if rp.left > 0 {
+		rp.left--
+	}
+}
+
+// BeginComponent implements DrawTracer
+func (rp *RecordingProxy) BeginComponent(name string, bounds, region rl.RectangleInt32) {
+	rp.record(DrawCommand{Op: DrawOpComponent, Rect: bounds.ToFloat32(), Text: name,
+		Params: []float32{float32(region.X), float32(region.Y), float32(region.Width), float32(region.Height)}})
+}
+
+// EndComponent implements DrawTracer
+func (rp *RecordingProxy) EndComponent() {
+	rp.record(DrawCommand{Op: DrawOpComponentEnd})
+}
+
+// BeginScissorMode implements RlProxy
+func (rp *RecordingProxy) BeginScissorMode(r rl.RectangleInt32) {
+	rp.record(DrawCommand{Op: DrawOpScissor, Rect: r.ToFloat32()})
+	rp.RlProxy.BeginScissorMode(r)
+}
+
+// EndScissorMode implements RlProxy
+func (rp *RecordingProxy) EndScissorMode() {
+	rp.record(DrawCommand{Op: DrawOpScissorEnd})
+	rp.RlProxy.EndScissorMode()
+}
+
+// ClearBackground implements RlProxy
+func (rp *RecordingProxy) ClearBackground(color rl.Color) {
+	rp.record(DrawCommand{Op: DrawOpClear, Color: color})
+	rp.RlProxy.ClearBackground(color)
+}
+
+// DrawTexture implements RlProxy
+func (rp *RecordingProxy) DrawTexture(texture rl.Texture2D, pos Vector2Int32, color rl.Color) {
+	rp.record(DrawCommand{Op: DrawOpTexture, Rect: rl.Rectangle{X: float32(pos.X), Y: float32(pos.Y)}, Color: color, Texture: texture})
+	rp.RlProxy.DrawTexture(texture, pos, color)
+}
+
+// DrawRectangle implements RlProxy
+func (rp *RecordingProxy) DrawRectangle(r rl.RectangleInt32, color rl.Color) {
+	rp.record(DrawCommand{Op: DrawOpRectangle, Rect: r.ToFloat32(), Color: color})
+	rp.RlProxy.DrawRectangle(r, color)
+}
+
+// DrawRectangleRec implements RlProxy
+func (rp *RecordingProxy) DrawRectangleRec(r rl.Rectangle, color rl.Color) {
+	rp.record(DrawCommand{Op: DrawOpRectangle, Rect: r, Color: color})
+	rp.RlProxy.DrawRectangleRec(r, color)
+}
+
+// DrawRectangleLines implements RlProxy
+func (rp *RecordingProxy) DrawRectangleLines(r rl.Rectangle, lineThick float32, color rl.Color) {
+	rp.record(DrawCommand{Op: DrawOpRectangleLines, Rect: r, Params: []float32{lineThick}, Color: color})
+	rp.RlProxy.DrawRectangleLines(r, lineThick, color)
+}
+
+// DrawRectangleRounded implements RlProxy
+func (rp *RecordingProxy) DrawRectangleRounded(r rl.Rectangle, roundness float32, segments int32, color rl.Color) {
+	rp.record(DrawCommand{Op: DrawOpRectangleRounded, Rect: r, Params: []float32{roundness, float32(segments)}, Color: color})
+	rp.RlProxy.DrawRectangleRounded(r, roundness, segments, color)
+}
+
+// DrawRectangleRoundedLines implements RlProxy
+func (rp *RecordingProxy) DrawRectangleRoundedLines(r rl.Rectangle, roundness float32, segments int32, lineThick float32, color rl.Color) {
+	rp.record(DrawCommand{Op: DrawOpRectangleRoundedLines, Rect: r, Params: []float32{roundness, float32(segments), lineThick}, Color: color})
+	rp.RlProxy.DrawRectangleRoundedLines(r, roundness, segments, lineThick, color)
+}
+
+// DrawCircle implements RlProxy
+func (rp *RecordingProxy) DrawCircle(center rl.Vector2, radius float32, color rl.Color) {
+	rp.record(DrawCommand{Op: DrawOpCircle, Rect: rl.Rectangle{X: center.X, Y: center.Y}, Params: []float32{radius}, Color: color})
+	rp.RlProxy.DrawCircle(center, radius, color)
+}
+
+// DrawCircleLines implements RlProxy
+func (rp *RecordingProxy) DrawCircleLines(center rl.Vector2, radius float32, color rl.Color) {
+	rp.record(DrawCommand{Op: DrawOpCircleLines, Rect: rl.Rectangle{X: center.X, Y: center.Y}, Params: []float32{radius}, Color: color})
+	rp.RlProxy.DrawCircleLines(center, radius, color)
+}
+
+// DrawCircleSector implements RlProxy
+func (rp *RecordingProxy) DrawCircleSector(center rl.Vector2, radius, startAngle, endAngle float32, segments int32, color rl.Color) {
+	rp.record(DrawCommand{Op: DrawOpCircleSector, Rect: rl.Rectangle{X: center.X, Y: center.Y},
+		Params: []float32{radius, startAngle, endAngle, float32(segments)}, Color: color})
+	rp.RlProxy.DrawCircleSector(center, radius, startAngle, endAngle, segments, color)
+}
+
+// DrawText implements RlProxy
+func (rp *RecordingProxy) DrawText(font rl.Font, text string, pos rl.Vector2, fontSize, spacing float32, color rl.Color) {
+	rp.record(DrawCommand{Op: DrawOpText, Rect: rl.Rectangle{X: pos.X, Y: pos.Y}, Params: []float32{fontSize, spacing}, Color: color, Text: text})
+	rp.RlProxy.DrawText(font, text, pos, fontSize, spacing, color)
+}
+
+func (rp *RecordingProxy) record(dc DrawCommand) {
+	rp.lock.Lock()
+	defer rp.lock.Unlock()
+	if !rp.recording {
+		return
+	}
+	rp.writeOp(dc.Op)
+	for _, v := range []float32{dc.Rect.X, dc.Rect.Y, dc.Rect.Width, dc.Rect.Height} {
+		rp.ow.WriteUint32(math.Float32bits(v))
+	}
+	rp.ow.WriteUint(uint(len(dc.Params)))
+	for _, v := range dc.Params {
+		rp.ow.WriteUint32(math.Float32bits(v))
+	}
+	rp.ow.WriteUint32(uint32(dc.Color.R)<<24 | uint32(dc.Color.G)<<16 | uint32(dc.Color.B)<<8 | uint32(dc.Color.A))
+	rp.ow.WriteString(dc.Text)
+	if dc.Op == DrawOpTexture {
+		rp.ow.WriteUint(uint(dc.Texture.ID))
+		rp.ow.WriteUint(uint(dc.Texture.Width))
+		rp.ow.WriteUint(uint(dc.Texture.Height))
+	}
+}
+
+func (rp *RecordingProxy) writeOp(op DrawOp) {
+	if _, err := rp.ow.WriteByteWithSize(byte(op)); err != nil && rp.err == nil {
+		rp.err = err
+	}
+}
+
+// ReadDrawRecording reads the frames written by RecordingProxy. The commands Path is
+// restored from the components nesting, the path elements are separated by '/'.
+func ReadDrawRecording(r io.Reader) ([]DrawFrame, error) {
+	buf, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	if !strings.HasPrefix(string(buf), drawRecordingMagic) {
+		return nil, fmt.Errorf("not a draw recording, the header is not found: %w", errors.ErrInvalid)
+	}
+	rd := &drReader{buf: buf[len(drawRecordingMagic):]}
+	width, height := int32(rd.uint()), int32(rd.uint())
+	var res []DrawFrame
+	var frame *DrawFrame
+	var path []string
+	for len(rd.buf) > 0 && rd.err == nil {
+		op := DrawOp(rd.byte())
+		switch op {
+		case drawOpFrame:
+			res = append(res, DrawFrame{Number: uint64(rd.uint()), Width: width, Height: height})
+			frame = &res[len(res)-1]
+			path = path[:0]
+			continue
+		case drawOpFrameEnd:
+			frame = nil
+			continue
+		}
+		if frame == nil || drawOpNames[op] == "" {
+			return res, fmt.Errorf("unexpected operation %d at %d bytes before the end: %w", op, len(rd.buf), errors.ErrDataLoss)
+		}
+		dc := DrawCommand{Op: op}
+		dc.Rect = rl.Rectangle{X: rd.float32(), Y: rd.float32(), Width: rd.float32(), Height: rd.float32()}
+		if n := int(rd.uint()); n > 0 && n <= len(rd.buf) {
+			dc.Params = make([]float32, n)
+			for i := range dc.Params {
+				dc.Params[i] = rd.float32()
+			}
+		}
+		col := rd.uint32()
+		dc.Color = rl.Color{R: uint8(col >> 24), G: uint8(col >> 16), B: uint8(col >> 8), A: uint8(col)}
+		dc.Text = rd.string()
+		if op == DrawOpTexture {
+			dc.Texture = rl.Texture2D{ID: uint32(rd.uint()), Width: int32(rd.uint()), Height: int32(rd.uint())}
+		}
+		if op == DrawOpComponent {
+			path = append(path, dc.Text)
+		}
+		dc.Path = strings.Join(path, "/")
+		if op == DrawOpComponentEnd && len(path) > 0 {
+			path = path[:len(path)-1]
+		}
+		frame.Commands = append(frame.Commands, dc)
+	}
+	if rd.err != nil {
+		return res, fmt.Errorf("the recording is truncated: %w", errors.ErrDataLoss)
+	}
+	return res, nil
+}
+
+// Replay draws the frame commands by the painter p. The textures are not available out of the
+// process they were recorded in, so they are drawn as the magenta frames of the texture size.
+func (df DrawFrame) Replay(p Painter) {
+	for _, dc := range df.Commands {
+		dc.Replay(p, df.Width, df.Height)
+	}
+}
+
+// String returns the frame commands dump, one command per line
+func (df DrawFrame) String() string {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "frame %d (%dx%d)\n", df.Number, df.Width, df.Height)
+	depth := 0
+	for _, dc := range df.Commands {
+		if dc.Op == DrawOpComponentEnd {
+			depth = max(0, depth-1)
+			continue
+		}
+		sb.WriteString(strings.Repeat("  ", depth+1))
+		sb.WriteString(dc.String())
+		sb.WriteByte('\n')
+		if dc.Op == DrawOpComponent {
+			depth++
+		}
+	}
+	return sb.String()
+}
+
+// Replay draws the command by the painter p, the width and height are the display dimensions
+func (dc DrawCommand) Replay(p Painter, width, height int32) {
+	r := dc.Rect
+	ri := rl.RectangleInt32{X: int32(r.X), Y: int32(r.Y), Width: int32(r.Width), Height: int32(r.Height)}
+	pos := rl.Vector2{X: r.X, Y: r.Y}
+	switch dc.Op {
+	case DrawOpScissor:
+		p.BeginScissorMode(ri)
+	case DrawOpScissorEnd:
+		p.EndScissorMode()
+	case DrawOpClear:
+		p.DrawRectangle(rl.RectangleInt32{Width: width, Height: height}, dc.Color)
+	case DrawOpTexture:
+		p.DrawRectangleLines(rl.Rectangle{X: r.X, Y: r.Y, Width: float32(dc.Texture.Width), Height: float32(dc.Texture.Height)}, 1, rl.Magenta)
+	case DrawOpRectangle:
+		p.DrawRectangleRec(r, dc.Color)
+	case DrawOpRectangleLines:
+		p.DrawRectangleLines(r, dc.param(0), dc.Color)
+	case DrawOpRectangleRounded:
+		p.DrawRectangleRounded(r, dc.param(0), int32(dc.param(1)), dc.Color)
+	case DrawOpRectangleRoundedLines:
+		p.DrawRectangleRoundedLines(r, dc.param(0), int32(dc.param(1)), dc.param(2), dc.Color)
+	case DrawOpCircle:
+		p.DrawCircle(pos, dc.param(0), dc.Color)
+	case DrawOpCircleLines:
+		p.DrawCircleLines(pos, dc.param(0), dc.Color)
+	case DrawOpCircleSector:
+		p.DrawCircleSector(pos, dc.param(0), dc.param(1), dc.param(2), int32(dc.param(3)), dc.Color)
+	case DrawOpText:
+		p.DrawText(rl.Font{}, dc.Text, pos, dc.param(0), dc.param(1), dc.Color)
+	}
+}
+
+// String returns the command description
+func (dc DrawCommand) String() string {
+	switch dc.Op {
+	case DrawOpComponent:
+		return fmt.Sprintf("%s %s bounds=%v region=%v", drawOpNames[dc.Op], dc.Text, dc.Rect, dc.Params)
+	case DrawOpScissorEnd:
+		return drawOpNames[dc.Op]
+	case DrawOpScissor:
+		return fmt.Sprintf("%s %v", drawOpNames[dc.Op], dc.Rect)
+	case DrawOpClear:
+		return fmt.Sprintf("%s %v", drawOpNames[dc.Op], dc.Color)
+	case DrawOpTexture:
+		return fmt.Sprintf("%s id=%d %dx%d pos=(%v, %v) %v", drawOpNames[dc.Op], dc.Texture.ID, dc.Texture.Width, dc.Texture.Height, dc.Rect.X, dc.Rect.Y, dc.Color)
+	case DrawOpText:
+		return fmt.Sprintf("%s %q pos=(%v, %v) %v %v", drawOpNames[dc.Op], dc.Text, dc.Rect.X, dc.Rect.Y, dc.Params, dc.Color)
+	case DrawOpCircle, DrawOpCircleLines, DrawOpCircleSector:
+		return fmt.Sprintf("%s center=(%v, %v) %v %v", drawOpNames[dc.Op], dc.Rect.X, dc.Rect.Y, dc.Params, dc.Color)
+	}
+	return fmt.Sprintf("%s %v %v %v", drawOpNames[dc.Op], dc.Rect, dc.Params, dc.Color)
+}
+
+func (dc DrawCommand) param(i int) float32 {
+	if i < len(dc.Params) {
+		return dc.Params[i]
+	}
+	return 0
+}
+
+func (rd *drReader) byte() byte {
+	n, v, err := xbinary.UnmarshalByte(rd.buf)
+	rd.next(n, err)
+	return v
+}
+
+func (rd *drReader) uint() uint {
+	n, v, err := xbinary.UnmarshalUint(rd.buf)
+	rd.next(n, err)
+	return v
+}
+
+func (rd *drReader) uint32() uint32 {
+	n, v, err := xbinary.UnmarshalUint32(rd.buf)
+	rd.next(n, err)
+	return v
+}
+
+func (rd *drReader) float32() float32 {
+	return math.Float32frombits(rd.uint32())
+}
+
+func (rd *drReader) string() string {
+	n, v, err := xbinary.UnmarshalString(rd.buf, true)
+	rd.next(n, err)
+	return v
+}
+
+func (rd *drReader) next(n int, err error) {
+	if rd.err == nil && err != nil {
+		rd.err = err
+	}
+	rd.buf = rd.buf[n:]
+}
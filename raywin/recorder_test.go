@@ -0,0 +1,123 @@
+package raywin
+
+// Copyright 2025 Dmitry Spasibenko
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+import (
+	"bytes"
+	"github.com/dspasibenko/raywin-go/pkg/golibs/errors"
+	rl "github.com/gen2brain/raylib-go/raylib"
+	"github.com/stretchr/testify/assert"
+	"strings"
+	"testing"
+)
+
+func TestRecordingProxy_RecordAndReplay(t *testing.T) {
+	var buf bytes.Buffer
+	sp := NewSoftProxy()
+	rp := NewRecordingProxy(sp, &buf)
+	cfg := DefaultDisplayConfig()
+	cfg.Width, cfg.Height = 64, 48
+	d := newDisplay(cfg, rp)
+	d.root.backgroundColor = rl.DarkBlue
+
+	b1 := &_softproxy_test_box{col: rl.Maroon}
+	assert.Nil(t, b1.Init(&d.root, b1))
+	b1.SetBounds(rl.RectangleInt32{X: 4, Y: 4, Width: 30, Height: 30})
+	b2 := &_softproxy_test_box{col: rl.DarkGreen}
+	assert.Nil(t, b2.Init(b1, b2))
+	b2.SetBounds(rl.RectangleInt32{X: 20, Y: 20, Width: 30, Height: 30})
+
+	d.formFrame(0) // not recorded
+	rp.RecordFrames(2)
+	for i := 1; i < 4; i++ {
+		d.formFrame(int64(i * 16))
+	}
+	assert.Nil(t, rp.Err())
+	assert.Equal(t, 4, sp.Frames())
+
+	frames, err := ReadDrawRecording(bytes.NewReader(buf.Bytes()))
+	assert.Nil(t, err)
+	assert.Equal(t, 2, len(frames))
+	assert.Equal(t, uint64(2), frames[0].Number)
+	assert.Equal(t, uint64(3), frames[1].Number)
+	assert.Equal(t, int32(64), frames[0].Width)
+	assert.Equal(t, int32(48), frames[0].Height)
+
+	var comps []string
+	for _, dc := range frames[0].Commands {
+		if dc.Op == DrawOpComponent {
+			comps = append(comps, dc.Path)
+		}
+		if dc.Op == DrawOpText {
+			assert.Equal(t, "ab", dc.Text)
+			assert.Equal(t, []float32{6, 1}, dc.Params)
+		}
+	}
+	assert.Equal(t, []string{"*raywin.rootContainer", "*raywin.rootContainer/*raywin._softproxy_test_box",
+		"*raywin.rootContainer/*raywin._softproxy_test_box/*raywin._softproxy_test_box"}, comps)
+	assert.Equal(t, DrawOpClear, frames[0].Commands[1].Op)
+
+	s := frames[0].String()
+	assert.True(t, strings.HasPrefix(s, "frame 2 (64x48)\n  component *raywin.rootContainer"))
+	assert.Contains(t, s, "\n      component *raywin._softproxy_test_box bounds={24 24 30 30} region=[24 24 10 10]\n")
+	assert.Contains(t, s, "scissor {24 24 10 10}")
+
+	rsp := newTestSoftProxy(64, 48)
+	frames[1].Replay(rsp)
+	assert.Equal(t, 0, ImageDiff(sp.Image(), rsp.Image(), 0))
+}
+
+func TestRecordingProxy_DrawTracerOnlyWhenRecording(t *testing.T) {
+	var buf bytes.Buffer
+	rp := NewRecordingProxy(&testProxy{}, &buf)
+	rp.Init(DefaultDisplayConfig())
+	rp.BeginDrawing()
+	rp.BeginComponent("c", rl.RectangleInt32{}, rl.RectangleInt32{})
+	rp.DrawCircle(rl.Vector2{}, 1, rl.Red)
+	rp.EndDrawing()
+	assert.Equal(t, 0, buf.Len())
+
+	rp.RecordFrames(-1)
+	for i := 0; i < 3; i++ {
+		rp.BeginDrawing()
+		rp.DrawTexture(rl.Texture2D{ID: 7, Width: 3, Height: 4}, Vector2Int32{X: 1, Y: 2}, rl.White)
+		rp.EndDrawing()
+	}
+	rp.StopRecording()
+	rp.BeginDrawing()
+	rp.EndDrawing()
+
+	frames, err := ReadDrawRecording(bytes.NewReader(buf.Bytes()))
+	assert.Nil(t, err)
+	assert.Equal(t, 3, len(frames))
+	assert.Equal(t, rl.Texture2D{ID: 7, Width: 3, Height: 4}, frames[2].Commands[0].Texture)
+	assert.Equal(t, "texture id=7 3x4 pos=(1, 2) {255 255 255 255}", frames[2].Commands[0].String())
+}
+
+func TestReadDrawRecording_Errors(t *testing.T) {
+	_, err := ReadDrawRecording(strings.NewReader("abc"))
+	assert.ErrorIs(t, err, errors.ErrInvalid)
+
+	var buf bytes.Buffer
+	rp := NewRecordingProxy(&testProxy{}, &buf)
+	rp.RecordFrames(1)
+	rp.BeginDrawing()
+	rp.DrawRectangle(rl.RectangleInt32{Width: 10, Height: 10}, rl.Red)
+	rp.EndDrawing()
+
+	frames, err := ReadDrawRecording(bytes.NewReader(buf.Bytes()[:buf.Len()-5]))
+	assert.ErrorIs(t, err, errors.ErrDataLoss)
+	assert.Equal(t, 1, len(frames))
+}
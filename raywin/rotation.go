@@ -0,0 +1,74 @@
+package raywin
+
+// Copyright 2025 Dmitry Spasibenko
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+import (
+	rl "github.com/gen2brain/raylib-go/raylib"
+)
+
+// Rotation is the clockwise angle in degrees, the picture is rotated by, when it is
+// presented on the physical display (see DisplayConfig.Rotation)
+type Rotation int
+
+const (
+	// Rotation0 presents the picture as is
+	Rotation0 = Rotation(0)
+	// Rotation90 rotates the picture clockwise, the logical top left corner is in the
+	// physical top right corner
+	Rotation90 = Rotation(90)
+	// Rotation180 turns the picture upside down
+	Rotation180 = Rotation(180)
+	// Rotation270 rotates the picture counterclockwise, the logical top left corner is
+	// in the physical bottom left corner
+	Rotation270 = Rotation(270)
+)
+
+// IsValid returns whether the rotation is one of Rotation0, Rotation90, Rotation180 or Rotation270
+func (r Rotation) IsValid() bool {
+	return r == Rotation0 || r == Rotation90 || r == Rotation180 || r == Rotation270
+}
+
+// LogicalSize returns the size of the display area for the components: the physical
+// Width and Height are swapped for Rotation90 and Rotation270
+func (dc DisplayConfig) LogicalSize() (uint32, uint32) {
+	if dc.Rotation == Rotation90 || dc.Rotation == Rotation270 {
+		return dc.Height, dc.Width
+	}
+	return dc.Width, dc.Height
+}
+
+// toLogical transforms the point p in the physical display coordinates to the logical ones
+func (dc DisplayConfig) toLogical(p rl.Vector2) rl.Vector2 {
+	w, h := float32(dc.Width), float32(dc.Height)
+	switch dc.Rotation {
+	case Rotation90:
+		return rl.Vector2{X: p.Y, Y: w - p.X}
+	case Rotation180:
+		return rl.Vector2{X: w - p.X, Y: h - p.Y}
+	case Rotation270:
+		return rl.Vector2{X: h - p.Y, Y: p.X}
+	}
+	return p
+}
+
+// present draws the render texture rt with the logical picture rotated to the physical display
+func (d *display) present() {
+	lw, lh := d.cfg.LogicalSize()
+	// the render texture is stored upside down, so it is flipped vertically
+	src := rl.Rectangle{Width: float32(lw), Height: -float32(lh)}
+	dst := rl.Rectangle{X: float32(d.cfg.Width) / 2, Y: float32(d.cfg.Height) / 2, Width: float32(lw), Height: float32(lh)}
+	origin := rl.Vector2{X: float32(lw) / 2, Y: float32(lh) / 2}
+	d.proxy.DrawTexturePro(d.rt.Texture, src, dst, origin, float32(d.cfg.Rotation), rl.White)
+}
@@ -0,0 +1,131 @@
+package raywin
+
+// Copyright 2025 Dmitry Spasibenko
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+import (
+	"github.com/dspasibenko/raywin-go/pkg/golibs/errors"
+	rl "github.com/gen2brain/raylib-go/raylib"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestDisplayConfig_toLogical(t *testing.T) {
+	dc := DisplayConfig{Width: 40, Height: 20}
+	p := rl.Vector2{X: 30, Y: 5}
+	for _, tc := range []struct {
+		rot    Rotation
+		w, h   uint32
+		expect rl.Vector2
+	}{
+		{Rotation0, 40, 20, rl.Vector2{X: 30, Y: 5}},
+		{Rotation90, 20, 40, rl.Vector2{X: 5, Y: 10}},
+		{Rotation180, 40, 20, rl.Vector2{X: 10, Y: 15}},
+		{Rotation270, 20, 40, rl.Vector2{X: 15, Y: 30}},
+	} {
+		dc.Rotation = tc.rot
+		w, h := dc.LogicalSize()
+		assert.Equal(t, tc.w, w, tc.rot)
+		assert.Equal(t, tc.h, h, tc.rot)
+		assert.Equal(t, tc.expect, dc.toLogical(p), tc.rot)
+		assert.True(t, tc.rot.IsValid())
+	}
+	assert.False(t, Rotation(45).IsValid())
+}
+
+type _rotation_test_comp struct {
+	BaseComponent
+	tps []TPState
+}
+
+func (rc *_rotation_test_comp) Draw(cc *CanvasContext) {
+	cc.DrawRectangle(rl.RectangleInt32{Width: 4, Height: 2}, rl.Red)
+}
+
+func (rc *_rotation_test_comp) OnTPState(tps TPState) OnTPSResult {
+	rc.tps = append(rc.tps, tps)
+	return OnTPSResultNA
+}
+
+func TestApp_rotation(t *testing.T) {
+	for _, tc := range []struct {
+		rot Rotation
+		// red and black are the physical pixels expected to be red and black
+		red, black []rl.Vector2
+	}{
+		{Rotation0, []rl.Vector2{{X: 0, Y: 0}, {X: 3, Y: 1}}, []rl.Vector2{{X: 4, Y: 0}, {X: 0, Y: 2}}},
+		{Rotation90, []rl.Vector2{{X: 39, Y: 0}, {X: 38, Y: 3}}, []rl.Vector2{{X: 37, Y: 0}, {X: 39, Y: 4}, {X: 0, Y: 0}}},
+		{Rotation180, []rl.Vector2{{X: 39, Y: 19}, {X: 36, Y: 18}}, []rl.Vector2{{X: 35, Y: 19}, {X: 39, Y: 17}, {X: 0, Y: 0}}},
+		{Rotation270, []rl.Vector2{{X: 0, Y: 19}, {X: 1, Y: 16}}, []rl.Vector2{{X: 2, Y: 19}, {X: 0, Y: 15}, {X: 39, Y: 0}}},
+	} {
+		cfg := DefaultConfig()
+		cfg.DisplayConfig.Width, cfg.DisplayConfig.Height = 40, 20
+		cfg.DisplayConfig.Rotation = tc.rot
+		sp := NewSoftProxy()
+		cfg.Proxy = sp
+		app, err := NewApp(cfg)
+		assert.Nil(t, err)
+		app.disp.root.backgroundColor = rl.Black
+		lw, lh := cfg.DisplayConfig.LogicalSize()
+		assert.Equal(t, rl.RectangleInt32{Width: int32(lw), Height: int32(lh)}, app.RootContainer().(Component).Bounds())
+
+		rc := &_rotation_test_comp{}
+		assert.Nil(t, rc.Init(app.RootContainer(), rc))
+		rc.SetBounds(rl.RectangleInt32{Width: 10, Height: 10})
+		assert.Nil(t, app.RunFrame(10))
+		img := sp.Image()
+		assert.Equal(t, 40, img.Rect.Dx())
+		for _, p := range tc.red {
+			assert.Equal(t, rl.Red, img.RGBAAt(int(p.X), int(p.Y)), "%d %v", tc.rot, p)
+		}
+		for _, p := range tc.black {
+			assert.Equal(t, rl.Black, img.RGBAAt(int(p.X), int(p.Y)), "%d %v", tc.rot, p)
+		}
+		assert.Nil(t, app.Close())
+		// the render texture is unloaded
+		assert.Equal(t, 0, len(sp.textures))
+	}
+
+	cfg := DefaultConfig()
+	cfg.Proxy = &testProxy{}
+	cfg.DisplayConfig.Rotation = 45
+	_, err := NewApp(cfg)
+	assert.ErrorIs(t, err, errors.ErrInvalid)
+}
+
+func TestApp_rotationTouch(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.DisplayConfig.Width, cfg.DisplayConfig.Height = 40, 20
+	cfg.DisplayConfig.Rotation = Rotation90
+	cfg.DisplayConfig.MinTouchTarget = 0
+	tp := &testProxy{}
+	cfg.Proxy = tp
+	app, err := NewApp(cfg)
+	assert.Nil(t, err)
+	rc := &_rotation_test_comp{}
+	assert.Nil(t, rc.Init(app.RootContainer(), rc))
+	// the component is in the logical bottom half, which is the physical left half
+	rc.SetBounds(rl.RectangleInt32{Y: 20, Width: 20, Height: 20})
+
+	tp.touches = []testTouch{{id: 1, pos: rl.Vector2{X: 30, Y: 5}}}
+	assert.Nil(t, app.RunFrame(10))
+	assert.Nil(t, rc.tps)
+
+	tp.touches = nil
+	assert.Nil(t, app.RunFrame(20))
+	tp.touches = []testTouch{{id: 2, pos: rl.Vector2{X: 10, Y: 5}}}
+	assert.Nil(t, app.RunFrame(30))
+	assert.Equal(t, 1, len(rc.tps))
+	assert.Equal(t, rl.Vector2{X: 5, Y: 30}, rc.tps[0].Pos)
+}
@@ -82,12 +82,17 @@ const (
 	ScrollVertical   = 2
 )
 
-// DefaultInternalScrollerDeceleration returns the default InternalScroller deceleration. The parameters
-// are adjusted for 60FPS and the 800x600 screen size. To decelerate faster, put lower (bigger absolute)
-// values
+// DefaultInternalScrollerDeceleration returns the default InternalScroller deceleration for
+// the display of the default App (see InertialScrollerDeceleration)
 func DefaultInternalScrollerDeceleration() rl.Vector2 {
-	assertInitialized()
-	fps := uint(c.disp.cfg.FPS)
+	return InertialScrollerDeceleration(displayConfig().FPS)
+}
+
+// InertialScrollerDeceleration returns the default InternalScroller deceleration for the display
+// with the fps frames per second. The parameters are adjusted for the 800x600 screen size. To
+// decelerate faster, put lower (bigger absolute) values
+func InertialScrollerDeceleration(fps int) rl.Vector2 {
+	fps = max(1, fps)
 	return rl.Vector2{X: -float32(8) / float32(fps), Y: -float32(8) / float32(fps)}
 }
 
@@ -98,7 +103,7 @@ func (s *InertialScroller) InitInertialScroller(owner Component, virtBounds rl.R
 	if owner == nil {
 		return fmt.Errorf("InitScroller: owner is nil: %w", errors.ErrInvalid)
 	}
-	fps := uint(c.disp.cfg.FPS)
+	fps := uint(AppOf(owner).displayConfig().FPS)
 	s.samples = container.NewRingBuffer[rl.Vector2](fps / 3)
 	s.flags = flags
 	s.decel = decel
@@ -27,9 +27,9 @@ func TestDefaultInternalScrollerDeceleration(t *testing.T) {
 }
 
 func TestInertialScroller_InitScroller(t *testing.T) {
-	c = &controller{}
+	c = &App{}
 	defer func() {
-		c = &controller{}
+		c = &App{}
 	}()
 	assert.Nil(t, c.initConfig(DefaultConfig(), &testProxy{}))
 	var is InertialScroller
@@ -45,9 +45,9 @@ func TestInertialScroller_InitScroller(t *testing.T) {
 }
 
 func TestInertialScroller_OnTPState(t *testing.T) {
-	c = &controller{}
+	c = &App{}
 	defer func() {
-		c = &controller{}
+		c = &App{}
 	}()
 	assert.Nil(t, c.initConfig(DefaultConfig(), &testProxy{}))
 	var is InertialScroller
@@ -85,9 +85,9 @@ func TestInertialScroller_SetVirtualBounds(t *testing.T) {
 }
 
 func TestInertialScroller_OnNewFrame(t *testing.T) {
-	c = &controller{}
+	c = &App{}
 	defer func() {
-		c = &controller{}
+		c = &App{}
 	}()
 	assert.Nil(t, c.initConfig(DefaultConfig(), &testProxy{}))
 	var is InertialScroller
@@ -0,0 +1,612 @@
+package raywin
+
+// Copyright 2025 Dmitry Spasibenko
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+import (
+	"fmt"
+	"github.com/dspasibenko/raywin-go/pkg/golibs/errors"
+	rl "github.com/gen2brain/raylib-go/raylib"
+	"image"
+	"image/color"
+	"image/png"
+	"math"
+	"os"
+	"path/filepath"
+	"sync"
+	"unsafe"
+)
+
+// SoftProxy is the headless RlProxy implementation, which rasterizes the drawings into
+// an in-memory RGBA image instead of a window, so no GPU is needed. It may be provided
+// via Config.Proxy to render the frames in tests and compare them with the golden
+// images (see MatchGolden).
+//
+// SoftProxy supports the background clearing, textures, rectangles (filled, outlined and
+// rounded), circles, circle sectors, text and the scissor regions. The colors are alpha-blended, and no anti-aliasing is applied.
+// The fonts are not rasterized: every glyph, except the space, is drawn as a filled box
+// of 1/2 of the font size width and 2/3 of the font size height, and the text is measured
+// accordingly. This is good enough to catch the text position, color and length changes.
+//
+// SoftProxy provides no input, so the touchpad is always released and no keys are pressed.
+type SoftProxy struct {
+	lock     sync.Mutex
+	canvas   *image.RGBA
+	scissor  image.Rectangle
+	textures map[uint32]*image.RGBA
+	lastTx   uint32
+	// screen is the display canvas, while the canvas is a render texture (see BeginTextureMode)
+	screen *image.RGBA
+	// targets contains the IDs of the render textures, they are stored upside down as
+	// OpenGL does, so they must be drawn flipped vertically
+	targets map[uint32]bool
+	frames  int
+	closed  bool
+	// eventWaiting is the last value set by SetEventWaiting, wakeUps counts WakeUp() calls
+	eventWaiting bool
+	wakeUps      int
+}
+
+// SoftProxyUpdateGoldenEnv is the environment variable, which makes MatchGolden write
+// the golden files instead of comparing the images with them, if it is set
+const SoftProxyUpdateGoldenEnv = "RAYWIN_UPDATE_GOLDEN"
+
+var _ RlProxy = (*SoftProxy)(nil)
+
+// NewSoftProxy returns the new SoftProxy. The canvas is allocated by Init() with the
+// DisplayConfig dimensions
+func NewSoftProxy() *SoftProxy {
+	return &SoftProxy{textures: map[uint32]*image.RGBA{}, targets: map[uint32]bool{}}
+}
+
+// Image returns the copy of the canvas with everything drawn so far
+func (sp *SoftProxy) Image() *image.RGBA {
+	sp.lock.Lock()
+	defer sp.lock.Unlock()
+	if sp.canvas == nil {
+		return image.NewRGBA(image.Rectangle{})
+	}
+	res := image.NewRGBA(sp.canvas.Rect)
+	copy(res.Pix, sp.canvas.Pix)
+	return res
+}
+
+// Frames returns the number of the frames rendered (EndDrawing() calls)
+func (sp *SoftProxy) Frames() int {
+	sp.lock.Lock()
+	defer sp.lock.Unlock()
+	return sp.frames
+}
+
+// Init implements RlProxy
+func (sp *SoftProxy) Init(cfg DisplayConfig) {
+	sp.lock.Lock()
+	defer sp.lock.Unlock()
+	sp.canvas = image.NewRGBA(image.Rect(0, 0, int(cfg.Width), int(cfg.Height)))
+	sp.scissor = sp.canvas.Rect
+	sp.closed = false
+}
+
+// CloseWindow implements RlProxy
+func (sp *SoftProxy) CloseWindow() {
+	sp.lock.Lock()
+	defer sp.lock.Unlock()
+	sp.closed = true
+}
+
+// WindowShouldClose implements RlProxy
+func (sp *SoftProxy) WindowShouldClose() bool {
+	sp.lock.Lock()
+	defer sp.lock.Unlock()
+	return sp.closed
+}
+
+// BeginDrawing implements RlProxy
+func (sp *SoftProxy) BeginDrawing() {
+	sp.lock.Lock()
+	defer sp.lock.Unlock()
+	sp.scissor = sp.bounds()
+}
+
+// EndDrawing implements RlProxy
+func (sp *SoftProxy) EndDrawing() {
+	sp.lock.Lock()
+	defer sp.lock.Unlock()
+	sp.frames++
+}
+
+// SetEventWaiting implements RlProxy, EndDrawing() never waits for the events actually
+func (sp *SoftProxy) SetEventWaiting(enabled bool) {
+	sp.lock.Lock()
+	defer sp.lock.Unlock()
+	sp.eventWaiting = enabled
+}
+
+// WakeUp implements RlProxy
+func (sp *SoftProxy) WakeUp() {
+	sp.lock.Lock()
+	defer sp.lock.Unlock()
+	sp.wakeUps++
+}
+
+// BeginScissorMode implements RlProxy
+func (sp *SoftProxy) BeginScissorMode(r rl.RectangleInt32) {
+	sp.lock.Lock()
+	defer sp.lock.Unlock()
+	sp.scissor = image.Rect(int(r.X), int(r.Y), int(r.X+r.Width), int(r.Y+r.Height)).Intersect(sp.bounds())
+}
+
+// EndScissorMode implements RlProxy
+func (sp *SoftProxy) EndScissorMode() {
+	sp.lock.Lock()
+	defer sp.lock.Unlock()
+	sp.scissor = sp.bounds()
+}
+
+// ClearBackground implements RlProxy, the scissor region is cleared only
+func (sp *SoftProxy) ClearBackground(col rl.Color) {
+	sp.lock.Lock()
+	defer sp.lock.Unlock()
+	for y := sp.scissor.Min.Y; y < sp.scissor.Max.Y; y++ {
+		for x := sp.scissor.Min.X; x < sp.scissor.Max.X; x++ {
+			sp.canvas.SetRGBA(x, y, col)
+		}
+	}
+}
+
+// DrawTexture implements RlProxy, the texture colors are multiplied by the color (tint)
+func (sp *SoftProxy) DrawTexture(texture rl.Texture2D, pos Vector2Int32, col rl.Color) {
+	sp.lock.Lock()
+	defer sp.lock.Unlock()
+	img, ok := sp.textures[texture.ID]
+	if !ok {
+		return
+	}
+	r := img.Rect.Add(image.Pt(int(pos.X), int(pos.Y))).Intersect(sp.scissor)
+	for y := r.Min.Y; y < r.Max.Y; y++ {
+		for x := r.Min.X; x < r.Max.X; x++ {
+			sp.blend(x, y, tint(img.RGBAAt(x-int(pos.X), y-int(pos.Y)), col))
+		}
+	}
+}
+
+// DrawRectangle implements RlProxy
+func (sp *SoftProxy) DrawRectangle(r rl.RectangleInt32, col rl.Color) {
+	sp.lock.Lock()
+	defer sp.lock.Unlock()
+	sp.fill(image.Rect(int(r.X), int(r.Y), int(r.X+r.Width), int(r.Y+r.Height)), col)
+}
+
+// DrawRectangleRec implements RlProxy
+func (sp *SoftProxy) DrawRectangleRec(r rl.Rectangle, col rl.Color) {
+	sp.lock.Lock()
+	defer sp.lock.Unlock()
+	sp.fillFunc(r, col, func(x, y float32) bool {
+		return inRect(x, y, r)
+	})
+}
+
+// DrawRectangleLines implements RlProxy, the lines are drawn inside r
+func (sp *SoftProxy) DrawRectangleLines(r rl.Rectangle, lineThick float32, col rl.Color) {
+	sp.lock.Lock()
+	defer sp.lock.Unlock()
+	in := shrinkRect(r, lineThick)
+	sp.fillFunc(r, col, func(x, y float32) bool {
+		return inRect(x, y, r) && !inRect(x, y, in)
+	})
+}
+
+// DrawRectangleRounded implements RlProxy, the corners radius is roundness * (the shortest side) / 2
+func (sp *SoftProxy) DrawRectangleRounded(r rl.Rectangle, roundness float32, segments int32, col rl.Color) {
+	sp.lock.Lock()
+	defer sp.lock.Unlock()
+	rad := cornerRadius(r, roundness)
+	sp.fillFunc(r, col, func(x, y float32) bool {
+		return inRoundedRect(x, y, r, rad)
+	})
+}
+
+// DrawRectangleRoundedLines implements RlProxy, the lines are drawn outside r as raylib does
+func (sp *SoftProxy) DrawRectangleRoundedLines(r rl.Rectangle, roundness float32, segments int32, lineThick float32, col rl.Color) {
+	sp.lock.Lock()
+	defer sp.lock.Unlock()
+	rad := cornerRadius(r, roundness)
+	out := shrinkRect(r, -lineThick)
+	sp.fillFunc(out, col, func(x, y float32) bool {
+		return inRoundedRect(x, y, out, rad+lineThick) && !inRoundedRect(x, y, r, rad)
+	})
+}
+
+// DrawCircle implements RlProxy, the pixel is filled if its center is within the circle
+func (sp *SoftProxy) DrawCircle(center rl.Vector2, radius float32, col rl.Color) {
+	sp.lock.Lock()
+	defer sp.lock.Unlock()
+	sp.fillFunc(circleRect(center, radius), col, func(x, y float32) bool {
+		return distance(rl.Vector2{X: x, Y: y}, center) <= radius
+	})
+}
+
+// DrawCircleLines implements RlProxy, the circle line is 1 pixel thick
+func (sp *SoftProxy) DrawCircleLines(center rl.Vector2, radius float32, col rl.Color) {
+	sp.lock.Lock()
+	defer sp.lock.Unlock()
+	sp.fillFunc(circleRect(center, radius+1), col, func(x, y float32) bool {
+		d := distance(rl.Vector2{X: x, Y: y}, center)
+		return d <= radius+0.5 && d >= radius-0.5
+	})
+}
+
+// DrawCircleSector implements RlProxy, the angles are in degrees clockwise from the X axis
+func (sp *SoftProxy) DrawCircleSector(center rl.Vector2, radius, startAngle, endAngle float32, segments int32, col rl.Color) {
+	sp.lock.Lock()
+	defer sp.lock.Unlock()
+	if endAngle < startAngle {
+		startAngle, endAngle = endAngle, startAngle
+	}
+	span := endAngle - startAngle
+	sp.fillFunc(circleRect(center, radius), col, func(x, y float32) bool {
+		if distance(rl.Vector2{X: x, Y: y}, center) > radius {
+			return false
+		}
+		a := float32(math.Atan2(float64(y-center.Y), float64(x-center.X)) * 180 / math.Pi)
+		return span >= 360 || float32(math.Mod(float64(a-startAngle)+720, 360)) <= span
+	})
+}
+
+// DrawText implements RlProxy, the glyphs are drawn as the boxes (see SoftProxy)
+func (sp *SoftProxy) DrawText(font rl.Font, text string, pos rl.Vector2, fontSize, spacing float32, col rl.Color) {
+	sp.lock.Lock()
+	defer sp.lock.Unlock()
+	w, h := fontSize/2, fontSize*2/3
+	x := pos.X
+	for _, r := range text {
+		if r != ' ' {
+			sp.fill(image.Rect(int(x), int(pos.Y+fontSize-h), int(x+w), int(pos.Y+fontSize)), col)
+		}
+		x += w + spacing
+	}
+}
+
+// MeasureText implements RlProxy
+func (sp *SoftProxy) MeasureText(font rl.Font, text string, fontSize, spacing float32) rl.Vector2 {
+	return softMeasureText(text, fontSize, spacing)
+}
+
+// IsMouseButtonDown implements RlProxy
+func (sp *SoftProxy) IsMouseButtonDown(mb rl.MouseButton) bool {
+	return false
+}
+
+// GetMouseDelta implements RlProxy
+func (sp *SoftProxy) GetMouseDelta() rl.Vector2 {
+	return rl.Vector2{}
+}
+
+// GetMousePosition implements RlProxy
+func (sp *SoftProxy) GetMousePosition() rl.Vector2 {
+	return rl.Vector2{}
+}
+
+// GetTouchPointCount implements RlProxy
+func (sp *SoftProxy) GetTouchPointCount() int32 {
+	return 0
+}
+
+// GetTouchPointId implements RlProxy
+func (sp *SoftProxy) GetTouchPointId(index int32) int32 {
+	return 0
+}
+
+// GetTouchPosition implements RlProxy
+func (sp *SoftProxy) GetTouchPosition(index int32) rl.Vector2 {
+	return rl.Vector2{}
+}
+
+// GetKeyPressed implements RlProxy
+func (sp *SoftProxy) GetKeyPressed() int32 {
+	return 0
+}
+
+// GetCharPressed implements RlProxy
+func (sp *SoftProxy) GetCharPressed() int32 {
+	return 0
+}
+
+// IsKeyDown implements RlProxy
+func (sp *SoftProxy) IsKeyDown(key int32) bool {
+	return false
+}
+
+// IsKeyPressedRepeat implements RlProxy
+func (sp *SoftProxy) IsKeyPressedRepeat(key int32) bool {
+	return false
+}
+
+// LoadTextureFromImage implements RlProxy, the image pixels are copied to the texture
+func (sp *SoftProxy) LoadTextureFromImage(img *rl.Image) rl.Texture2D {
+	if img == nil {
+		return rl.Texture2D{}
+	}
+	rgba := imageToRGBA(img)
+	sp.lock.Lock()
+	defer sp.lock.Unlock()
+	sp.lastTx++
+	sp.textures[sp.lastTx] = rgba
+	return rl.NewTexture2D(sp.lastTx, img.Width, img.Height, 1, rl.UncompressedR8g8b8a8)
+}
+
+// LoadFontEx implements RlProxy, no font is loaded actually (see SoftProxy)
+func (sp *SoftProxy) LoadFontEx(fileName string, fontSize int32) rl.Font {
+	return rl.Font{BaseSize: fontSize}
+}
+
+// UnloadTexture implements RlProxy, the texture pixels are released
+func (sp *SoftProxy) UnloadTexture(texture rl.Texture2D) {
+	sp.lock.Lock()
+	defer sp.lock.Unlock()
+	delete(sp.textures, texture.ID)
+}
+
+// UnloadFont implements RlProxy
+func (sp *SoftProxy) UnloadFont(font rl.Font) {}
+
+// LoadRenderTexture implements RlProxy
+func (sp *SoftProxy) LoadRenderTexture(width, height int32) rl.RenderTexture2D {
+	sp.lock.Lock()
+	defer sp.lock.Unlock()
+	sp.lastTx++
+	sp.textures[sp.lastTx] = image.NewRGBA(image.Rect(0, 0, int(width), int(height)))
+	sp.targets[sp.lastTx] = true
+	return rl.RenderTexture2D{ID: sp.lastTx, Texture: rl.NewTexture2D(sp.lastTx, width, height, 1, rl.UncompressedR8g8b8a8)}
+}
+
+// UnloadRenderTexture implements RlProxy
+func (sp *SoftProxy) UnloadRenderTexture(target rl.RenderTexture2D) {
+	sp.lock.Lock()
+	defer sp.lock.Unlock()
+	delete(sp.textures, target.Texture.ID)
+	delete(sp.targets, target.Texture.ID)
+}
+
+// BeginTextureMode implements RlProxy, everything is drawn into the target until
+// EndTextureMode() is called
+func (sp *SoftProxy) BeginTextureMode(target rl.RenderTexture2D) {
+	sp.lock.Lock()
+	defer sp.lock.Unlock()
+	img, ok := sp.textures[target.Texture.ID]
+	if !ok {
+		return
+	}
+	if sp.screen == nil {
+		sp.screen = sp.canvas
+	}
+	sp.canvas = img
+	sp.scissor = sp.bounds()
+}
+
+// EndTextureMode implements RlProxy
+func (sp *SoftProxy) EndTextureMode() {
+	sp.lock.Lock()
+	defer sp.lock.Unlock()
+	if sp.screen != nil {
+		sp.canvas = sp.screen
+		sp.screen = nil
+	}
+	sp.scissor = sp.bounds()
+}
+
+// DrawTexturePro implements RlProxy. The src part of the texture is scaled to the dst
+// rectangle, which is rotated by rotation degrees clockwise around the origin (relative
+// to dst), the negative src width or height flips the texture. The pixels are sampled
+// by the nearest neighbor.
+func (sp *SoftProxy) DrawTexturePro(texture rl.Texture2D, src, dst rl.Rectangle, origin rl.Vector2, rotation float32, col rl.Color) {
+	sp.lock.Lock()
+	defer sp.lock.Unlock()
+	img, ok := sp.textures[texture.ID]
+	if !ok || dst.Width <= 0 || dst.Height <= 0 {
+		return
+	}
+	sw, sh := float32(math.Abs(float64(src.Width))), float32(math.Abs(float64(src.Height)))
+	sin, cos := math.Sincos(float64(rotation) * math.Pi / 180)
+	for y := sp.scissor.Min.Y; y < sp.scissor.Max.Y; y++ {
+		for x := sp.scissor.Min.X; x < sp.scissor.Max.X; x++ {
+			// the pixel center in dst coordinates, rotated back
+			px, py := float64(x)+0.5-float64(dst.X), float64(y)+0.5-float64(dst.Y)
+			u := float32(px*cos+py*sin) + origin.X
+			v := float32(-px*sin+py*cos) + origin.Y
+			if u < 0 || v < 0 || u >= dst.Width || v >= dst.Height {
+				continue
+			}
+			tu, tv := u*sw/dst.Width, v*sh/dst.Height
+			if src.Width < 0 {
+				tu = sw - tu
+			}
+			if src.Height < 0 {
+				tv = sh - tv
+			}
+			tx, ty := int(src.X+tu), int(src.Y+tv)
+			if sp.targets[texture.ID] {
+				// the render texture rows are stored upside down
+				ty = img.Rect.Dy() - 1 - ty
+			}
+			if !(image.Point{X: tx, Y: ty}).In(img.Rect) {
+				continue
+			}
+			sp.blend(x, y, tint(img.RGBAAt(tx, ty), col))
+		}
+	}
+}
+
+// SetTextureFilter implements RlProxy
+func (sp *SoftProxy) SetTextureFilter(texture rl.Texture2D, filterMode rl.TextureFilterMode) {}
+
+func (sp *SoftProxy) bounds() image.Rectangle {
+	if sp.canvas == nil {
+		return image.Rectangle{}
+	}
+	return sp.canvas.Rect
+}
+
+func (sp *SoftProxy) fill(r image.Rectangle, col rl.Color) {
+	r = r.Intersect(sp.scissor)
+	for y := r.Min.Y; y < r.Max.Y; y++ {
+		for x := r.Min.X; x < r.Max.X; x++ {
+			sp.blend(x, y, col)
+		}
+	}
+}
+
+// fillFunc fills the pixels within the bounds b, which centers are inside the figure
+func (sp *SoftProxy) fillFunc(b rl.Rectangle, col rl.Color, inside func(x, y float32) bool) {
+	r := image.Rect(int(math.Floor(float64(b.X))), int(math.Floor(float64(b.Y))),
+		int(math.Ceil(float64(b.X+b.Width))), int(math.Ceil(float64(b.Y+b.Height)))).Intersect(sp.scissor)
+	for y := r.Min.Y; y < r.Max.Y; y++ {
+		for x := r.Min.X; x < r.Max.X; x++ {
+			if inside(float32(x)+0.5, float32(y)+0.5) {
+				sp.blend(x, y, col)
+			}
+		}
+	}
+}
+
+// blend draws the color col over the canvas pixel (x, y)
+func (sp *SoftProxy) blend(x, y int, col rl.Color) {
+	if col.A == 255 {
+		sp.canvas.SetRGBA(x, y, col)
+		return
+	}
+	dst := sp.canvas.RGBAAt(x, y)
+	a := uint32(col.A)
+	mix := func(s, d uint8) uint8 {
+		return uint8((uint32(s)*a + uint32(d)*(255-a) + 127) / 255)
+	}
+	sp.canvas.SetRGBA(x, y, color.RGBA{R: mix(col.R, dst.R), G: mix(col.G, dst.G), B: mix(col.B, dst.B),
+		A: uint8(a + (uint32(dst.A)*(255-a)+127)/255)})
+}
+
+func tint(c, t rl.Color) rl.Color {
+	return rl.Color{R: uint8(uint32(c.R) * uint32(t.R) / 255), G: uint8(uint32(c.G) * uint32(t.G) / 255),
+		B: uint8(uint32(c.B) * uint32(t.B) / 255), A: uint8(uint32(c.A) * uint32(t.A) / 255)}
+}
+
+func inRect(x, y float32, r rl.Rectangle) bool {
+	return x >= r.X && x < r.X+r.Width && y >= r.Y && y < r.Y+r.Height
+}
+
+// inRoundedRect returns whether the point (x, y) is inside the rectangle r with the corners
+// of the radius rad
+func inRoundedRect(x, y float32, r rl.Rectangle, rad float32) bool {
+	if !inRect(x, y, r) {
+		return false
+	}
+	cx := min(max(x, r.X+rad), r.X+r.Width-rad)
+	cy := min(max(y, r.Y+rad), r.Y+r.Height-rad)
+	return distance(rl.Vector2{X: x, Y: y}, rl.Vector2{X: cx, Y: cy}) <= rad
+}
+
+func shrinkRect(r rl.Rectangle, d float32) rl.Rectangle {
+	return rl.Rectangle{X: r.X + d, Y: r.Y + d, Width: r.Width - 2*d, Height: r.Height - 2*d}
+}
+
+func cornerRadius(r rl.Rectangle, roundness float32) float32 {
+	return min(max(roundness, 0), 1) * min(r.Width, r.Height) / 2
+}
+
+func circleRect(center rl.Vector2, radius float32) rl.Rectangle {
+	return rl.Rectangle{X: center.X - radius, Y: center.Y - radius, Width: 2 * radius, Height: 2 * radius}
+}
+
+func softMeasureText(text string, fontSize, spacing float32) rl.Vector2 {
+	n := float32(len([]rune(text)))
+	if n == 0 {
+		return rl.Vector2{Y: fontSize}
+	}
+	return rl.Vector2{X: n*fontSize/2 + (n-1)*spacing, Y: fontSize}
+}
+
+// imageToRGBA converts the raylib image to image.RGBA, the R8G8B8A8 images are copied
+// directly, the other formats are converted by raylib
+func imageToRGBA(img *rl.Image) *image.RGBA {
+	res := image.NewRGBA(image.Rect(0, 0, int(img.Width), int(img.Height)))
+	if img.Format == rl.UncompressedR8g8b8a8 && img.Data != nil {
+		copy(res.Pix, unsafe.Slice((*uint8)(img.Data), len(res.Pix)))
+		return res
+	}
+	src := img.ToImage()
+	for y := 0; y < res.Rect.Dy(); y++ {
+		for x := 0; x < res.Rect.Dx(); x++ {
+			res.Set(x, y, src.At(x, y))
+		}
+	}
+	return res
+}
+
+// ImageDiff returns the number of pixels of the images a and b, which differ in any color
+// channel by more than tolerance. The images of different sizes differ in all pixels.
+func ImageDiff(a, b image.Image, tolerance uint8) int {
+	ra, rb := a.Bounds(), b.Bounds()
+	if ra.Size() != rb.Size() {
+		return max(ra.Dx()*ra.Dy(), rb.Dx()*rb.Dy())
+	}
+	res := 0
+	for y := 0; y < ra.Dy(); y++ {
+		for x := 0; x < ra.Dx(); x++ {
+			ca := color.RGBAModel.Convert(a.At(ra.Min.X+x, ra.Min.Y+y)).(color.RGBA)
+			cb := color.RGBAModel.Convert(b.At(rb.Min.X+x, rb.Min.Y+y)).(color.RGBA)
+			if channelDiff(ca.R, cb.R) > tolerance || channelDiff(ca.G, cb.G) > tolerance ||
+				channelDiff(ca.B, cb.B) > tolerance || channelDiff(ca.A, cb.A) > tolerance {
+				res++
+			}
+		}
+	}
+	return res
+}
+
+// MatchGolden compares img with the golden PNG image stored in fileName. The pixels which
+// differ by tolerance or less in every channel are considered equal, and no more than maxDiff
+// different pixels are allowed. If the SoftProxyUpdateGoldenEnv environment variable is set,
+// the golden file is written by img instead of the comparison.
+func MatchGolden(img image.Image, fileName string, tolerance uint8, maxDiff int) error {
+	if os.Getenv(SoftProxyUpdateGoldenEnv) != "" {
+		if err := os.MkdirAll(filepath.Dir(fileName), 0755); err != nil {
+			return err
+		}
+		f, err := os.Create(fileName)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		return png.Encode(f, img)
+	}
+	f, err := os.Open(fileName)
+	if err != nil {
+		return fmt.Errorf("could not open the golden file (set %s=1 to create it): %w", SoftProxyUpdateGoldenEnv, err)
+	}
+	defer f.Close()
+	golden, err := png.Decode(f)
+	if err != nil {
+		return fmt.Errorf("could not decode the golden file %s: %w", fileName, err)
+	}
+	if diff := ImageDiff(img, golden, tolerance); diff > maxDiff {
+		return fmt.Errorf("the image differs from the golden %s in %d pixels (%d allowed): %w", fileName, diff, maxDiff, errors.ErrConflict)
+	}
+	return nil
+}
+
+func channelDiff(a, b uint8) uint8 {
+	if a > b {
+		return a - b
+	}
+	return b - a
+}
@@ -0,0 +1,181 @@
+package raywin
+
+// Copyright 2025 Dmitry Spasibenko
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+import (
+	rl "github.com/gen2brain/raylib-go/raylib"
+	"github.com/stretchr/testify/assert"
+	"image"
+	"image/color"
+	"path/filepath"
+	"testing"
+)
+
+func newTestSoftProxy(w, h uint32) *SoftProxy {
+	sp := NewSoftProxy()
+	cfg := DefaultDisplayConfig()
+	cfg.Width, cfg.Height = w, h
+	sp.Init(cfg)
+	return sp
+}
+
+func TestSoftProxy_Primitives(t *testing.T) {
+	sp := newTestSoftProxy(10, 10)
+	sp.BeginDrawing()
+	sp.ClearBackground(rl.Black)
+	sp.DrawRectangle(rl.RectangleInt32{X: 1, Y: 1, Width: 2, Height: 2}, rl.Red)
+	sp.DrawRectangle(rl.RectangleInt32{X: 2, Y: 2, Width: 2, Height: 2}, rl.Color{R: 255, G: 255, B: 255, A: 128})
+	sp.DrawCircle(rl.Vector2{X: 7, Y: 7}, 1, rl.Blue)
+	sp.EndDrawing()
+	assert.Equal(t, 1, sp.Frames())
+
+	img := sp.Image()
+	assert.Equal(t, color.RGBA{0, 0, 0, 255}, img.RGBAAt(0, 0))
+	assert.Equal(t, color.RGBA{230, 41, 55, 255}, img.RGBAAt(1, 1))
+	assert.Equal(t, color.RGBA{243, 148, 155, 255}, img.RGBAAt(2, 2))
+	assert.Equal(t, color.RGBA{128, 128, 128, 255}, img.RGBAAt(3, 3))
+	assert.Equal(t, rl.Blue, img.RGBAAt(6, 6))
+	assert.Equal(t, rl.Blue, img.RGBAAt(7, 7))
+	assert.Equal(t, color.RGBA{0, 0, 0, 255}, img.RGBAAt(8, 8))
+	assert.Equal(t, color.RGBA{0, 0, 0, 255}, img.RGBAAt(5, 5))
+}
+
+func TestSoftProxy_Scissor(t *testing.T) {
+	sp := newTestSoftProxy(10, 10)
+	sp.BeginScissorMode(rl.RectangleInt32{X: 2, Y: 2, Width: 3, Height: 3})
+	sp.ClearBackground(rl.White)
+	sp.DrawRectangle(rl.RectangleInt32{X: 0, Y: 0, Width: 10, Height: 10}, rl.Red)
+	sp.EndScissorMode()
+	sp.DrawCircle(rl.Vector2{X: 0, Y: 0}, 1, rl.Green)
+
+	img := sp.Image()
+	assert.Equal(t, rl.Red, img.RGBAAt(2, 2))
+	assert.Equal(t, rl.Red, img.RGBAAt(4, 4))
+	assert.Equal(t, color.RGBA{}, img.RGBAAt(5, 5))
+	assert.Equal(t, color.RGBA{}, img.RGBAAt(1, 2))
+	assert.Equal(t, rl.Green, img.RGBAAt(0, 0))
+}
+
+func TestSoftProxy_TextureAndText(t *testing.T) {
+	sp := newTestSoftProxy(20, 20)
+	data := []byte{255, 255, 255, 255, 0, 0, 0, 0, 10, 20, 30, 255, 100, 100, 100, 255}
+	tx := sp.LoadTextureFromImage(rl.NewImage(data, 2, 2, 1, rl.UncompressedR8g8b8a8))
+	assert.Equal(t, int32(2), tx.Width)
+	sp.DrawTexture(tx, Vector2Int32{X: 1, Y: 1}, rl.Color{R: 255, G: 0, B: 255, A: 255})
+	sp.DrawTexture(rl.Texture2D{ID: 100}, Vector2Int32{}, rl.White) // unknown texture
+
+	img := sp.Image()
+	assert.Equal(t, color.RGBA{255, 0, 255, 255}, img.RGBAAt(1, 1))
+	assert.Equal(t, color.RGBA{}, img.RGBAAt(2, 1))
+	assert.Equal(t, color.RGBA{10, 0, 30, 255}, img.RGBAAt(1, 2))
+	assert.Equal(t, color.RGBA{}, img.RGBAAt(0, 0))
+
+	assert.Equal(t, rl.Vector2{X: 13, Y: 6}, sp.MeasureText(rl.Font{}, "a b", 6, 2))
+	assert.Equal(t, rl.Vector2{Y: 6}, sp.MeasureText(rl.Font{}, "", 6, 2))
+	sp.DrawText(rl.Font{}, "a b", rl.Vector2{X: 0, Y: 10}, 6, 2, rl.Red)
+	img = sp.Image()
+	assert.Equal(t, rl.Red, img.RGBAAt(0, 15))
+	assert.Equal(t, rl.Red, img.RGBAAt(2, 12))
+	assert.Equal(t, color.RGBA{}, img.RGBAAt(0, 11))
+	assert.Equal(t, color.RGBA{}, img.RGBAAt(6, 15))
+	assert.Equal(t, rl.Red, img.RGBAAt(10, 15))
+}
+
+func TestSoftProxy_Shapes(t *testing.T) {
+	sp := newTestSoftProxy(20, 20)
+	sp.DrawRectangleRounded(rl.Rectangle{X: 0, Y: 0, Width: 10, Height: 10}, 1, 5, rl.Red)
+	sp.DrawRectangleLines(rl.Rectangle{X: 10, Y: 10, Width: 10, Height: 10}, 2, rl.Blue)
+	sp.DrawCircleSector(rl.Vector2{X: 15, Y: 5}, 5, 0, 90, 5, rl.Green)
+	sp.DrawCircleLines(rl.Vector2{X: 5, Y: 15}, 4, rl.White)
+
+	img := sp.Image()
+	assert.Equal(t, rl.Red, img.RGBAAt(5, 5))
+	assert.Equal(t, rl.Red, img.RGBAAt(5, 0))
+	assert.Equal(t, color.RGBA{}, img.RGBAAt(0, 0))
+	assert.Equal(t, rl.Blue, img.RGBAAt(11, 15))
+	assert.Equal(t, color.RGBA{}, img.RGBAAt(15, 15))
+	// the sector is below the X axis to the right of the center
+	assert.Equal(t, rl.Green, img.RGBAAt(17, 7))
+	assert.Equal(t, color.RGBA{}, img.RGBAAt(13, 7))
+	assert.Equal(t, color.RGBA{}, img.RGBAAt(17, 3))
+	assert.Equal(t, rl.White, img.RGBAAt(5, 11))
+	assert.Equal(t, color.RGBA{}, img.RGBAAt(5, 15))
+}
+
+func TestImageDiff(t *testing.T) {
+	a := image.NewRGBA(image.Rect(0, 0, 2, 2))
+	b := image.NewRGBA(image.Rect(0, 0, 2, 2))
+	assert.Equal(t, 0, ImageDiff(a, b, 0))
+	b.SetRGBA(1, 1, color.RGBA{R: 3})
+	assert.Equal(t, 1, ImageDiff(a, b, 2))
+	assert.Equal(t, 0, ImageDiff(a, b, 3))
+	assert.Equal(t, 6, ImageDiff(a, image.NewRGBA(image.Rect(0, 0, 3, 2)), 0))
+}
+
+func TestMatchGolden(t *testing.T) {
+	img := image.NewRGBA(image.Rect(0, 0, 2, 2))
+	img.SetRGBA(0, 0, rl.Red)
+	fn := filepath.Join(t.TempDir(), "img.png")
+	t.Setenv(SoftProxyUpdateGoldenEnv, "")
+	assert.NotNil(t, MatchGolden(img, fn, 0, 0))
+
+	t.Setenv(SoftProxyUpdateGoldenEnv, "1")
+	assert.Nil(t, MatchGolden(img, fn, 0, 0))
+	t.Setenv(SoftProxyUpdateGoldenEnv, "")
+	assert.Nil(t, MatchGolden(img, fn, 0, 0))
+	img.SetRGBA(1, 1, rl.Red)
+	assert.NotNil(t, MatchGolden(img, fn, 0, 0))
+	assert.Nil(t, MatchGolden(img, fn, 0, 1))
+}
+
+type _softproxy_test_box struct {
+	BaseContainer
+	col rl.Color
+}
+
+func (b *_softproxy_test_box) Draw(cc *CanvasContext) {
+	r := b.Bounds()
+	x, y := cc.PhysicalPointXY(0, 0)
+	cc.DrawRectangle(rl.RectangleInt32{X: x, Y: y, Width: r.Width, Height: r.Height}, b.col)
+	x, y = cc.PhysicalPointXY(r.Width/2, r.Height/2)
+	cc.DrawCircle(rl.Vector2{X: float32(x), Y: float32(y)}, 4, rl.Yellow)
+	x, y = cc.PhysicalPointXY(2, 2)
+	cc.DrawText(rl.Font{}, "ab", rl.Vector2{X: float32(x), Y: float32(y)}, 6, 1, rl.White)
+}
+
+func Test_display_softProxyGolden(t *testing.T) {
+	sp := NewSoftProxy()
+	cfg := DefaultDisplayConfig()
+	cfg.Width, cfg.Height = 64, 48
+	cfg.BackgroundColor = rl.DarkBlue
+	d := newDisplay(cfg, sp)
+	d.root.backgroundColor = cfg.BackgroundColor
+
+	b1 := &_softproxy_test_box{col: rl.Maroon}
+	assert.Nil(t, b1.Init(&d.root, b1))
+	b1.SetBounds(rl.RectangleInt32{X: 4, Y: 4, Width: 30, Height: 30})
+	b2 := &_softproxy_test_box{col: rl.DarkGreen}
+	assert.Nil(t, b2.Init(b1, b2))
+	b2.SetBounds(rl.RectangleInt32{X: 20, Y: 20, Width: 30, Height: 30}) // clipped by b1
+	b3 := &_softproxy_test_box{col: rl.Fade(rl.SkyBlue, 0.5)}
+	assert.Nil(t, b3.Init(&d.root, b3))
+	b3.SetBounds(rl.RectangleInt32{X: 30, Y: 10, Width: 30, Height: 20})
+
+	for i := 0; i < 3; i++ {
+		d.formFrame(int64(i * 16))
+	}
+	assert.Equal(t, 3, sp.Frames())
+	assert.Nil(t, MatchGolden(sp.Image(), "testdata/golden/softproxy_display.png", 0, 0))
+}
@@ -0,0 +1,265 @@
+package raywin
+
+// Copyright 2025 Dmitry Spasibenko
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+import (
+	"fmt"
+	"github.com/dspasibenko/raywin-go/pkg/golibs/errors"
+	"sync"
+	"sync/atomic"
+)
+
+type (
+	// Timer is the handle of the function scheduled by After, Every or OnIdle. The function
+	// is called by the drawing loop goroutine, so it may access the components directly.
+	// The timer may have the owner component, the timer is cancelled automatically when
+	// the owner is closed.
+	Timer struct {
+		owner     Component
+		f         func()
+		due       int64
+		period    int64
+		idle      bool
+		cancelled atomic.Bool
+		active    atomic.Bool
+	}
+
+	// scheduler runs the timers and notifies the frame listeners registered at runtime
+	scheduler struct {
+		lock      sync.Mutex
+		timers    []*Timer
+		listeners []FrameListener
+	}
+)
+
+// After calls the function f once in the default App, when ms milliseconds pass (see App.After)
+func After(owner Component, ms int64, f func()) (*Timer, error) {
+	return AppOf(owner).After(owner, ms, f)
+}
+
+// Every calls the function f every ms milliseconds in the default App (see App.Every)
+func Every(owner Component, ms int64, f func()) (*Timer, error) {
+	return AppOf(owner).Every(owner, ms, f)
+}
+
+// OnIdle calls the function f once, when the default App becomes idle (see App.OnIdle)
+func OnIdle(owner Component, f func()) (*Timer, error) {
+	return AppOf(owner).OnIdle(owner, f)
+}
+
+// AddFrameListener registers the frame listener fl for the default App (see App.AddFrameListener)
+func AddFrameListener(fl FrameListener) error {
+	return c.AddFrameListener(fl)
+}
+
+// RemoveFrameListener unregisters the frame listener fl of the default App
+// (see App.RemoveFrameListener)
+func RemoveFrameListener(fl FrameListener) bool {
+	return c.RemoveFrameListener(fl)
+}
+
+// After schedules the function f to be called once by the drawing loop goroutine in
+// the first frame, which millis are ms or more milliseconds later than the current
+// frame millis (see Millis). The owner may be nil, otherwise the timer is cancelled
+// when the owner is closed. The timers are run at the beginning of the frame, right after
+// the functions queued by Post.
+func (c *App) After(owner Component, ms int64, f func()) (*Timer, error) {
+	return c.schedule(&Timer{owner: owner, f: f, due: max(ms, 0)})
+}
+
+// Every schedules the function f to be called every ms milliseconds by the drawing loop
+// goroutine until the timer is cancelled. If the frames are longer than ms, f is called
+// once per frame, the missed calls are skipped. The owner may be nil, otherwise the timer
+// is cancelled when the owner is closed.
+func (c *App) Every(owner Component, ms int64, f func()) (*Timer, error) {
+	if ms <= 0 {
+		return nil, fmt.Errorf("the timer period must be positive, but it is %d: %w", ms, errors.ErrInvalid)
+	}
+	return c.schedule(&Timer{owner: owner, f: f, due: ms, period: ms})
+}
+
+// OnIdle schedules the function f to be called once by the drawing loop goroutine after
+// the first frame, in which the touchpad is not touched, no animations are played and
+// no functions are queued by Post. The function is called after the frame is drawn.
+// The owner may be nil, otherwise the timer is cancelled when the owner is closed.
+func (c *App) OnIdle(owner Component, f func()) (*Timer, error) {
+	return c.schedule(&Timer{owner: owner, f: f, idle: true})
+}
+
+// AddFrameListener registers the frame listener fl, which is called on every new frame
+// after Config.FrameListener in the order of the registration. The listener may be
+// registered or unregistered at any time from any goroutine, the change takes an effect
+// from the next frame.
+func (c *App) AddFrameListener(fl FrameListener) error {
+	if c.disp == nil {
+		return fmt.Errorf("raywin is not initialized, Init() must be called first: %w", errors.ErrInvalid)
+	}
+	if fl == nil {
+		return fmt.Errorf("the frame listener must not be nil: %w", errors.ErrInvalid)
+	}
+	return c.disp.sched.addListener(fl)
+}
+
+// RemoveFrameListener unregisters the frame listener fl. It returns false if the listener
+// is not registered.
+func (c *App) RemoveFrameListener(fl FrameListener) bool {
+	if c.disp == nil {
+		return false
+	}
+	return c.disp.sched.removeListener(fl)
+}
+
+func (c *App) schedule(t *Timer) (*Timer, error) {
+	if c.disp == nil {
+		return nil, fmt.Errorf("raywin is not initialized, Init() must be called first: %w", errors.ErrInvalid)
+	}
+	if t.f == nil {
+		return nil, fmt.Errorf("the timer function must not be nil: %w", errors.ErrInvalid)
+	}
+	// due is relative to the current frame millis until the timer is scheduled
+	t.due += c.disp.millis.Load()
+	c.disp.sched.add(t)
+	// the timer may be scheduled from another goroutine, while the drawing loop waits
+	// for the input events
+	c.disp.dsp.wakeUp()
+	return t, nil
+}
+
+// Cancel stops the timer, the function is not called after that. If Cancel is called
+// from the timer function, the function is not called anymore.
+func (t *Timer) Cancel() {
+	t.cancelled.Store(true)
+}
+
+// IsActive returns whether the timer function is going to be called: the timer is not
+// cancelled, and the function of After or OnIdle is not called yet
+func (t *Timer) IsActive() bool {
+	return t.active.Load() && !t.isDead()
+}
+
+func (t *Timer) isDead() bool {
+	return t.cancelled.Load() || (t.owner != nil && t.owner.baseComponent().isClosed())
+}
+
+func (s *scheduler) add(t *Timer) {
+	t.active.Store(true)
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.timers = append(s.timers, t)
+}
+
+func (s *scheduler) addListener(fl FrameListener) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	for _, l := range s.listeners {
+		if l == fl {
+			return fmt.Errorf("the frame listener %v is already registered: %w", fl, errors.ErrExist)
+		}
+	}
+	// copy on write, so the listeners being notified are not affected
+	nl := make([]FrameListener, 0, len(s.listeners)+1)
+	s.listeners = append(append(nl, s.listeners...), fl)
+	return nil
+}
+
+func (s *scheduler) removeListener(fl FrameListener) bool {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	for i, l := range s.listeners {
+		if l == fl {
+			nl := make([]FrameListener, 0, len(s.listeners)-1)
+			s.listeners = append(append(nl, s.listeners[:i]...), s.listeners[i+1:]...)
+			return true
+		}
+	}
+	return false
+}
+
+// hasTimers returns whether there are timers, which functions are going to be called
+func (s *scheduler) hasTimers() bool {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	for _, t := range s.timers {
+		if !t.isDead() {
+			return true
+		}
+	}
+	return false
+}
+
+// onNewFrame runs the timers, which are due by millis, and notifies the frame listeners
+func (s *scheduler) onNewFrame(millis int64) {
+	s.run(func(t *Timer) bool {
+		if t.idle || t.due > millis {
+			return false
+		}
+		if t.period > 0 {
+			t.due += t.period
+			if t.due <= millis {
+				t.due = millis + t.period
+			}
+		}
+		return true
+	})
+
+	s.lock.Lock()
+	listeners := s.listeners
+	s.lock.Unlock()
+	for _, fl := range listeners {
+		fl.OnNewFrame(millis)
+	}
+}
+
+// onFrameDone runs the OnIdle timers, if the frame was idle
+func (s *scheduler) onFrameDone(idle bool) {
+	if idle {
+		s.run(func(t *Timer) bool {
+			return t.idle
+		})
+	}
+}
+
+// run calls the functions of the timers, for which the ready returns true. The periodic
+// timers are kept, other ones and the dead timers are removed.
+func (s *scheduler) run(ready func(t *Timer) bool) {
+	s.lock.Lock()
+	timers := s.timers
+	s.timers = nil
+	s.lock.Unlock()
+
+	alive := timers[:0]
+	for _, t := range timers {
+		if t.isDead() {
+			t.active.Store(false)
+			continue
+		}
+		if !ready(t) {
+			alive = append(alive, t)
+			continue
+		}
+		if t.period == 0 {
+			t.active.Store(false)
+		}
+		t.f()
+		if t.period > 0 {
+			alive = append(alive, t)
+		}
+	}
+
+	// the timers could be scheduled while the functions are called
+	s.lock.Lock()
+	s.timers = append(alive, s.timers...)
+	s.lock.Unlock()
+}
@@ -0,0 +1,204 @@
+package raywin
+
+// Copyright 2025 Dmitry Spasibenko
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+import (
+	"context"
+	"github.com/dspasibenko/raywin-go/pkg/golibs/errors"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func newTestTimerApp(t *testing.T) *App {
+	cfg := DefaultConfig()
+	cfg.Proxy = &testProxy{}
+	app, err := NewApp(cfg)
+	assert.Nil(t, err)
+	return app
+}
+
+func TestApp_After(t *testing.T) {
+	app := newTestTimerApp(t)
+	var res []int64
+	tm, err := app.After(nil, 20, func() { res = append(res, app.Millis()) })
+	assert.Nil(t, err)
+	assert.True(t, tm.IsActive())
+	for _, ms := range []int64{10, 19, 25, 40} {
+		assert.Nil(t, app.RunFrame(ms))
+	}
+	assert.Equal(t, []int64{25}, res)
+	assert.False(t, tm.IsActive())
+
+	tm, _ = app.After(nil, 0, func() { res = append(res, app.Millis()) })
+	tm.Cancel()
+	assert.False(t, tm.IsActive())
+	assert.Nil(t, app.RunFrame(50))
+	assert.Equal(t, []int64{25}, res)
+
+	_, err = app.After(nil, 10, nil)
+	assert.ErrorIs(t, err, errors.ErrInvalid)
+	_, err = (&App{}).After(nil, 10, func() {})
+	assert.ErrorIs(t, err, errors.ErrInvalid)
+}
+
+func TestApp_AfterRealClock(t *testing.T) {
+	cfg := DefaultConfig()
+	sp := NewSoftProxy()
+	cfg.Proxy = sp
+	app, err := NewApp(cfg)
+	assert.Nil(t, err)
+	defer app.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	var waiting []bool
+	start := time.Now()
+	_, err = app.After(nil, 50, func() {
+		waiting = append(waiting, sp.eventWaiting)
+		cancel()
+	})
+	assert.Nil(t, err)
+	// the frames must not wait for the input events, while the timer is pending
+	assert.ErrorIs(t, app.Run(ctx), context.Canceled)
+	assert.GreaterOrEqual(t, time.Since(start), 50*time.Millisecond)
+	assert.Equal(t, []bool{false}, waiting)
+	assert.True(t, sp.eventWaiting)
+}
+
+func Test_display_eventWaiting(t *testing.T) {
+	cfg := DefaultConfig()
+	sp := NewSoftProxy()
+	cfg.Proxy = sp
+	app, err := NewApp(cfg)
+	assert.Nil(t, err)
+	defer app.Close()
+	assert.Nil(t, app.RunFrame(10))
+	assert.True(t, sp.eventWaiting)
+
+	var bc BaseComponent
+	assert.Nil(t, bc.Init(app.RootContainer(), &bc))
+	a := NewAnimation(&bc, DefaultAnimationConfig().Duration(100), func(k float32) {})
+	assert.Nil(t, a.Start())
+	assert.Nil(t, app.RunFrame(20))
+	assert.False(t, sp.eventWaiting)
+	assert.Nil(t, app.RunFrame(200))
+	assert.Nil(t, app.RunFrame(210))
+	assert.True(t, sp.eventWaiting)
+
+	tm, err := app.Every(&bc, 1000, func() {})
+	assert.Nil(t, err)
+	assert.Nil(t, app.RunFrame(220))
+	assert.False(t, sp.eventWaiting)
+	tm.Cancel()
+	assert.Nil(t, app.RunFrame(230))
+	assert.True(t, sp.eventWaiting)
+}
+
+func TestApp_Every(t *testing.T) {
+	app := newTestTimerApp(t)
+	var res []int64
+	var tm *Timer
+	tm, err := app.Every(nil, 10, func() {
+		res = append(res, app.Millis())
+		if len(res) == 4 {
+			tm.Cancel()
+		}
+	})
+	assert.Nil(t, err)
+	// the frame 45 is long, the calls at 30 and 40 are collapsed into one
+	for _, ms := range []int64{5, 10, 15, 20, 45, 50, 55, 60, 70} {
+		assert.Nil(t, app.RunFrame(ms))
+	}
+	assert.Equal(t, []int64{10, 20, 45, 55}, res)
+	assert.False(t, tm.IsActive())
+
+	_, err = app.Every(nil, 0, func() {})
+	assert.ErrorIs(t, err, errors.ErrInvalid)
+}
+
+func TestApp_timersOwner(t *testing.T) {
+	app := newTestTimerApp(t)
+	var bc BaseComponent
+	assert.Nil(t, bc.Init(app.RootContainer(), &bc))
+	cnt := 0
+	tm1, err := After(&bc, 10, func() { cnt++ })
+	assert.Nil(t, err)
+	tm2, err := Every(&bc, 10, func() { cnt++ })
+	assert.Nil(t, err)
+	tm3, err := OnIdle(&bc, func() { cnt++ })
+	assert.Nil(t, err)
+	assert.Equal(t, 3, len(app.disp.sched.timers))
+
+	bc.Close()
+	assert.False(t, tm1.IsActive())
+	assert.False(t, tm2.IsActive())
+	assert.False(t, tm3.IsActive())
+	assert.Nil(t, app.RunFrame(20))
+	assert.Equal(t, 0, cnt)
+	assert.Equal(t, 0, len(app.disp.sched.timers))
+}
+
+func TestApp_OnIdle(t *testing.T) {
+	app := newTestTimerApp(t)
+	cnt := 0
+	tm, err := app.OnIdle(nil, func() { cnt++ })
+	assert.Nil(t, err)
+	a := NewAnimation(nil, DefaultAnimationConfig().Duration(20), nil)
+	assert.Nil(t, app.disp.anim.start(a))
+	assert.Nil(t, app.RunFrame(10))
+	assert.Equal(t, 0, cnt)
+	assert.True(t, tm.IsActive())
+
+	assert.Nil(t, app.RunFrame(40))
+	assert.Equal(t, 1, cnt)
+	assert.False(t, tm.IsActive())
+	assert.Nil(t, app.RunFrame(50))
+	assert.Equal(t, 1, cnt)
+
+	app.OnIdle(nil, func() { cnt++ })
+	assert.Nil(t, app.Post(func() {}))
+	app.disp.sched.onFrameDone(app.disp.isIdle())
+	assert.Equal(t, 1, cnt)
+	assert.Nil(t, app.RunFrame(60))
+	assert.Equal(t, 2, cnt)
+}
+
+type _timer_test_listener struct {
+	millis []int64
+}
+
+func (tl *_timer_test_listener) OnNewFrame(millis int64) {
+	tl.millis = append(tl.millis, millis)
+}
+
+func TestApp_AddFrameListener(t *testing.T) {
+	app := newTestTimerApp(t)
+	l1, l2 := &_timer_test_listener{}, &_timer_test_listener{}
+	assert.Nil(t, app.AddFrameListener(l1))
+	assert.ErrorIs(t, app.AddFrameListener(l1), errors.ErrExist)
+	assert.ErrorIs(t, app.AddFrameListener(nil), errors.ErrInvalid)
+	assert.Nil(t, app.RunFrame(10))
+	assert.Nil(t, app.AddFrameListener(l2))
+	assert.Nil(t, app.RunFrame(20))
+	assert.True(t, app.RemoveFrameListener(l1))
+	assert.False(t, app.RemoveFrameListener(l1))
+	assert.Nil(t, app.RunFrame(30))
+	assert.Equal(t, []int64{10, 20}, l1.millis)
+	assert.Equal(t, []int64{20, 30}, l2.millis)
+
+	assert.ErrorIs(t, (&App{}).AddFrameListener(l1), errors.ErrInvalid)
+	assert.False(t, (&App{}).RemoveFrameListener(l1))
+}
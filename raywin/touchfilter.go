@@ -0,0 +1,135 @@
+package raywin
+
+// Copyright 2025 Dmitry Spasibenko
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+import (
+	"fmt"
+	"github.com/dspasibenko/raywin-go/pkg/golibs/errors"
+	rl "github.com/gen2brain/raylib-go/raylib"
+	"slices"
+)
+
+// TouchFilter describes the filters applied to the touch points read from the panel,
+// before the touchpad state is formed. The filters suppress the noise of the panels
+// mounted on vibrating surfaces. The zero value disables all the filters.
+type TouchFilter struct {
+	// ReleaseDebounceMillis is the time a point may disappear for without being released.
+	// If the point is back within the time, the press continues, otherwise the point is
+	// released after the time. 0 releases the point in the first frame it is not reported.
+	ReleaseDebounceMillis int64
+	// MoveDeadZone is the distance a point must move from its start position before its
+	// state becomes TPStateMoving. The point position is not changed within the zone.
+	MoveDeadZone Mm
+	// MedianWindow is the number of the last positions of a point, the median of which
+	// is taken as the point position. 0 or 1 disables the median filter.
+	MedianWindow int
+	// Smoothing is the low-pass filter factor in [0..1), it is the weight of the previous
+	// point position: pos = Smoothing*prev + (1-Smoothing)*new. 0 disables the filter.
+	Smoothing float32
+	// RejectSpurious ignores the points which appear and vanish within one frame. The new
+	// points are reported since the second frame they are seen in.
+	RejectSpurious bool
+}
+
+// tpTrack is the filter state of one touch point
+type tpTrack struct {
+	id int32
+	// lastSeen is the millis of the last frame the point was reported by the panel
+	lastSeen int64
+	// frames is the number of the frames the point was reported in
+	frames int
+	// history contains the last positions for the median filter
+	history []rl.Vector2
+	pos     rl.Vector2
+}
+
+// validate returns errors.ErrInvalid if the filter settings are out of the ranges
+func (tf TouchFilter) validate() error {
+	if tf.ReleaseDebounceMillis < 0 || tf.MoveDeadZone < 0 || tf.MedianWindow < 0 {
+		return fmt.Errorf("the touch filter values must not be negative: %+v: %w", tf, errors.ErrInvalid)
+	}
+	if tf.Smoothing < 0 || tf.Smoothing >= 1 {
+		return fmt.Errorf("the touch filter Smoothing=%f must be in [0..1): %w", tf.Smoothing, errors.ErrInvalid)
+	}
+	return nil
+}
+
+// isEnabled returns whether the contacts must be filtered. The MoveDeadZone is not
+// considered, it is applied when the points are updated (see touchPad.updatePoints)
+func (tf TouchFilter) isEnabled() bool {
+	return tf.ReleaseDebounceMillis > 0 || tf.MedianWindow > 1 || tf.Smoothing > 0 || tf.RejectSpurious
+}
+
+// filterContacts returns the contacts of the frame with the filters applied. The
+// contacts, which vanished, are held until the release debounce time is over
+func (tp *touchPad) filterContacts(millis int64, contacts []tpContact) []tpContact {
+	tf := tp.cfg.TouchFilter
+	if !tf.isEnabled() {
+		return contacts
+	}
+	var res []tpContact
+	var tracks []tpTrack
+	for _, c := range contacts {
+		idx := slices.IndexFunc(tp.tracks, func(t tpTrack) bool { return t.id == c.id })
+		var t tpTrack
+		if idx < 0 {
+			t = tpTrack{id: c.id, pos: c.pos}
+		} else {
+			t = tp.tracks[idx]
+		}
+		t.lastSeen = millis
+		t.frames++
+		pos := t.median(c.pos, tf.MedianWindow)
+		t.pos = rl.Vector2{X: tf.Smoothing*t.pos.X + (1-tf.Smoothing)*pos.X, Y: tf.Smoothing*t.pos.Y + (1-tf.Smoothing)*pos.Y}
+		tracks = append(tracks, t)
+		if tf.RejectSpurious && t.frames < 2 {
+			continue
+		}
+		// the point is moved, if its filtered position is changed (see updatePoints)
+		res = append(res, tpContact{id: c.id, pos: t.pos})
+	}
+	for _, t := range tp.tracks {
+		if contactIndex(contacts, t.id) >= 0 || (tf.RejectSpurious && t.frames < 2) {
+			continue
+		}
+		if millis-t.lastSeen < tf.ReleaseDebounceMillis {
+			// the point is held at its last position until it is back, or the time is over
+			tracks = append(tracks, t)
+			res = append(res, tpContact{id: t.id, pos: t.pos})
+		}
+	}
+	tp.tracks = tracks
+	return res
+}
+
+// median adds the position pos to the track history and returns the median of the last
+// window positions by each axis
+func (t *tpTrack) median(pos rl.Vector2, window int) rl.Vector2 {
+	if window < 2 {
+		return pos
+	}
+	t.history = append(t.history, pos)
+	if len(t.history) > window {
+		t.history = t.history[len(t.history)-window:]
+	}
+	xs := make([]float32, len(t.history))
+	ys := make([]float32, len(t.history))
+	for i, p := range t.history {
+		xs[i], ys[i] = p.X, p.Y
+	}
+	slices.Sort(xs)
+	slices.Sort(ys)
+	return rl.Vector2{X: xs[len(xs)/2], Y: ys[len(ys)/2]}
+}
@@ -0,0 +1,141 @@
+package raywin
+
+// Copyright 2025 Dmitry Spasibenko
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+import (
+	"github.com/dspasibenko/raywin-go/pkg/golibs/errors"
+	rl "github.com/gen2brain/raylib-go/raylib"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+// newTestFilterTouchPad returns the touchPad with the filter, 1mm is 1 pixel for it
+func newTestFilterTouchPad(tf TouchFilter) *touchPad {
+	return &touchPad{cfg: DisplayConfig{PPI: 25.4, TouchFilter: tf}}
+}
+
+func TestTouchFilter_releaseDebounce(t *testing.T) {
+	tp := newTestFilterTouchPad(TouchFilter{ReleaseDebounceMillis: 50})
+	pxy := &testProxy{}
+	p := rl.Vector2{X: 10, Y: 10}
+	pxy.touches = []testTouch{{id: 1, pos: p}}
+	assert.Equal(t, TPStatePressed, tp.onNewFrame(0, pxy).State)
+
+	// the point flickers for 2 frames
+	pxy.touches = nil
+	assert.Equal(t, TPStatePressed, tp.onNewFrame(16, pxy).State)
+	assert.Equal(t, TPStatePressed, tp.onNewFrame(32, pxy).State)
+	pxy.touches = []testTouch{{id: 1, pos: p}}
+	s := tp.onNewFrame(48, pxy)
+	assert.Equal(t, TPStatePressed, s.State)
+	assert.Equal(t, int64(1), s.Sequence)
+
+	pxy.touches = nil
+	assert.Equal(t, TPStatePressed, tp.onNewFrame(64, pxy).State)
+	assert.Equal(t, TPStatePressed, tp.onNewFrame(80, pxy).State)
+	s = tp.onNewFrame(100, pxy)
+	assert.Equal(t, TPStateReleased, s.State)
+	assert.Equal(t, p, s.Pos)
+	assert.Nil(t, tp.tracks)
+}
+
+func TestTouchFilter_moveDeadZone(t *testing.T) {
+	tp := newTestFilterTouchPad(TouchFilter{MoveDeadZone: 5})
+	pxy := &testProxy{}
+	p := rl.Vector2{X: 10, Y: 10}
+	pxy.touches = []testTouch{{id: 1, pos: p}}
+	tp.onNewFrame(1, pxy)
+
+	pxy.touches = []testTouch{{id: 1, pos: rl.Vector2{X: 13, Y: 7}}}
+	s := tp.onNewFrame(2, pxy)
+	assert.Equal(t, TPStatePressed, s.State)
+	assert.Equal(t, p, s.Pos)
+
+	p1 := rl.Vector2{X: 14, Y: 14}
+	pxy.touches = []testTouch{{id: 1, pos: p1}}
+	s = tp.onNewFrame(3, pxy)
+	assert.Equal(t, TPStateMoving, s.State)
+	assert.Equal(t, p1, s.Pos)
+
+	// the moving point is not held by the zone anymore
+	p2 := rl.Vector2{X: 11, Y: 11}
+	pxy.touches = []testTouch{{id: 1, pos: p2}}
+	s = tp.onNewFrame(4, pxy)
+	assert.Equal(t, TPStateMoving, s.State)
+	assert.Equal(t, p2, s.Pos)
+}
+
+func TestTouchFilter_median(t *testing.T) {
+	tp := newTestFilterTouchPad(TouchFilter{MedianWindow: 3})
+	pxy := &testProxy{}
+	p := rl.Vector2{X: 10, Y: 10}
+	pxy.touches = []testTouch{{id: 1, pos: p}}
+	tp.onNewFrame(1, pxy)
+	tp.onNewFrame(2, pxy)
+
+	// the single spike is filtered out
+	pxy.touches = []testTouch{{id: 1, pos: rl.Vector2{X: 40, Y: 2}}}
+	s := tp.onNewFrame(3, pxy)
+	assert.Equal(t, TPStatePressed, s.State)
+	assert.Equal(t, p, s.Pos)
+
+	pxy.touches = []testTouch{{id: 1, pos: rl.Vector2{X: 30, Y: 20}}}
+	s = tp.onNewFrame(4, pxy)
+	assert.Equal(t, TPStateMoving, s.State)
+	assert.Equal(t, rl.Vector2{X: 30, Y: 10}, s.Pos)
+}
+
+func TestTouchFilter_smoothing(t *testing.T) {
+	tp := newTestFilterTouchPad(TouchFilter{Smoothing: 0.75})
+	pxy := &testProxy{}
+	pxy.touches = []testTouch{{id: 1, pos: rl.Vector2{X: 10, Y: 10}}}
+	assert.Equal(t, rl.Vector2{X: 10, Y: 10}, tp.onNewFrame(1, pxy).Pos)
+
+	pxy.touches = []testTouch{{id: 1, pos: rl.Vector2{X: 50, Y: 30}}}
+	assert.Equal(t, rl.Vector2{X: 20, Y: 15}, tp.onNewFrame(2, pxy).Pos)
+	assert.Equal(t, rl.Vector2{X: 27.5, Y: 18.75}, tp.onNewFrame(3, pxy).Pos)
+}
+
+func TestTouchFilter_rejectSpurious(t *testing.T) {
+	tp := newTestFilterTouchPad(TouchFilter{RejectSpurious: true, ReleaseDebounceMillis: 50})
+	pxy := &testProxy{}
+	pxy.touches = []testTouch{{id: 1, pos: rl.Vector2{X: 10, Y: 10}}}
+	assert.Equal(t, TPStateNA, tp.onNewFrame(0, pxy).State)
+	pxy.touches = nil
+	s := tp.onNewFrame(16, pxy)
+	assert.Equal(t, TPStateNA, s.State)
+	assert.Nil(t, s.Points)
+	assert.Nil(t, tp.tracks)
+
+	pxy.touches = []testTouch{{id: 2, pos: rl.Vector2{X: 20, Y: 20}}}
+	assert.Equal(t, TPStateNA, tp.onNewFrame(32, pxy).State)
+	s = tp.onNewFrame(48, pxy)
+	assert.Equal(t, TPStatePressed, s.State)
+	assert.Equal(t, rl.Vector2{X: 20, Y: 20}, s.Pos)
+}
+
+func TestTouchFilter_validate(t *testing.T) {
+	assert.Nil(t, TouchFilter{}.validate())
+	assert.Nil(t, TouchFilter{ReleaseDebounceMillis: 30, MoveDeadZone: 1, MedianWindow: 5, Smoothing: 0.5}.validate())
+	assert.ErrorIs(t, TouchFilter{ReleaseDebounceMillis: -1}.validate(), errors.ErrInvalid)
+	assert.ErrorIs(t, TouchFilter{MedianWindow: -1}.validate(), errors.ErrInvalid)
+	assert.ErrorIs(t, TouchFilter{Smoothing: 1}.validate(), errors.ErrInvalid)
+
+	cfg := DefaultConfig()
+	cfg.Proxy = &testProxy{}
+	cfg.DisplayConfig.TouchFilter.Smoothing = -0.5
+	_, err := NewApp(cfg)
+	assert.ErrorIs(t, err, errors.ErrInvalid)
+}
@@ -22,6 +22,12 @@ type (
 	// TPState describes the current touchpad state. The event describes
 	// most relevant state for the touchpad. The Millis field contains the timestamp
 	// when the state is observed (not when the touchpad switched to the state!)
+	//
+	// The State and Pos fields describe the primary point, which is the first touched
+	// point among the active ones. When the primary point is released, the state stays
+	// TPStateReleased until all the points are released, so the next touch becomes the
+	// primary one. The Points field contains all the points known in the frame, so the
+	// multi-touch gestures may be recognized by the components.
 	TPState struct {
 		// State contains the state of the touchpad
 		State int
@@ -33,6 +39,24 @@ type (
 		// Sequence is the state unique identifier. Every new state
 		// has a new monotonically increasing sequence
 		Sequence int64
+		// Points contains all the touched points ordered by the time they were touched. The
+		// released points are reported once with the TPStateReleased state and removed after that.
+		// The slice must not be modified by the receiver.
+		Points []TPPoint
+	}
+
+	// TPPoint describes one touched point of the multi-touch touchpad
+	TPPoint struct {
+		// ID is the point identifier, which is stable while the point is touched
+		ID int32
+		// State contains the point state: TPStatePressed, TPStateMoving or TPStateReleased
+		State int
+		// Pos is the current point position (or the last one for the released point)
+		Pos rl.Vector2
+		// StartPos is the position where the point was touched
+		StartPos rl.Vector2
+		// StartMillis is the timestamp when the point was touched
+		StartMillis int64
 	}
 
 	// OnTPSResult the result which will be returned by the OnTPState by the Touchpadable
@@ -40,7 +64,9 @@ type (
 	OnTPSResult int
 
 	// Touchpadable interface maybe implemented by a component to let raywin-go know
-	// that the component wants to react on the touchpad events.
+	// that the component wants to react on the touchpad events. The component is selected
+	// by the primary point position (TPState.Pos), but it receives all the touched points
+	// in TPState.Points.
 	Touchpadable interface {
 		// OnTPState is called every frame with the current touchpad State
 		// if any. The method must return OnTPSResult value(see below).
@@ -93,10 +119,27 @@ const (
 )
 
 type touchPad struct {
+	// cfg allows to transform the touch points to the logical coordinates (see Rotation
+	// and Calibration)
+	cfg    DisplayConfig
 	state  int
 	pos    rl.Vector2
 	millis int64
 	seq    int64
+
+	// primID is the ID of the primary point, relMillis is the timestamp when it was released
+	primID    int32
+	relMillis int64
+	points    []TPPoint
+	// tracks contains the filters state of the points (see TouchFilter)
+	tracks []tpTrack
+}
+
+// tpContact is a raw point read from the proxy
+type tpContact struct {
+	id    int32
+	pos   rl.Vector2
+	moved bool
 }
 
 const (
@@ -110,8 +153,29 @@ func (tps TPState) PosXY() (int32, int32) {
 	return int32(tps.Pos.X), int32(tps.Pos.Y)
 }
 
+// Point returns the point with the id provided, if it is in the state
+func (tps TPState) Point(id int32) (TPPoint, bool) {
+	for _, p := range tps.Points {
+		if p.ID == id {
+			return p, true
+		}
+	}
+	return TPPoint{}, false
+}
+
+// ActivePoints returns the number of the points which are touched (not released)
+func (tps TPState) ActivePoints() int {
+	res := 0
+	for _, p := range tps.Points {
+		if p.State != TPStateReleased {
+			res++
+		}
+	}
+	return res
+}
+
 func (tp *touchPad) tpState() TPState {
-	res := TPState{Pos: tp.pos, State: TPStateNA, Millis: tp.millis, Sequence: tp.seq}
+	res := TPState{Pos: tp.pos, State: TPStateNA, Millis: tp.millis, Sequence: tp.seq, Points: tp.points}
 	switch tp.state {
 	case tpsPressed:
 		res.State = TPStatePressed
@@ -125,27 +189,133 @@ func (tp *touchPad) tpState() TPState {
 
 func (tp *touchPad) onNewFrame(millis int64, proxy RlProxy) TPState {
 	tp.millis = millis
+	tp.updatePoints(millis, tp.filterContacts(millis, tp.readContacts(proxy)))
 	prevState := tp.state
-	if proxy.IsMouseButtonDown(rl.MouseLeftButton) {
-		switch tp.state {
-		case tpsInit, tpsReleased:
-			tp.state = tpsPressed
-		case tpsPressed:
-			if !IsEmpty(proxy.GetMouseDelta()) {
-				tp.state = tpsMoving
+	switch tp.state {
+	case tpsInit, tpsReleased:
+		released := tp.state == tpsReleased
+		tp.state = tpsInit
+		for _, p := range tp.points {
+			if p.State == TPStateReleased {
+				continue
+			}
+			if released && p.StartMillis <= tp.relMillis {
+				// the point was touched before the primary one was released, it is not
+				// promoted to the primary one, so the single touch listeners don't see
+				// the press without touching. The release is reported until it is released.
+				tp.state = tpsReleased
+				continue
 			}
+			tp.primID = p.ID
+			tp.pos = p.Pos
+			tp.state = tpsPressed
+			break
 		}
-		tp.pos = proxy.GetMousePosition()
-	} else {
-		switch tp.state {
-		case tpsMoving, tpsPressed:
+	case tpsPressed, tpsMoving:
+		p, ok := tp.tpState().Point(tp.primID)
+		if !ok || p.State == TPStateReleased {
 			tp.state = tpsReleased
-		case tpsReleased:
-			tp.state = tpsInit
+			tp.relMillis = millis
+			break
 		}
+		if p.State == TPStateMoving {
+			tp.state = tpsMoving
+		}
+		tp.pos = p.Pos
 	}
 	if prevState != tp.state {
 		tp.seq++
 	}
 	return tp.tpState()
 }
+
+// setState makes the touchpad state to be tps, it is used to replay the recorded states
+func (tp *touchPad) setState(tps TPState) {
+	tp.millis = tps.Millis
+	tp.seq = tps.Sequence
+	tp.pos = tps.Pos
+	tp.points = tps.Points
+	tp.tracks = nil
+	switch tps.State {
+	case TPStatePressed:
+		tp.state = tpsPressed
+	case TPStateMoving:
+		tp.state = tpsMoving
+	case TPStateReleased:
+		tp.state = tpsReleased
+		tp.relMillis = tps.Millis
+	default:
+		tp.state = tpsInit
+	}
+	for _, p := range tps.Points {
+		if p.Pos == tps.Pos {
+			tp.primID = p.ID
+			break
+		}
+	}
+}
+
+// readContacts returns the calibrated touched points in the logical coordinates. If the
+// touch points are not reported, the mouse left button is considered as the only point
+// with ID 0
+func (tp *touchPad) readContacts(proxy RlProxy) []tpContact {
+	n := proxy.GetTouchPointCount()
+	if n == 0 {
+		if !proxy.IsMouseButtonDown(rl.MouseLeftButton) {
+			return nil
+		}
+		return []tpContact{{id: 0, pos: tp.cfg.toDisplay(proxy.GetMousePosition()), moved: !IsEmpty(proxy.GetMouseDelta())}}
+	}
+	res := make([]tpContact, 0, n)
+	for i := int32(0); i < n; i++ {
+		res = append(res, tpContact{id: proxy.GetTouchPointId(i), pos: tp.cfg.toDisplay(proxy.GetTouchPosition(i))})
+	}
+	return res
+}
+
+// updatePoints builds the new list of points from the previous one and the contacts. A new
+// slice is created every time, so the TPState received by components stays immutable. The
+// pressed point is not moved until it leaves the TouchFilter.MoveDeadZone
+func (tp *touchPad) updatePoints(millis int64, contacts []tpContact) {
+	if len(tp.points) == 0 && len(contacts) == 0 {
+		return
+	}
+	deadZone := tp.cfg.TouchFilter.MoveDeadZone.Pixels(tp.cfg.PPI)
+	points := make([]TPPoint, 0, len(tp.points)+len(contacts))
+	for _, p := range tp.points {
+		if p.State == TPStateReleased {
+			continue
+		}
+		idx := contactIndex(contacts, p.ID)
+		if idx < 0 {
+			p.State = TPStateReleased
+		} else {
+			c := contacts[idx]
+			if p.State != TPStatePressed || distance(c.pos, p.StartPos) >= deadZone {
+				if c.moved || c.pos != p.Pos {
+					p.State = TPStateMoving
+				}
+				p.Pos = c.pos
+			}
+		}
+		points = append(points, p)
+	}
+	for _, c := range contacts {
+		if _, ok := (TPState{Points: points}).Point(c.id); !ok {
+			points = append(points, TPPoint{ID: c.id, State: TPStatePressed, Pos: c.pos, StartPos: c.pos, StartMillis: millis})
+		}
+	}
+	if len(points) == 0 {
+		points = nil
+	}
+	tp.points = points
+}
+
+func contactIndex(contacts []tpContact, id int32) int {
+	for i, c := range contacts {
+		if c.id == id {
+			return i
+		}
+	}
+	return -1
+}
@@ -32,26 +32,29 @@ func Test_touchpad_onNewFrame(t *testing.T) {
 	assert.Equal(t, TPState{Pos: pxy.mousePos, State: TPStateNA, Millis: 2, Sequence: 0}, s)
 
 	pxy.mousePos = rl.Vector2{X: 1, Y: 2}
+	pp := TPPoint{State: TPStatePressed, Pos: pxy.mousePos, StartPos: pxy.mousePos, StartMillis: 3}
 	s = tp.onNewFrame(3, pxy)
-	assert.Equal(t, TPState{Pos: pxy.mousePos, State: TPStatePressed, Millis: 3, Sequence: 1}, s)
+	assert.Equal(t, TPState{Pos: pxy.mousePos, State: TPStatePressed, Millis: 3, Sequence: 1, Points: []TPPoint{pp}}, s)
 	s = tp.onNewFrame(4, pxy)
-	assert.Equal(t, TPState{Pos: pxy.mousePos, State: TPStatePressed, Millis: 4, Sequence: 1}, s)
+	assert.Equal(t, TPState{Pos: pxy.mousePos, State: TPStatePressed, Millis: 4, Sequence: 1, Points: []TPPoint{pp}}, s)
 
 	pxy.mouseDiff = rl.Vector2{X: 1, Y: 1}
+	pp.State = TPStateMoving
 	s = tp.onNewFrame(5, pxy)
-	assert.Equal(t, TPState{Pos: pxy.mousePos, State: TPStateMoving, Millis: 5, Sequence: 2}, s)
+	assert.Equal(t, TPState{Pos: pxy.mousePos, State: TPStateMoving, Millis: 5, Sequence: 2, Points: []TPPoint{pp}}, s)
 	s = tp.onNewFrame(6, pxy)
-	assert.Equal(t, TPState{Pos: pxy.mousePos, State: TPStateMoving, Millis: 6, Sequence: 2}, s)
+	assert.Equal(t, TPState{Pos: pxy.mousePos, State: TPStateMoving, Millis: 6, Sequence: 2, Points: []TPPoint{pp}}, s)
 	pxy.mouseDiff = rl.Vector2{}
 	s = tp.onNewFrame(7, pxy)
 	// if we switched to moving, no changes like pressed after that until it is released
-	assert.Equal(t, TPState{Pos: pxy.mousePos, State: TPStateMoving, Millis: 7, Sequence: 2}, s)
+	assert.Equal(t, TPState{Pos: pxy.mousePos, State: TPStateMoving, Millis: 7, Sequence: 2, Points: []TPPoint{pp}}, s)
 
 	// Now release it!
 	prevPos := pxy.mousePos
 	pxy.mousePos = rl.Vector2{}
+	pp.State = TPStateReleased
 	s = tp.onNewFrame(8, pxy)
-	assert.Equal(t, TPState{Pos: prevPos, State: TPStateReleased, Millis: 8, Sequence: 3}, s)
+	assert.Equal(t, TPState{Pos: prevPos, State: TPStateReleased, Millis: 8, Sequence: 3, Points: []TPPoint{pp}}, s)
 	s = tp.onNewFrame(9, pxy)
 	assert.Equal(t, TPState{Pos: prevPos, State: TPStateNA, Millis: 9, Sequence: 4}, s)
 	s = tp.onNewFrame(10, pxy)
@@ -59,11 +62,101 @@ func Test_touchpad_onNewFrame(t *testing.T) {
 
 	// Ok Press and release:
 	pxy.mousePos = prevPos
+	pp = TPPoint{State: TPStatePressed, Pos: prevPos, StartPos: prevPos, StartMillis: 11}
 	s = tp.onNewFrame(11, pxy)
-	assert.Equal(t, TPState{Pos: pxy.mousePos, State: TPStatePressed, Millis: 11, Sequence: 5}, s)
+	assert.Equal(t, TPState{Pos: pxy.mousePos, State: TPStatePressed, Millis: 11, Sequence: 5, Points: []TPPoint{pp}}, s)
 	pxy.mousePos = rl.Vector2{}
+	pp.State = TPStateReleased
 	s = tp.onNewFrame(12, pxy)
-	assert.Equal(t, TPState{Pos: prevPos, State: TPStateReleased, Millis: 12, Sequence: 6}, s)
+	assert.Equal(t, TPState{Pos: prevPos, State: TPStateReleased, Millis: 12, Sequence: 6, Points: []TPPoint{pp}}, s)
 	s = tp.onNewFrame(13, pxy)
 	assert.Equal(t, TPState{Pos: prevPos, State: TPStateNA, Millis: 13, Sequence: 7}, s)
 }
+
+func Test_touchpad_multiTouch(t *testing.T) {
+	tp := &touchPad{}
+	pxy := &testProxy{}
+	p1 := rl.Vector2{X: 10, Y: 10}
+	p2 := rl.Vector2{X: 100, Y: 100}
+	pxy.touches = []testTouch{{id: 5, pos: p1}}
+	s := tp.onNewFrame(1, pxy)
+	assert.Equal(t, TPStatePressed, s.State)
+	assert.Equal(t, p1, s.Pos)
+	assert.Equal(t, 1, s.ActivePoints())
+
+	pxy.touches = []testTouch{{id: 5, pos: p1}, {id: 7, pos: p2}}
+	s = tp.onNewFrame(2, pxy)
+	assert.Equal(t, TPStatePressed, s.State)
+	assert.Equal(t, []TPPoint{
+		{ID: 5, State: TPStatePressed, Pos: p1, StartPos: p1, StartMillis: 1},
+		{ID: 7, State: TPStatePressed, Pos: p2, StartPos: p2, StartMillis: 2},
+	}, s.Points)
+
+	// the second point is moved, the primary one is not
+	p3 := rl.Vector2{X: 110, Y: 90}
+	pxy.touches = []testTouch{{id: 7, pos: p3}, {id: 5, pos: p1}}
+	s = tp.onNewFrame(3, pxy)
+	assert.Equal(t, TPStatePressed, s.State)
+	p, ok := s.Point(7)
+	assert.True(t, ok)
+	assert.Equal(t, TPPoint{ID: 7, State: TPStateMoving, Pos: p3, StartPos: p2, StartMillis: 2}, p)
+	_, ok = s.Point(8)
+	assert.False(t, ok)
+
+	// the primary point is released, the release is reported while the second one is touched
+	pxy.touches = []testTouch{{id: 7, pos: p3}}
+	s = tp.onNewFrame(4, pxy)
+	assert.Equal(t, TPStateReleased, s.State)
+	assert.Equal(t, p1, s.Pos)
+	assert.Equal(t, 1, s.ActivePoints())
+	assert.Equal(t, 2, len(s.Points))
+	p, _ = s.Point(5)
+	assert.Equal(t, TPStateReleased, p.State)
+
+	seq := s.Sequence
+	s = tp.onNewFrame(5, pxy)
+	assert.Equal(t, TPStateReleased, s.State)
+	assert.Equal(t, p1, s.Pos)
+	assert.Equal(t, seq, s.Sequence)
+	assert.Equal(t, []TPPoint{{ID: 7, State: TPStateMoving, Pos: p3, StartPos: p2, StartMillis: 2}}, s.Points)
+
+	// the new touch becomes the primary point
+	p4 := rl.Vector2{X: 50, Y: 50}
+	pxy.touches = []testTouch{{id: 7, pos: p3}, {id: 9, pos: p4}}
+	s = tp.onNewFrame(6, pxy)
+	assert.Equal(t, TPStatePressed, s.State)
+	assert.Equal(t, p4, s.Pos)
+	assert.Equal(t, seq+1, s.Sequence)
+
+	pxy.touches = nil
+	s = tp.onNewFrame(7, pxy)
+	assert.Equal(t, TPStateReleased, s.State)
+	s = tp.onNewFrame(8, pxy)
+	assert.Equal(t, TPStateNA, s.State)
+	assert.Nil(t, s.Points)
+}
+
+func Test_touchpad_primaryReleasedFirst(t *testing.T) {
+	tp := &touchPad{}
+	pxy := &testProxy{}
+	p1 := rl.Vector2{X: 10, Y: 10}
+	p2 := rl.Vector2{X: 100, Y: 100}
+	pxy.touches = []testTouch{{id: 1, pos: p1}}
+	s := tp.onNewFrame(1, pxy)
+	pxy.touches = []testTouch{{id: 1, pos: p1}, {id: 2, pos: p2}}
+	s = tp.onNewFrame(2, pxy)
+	assert.Equal(t, TPStatePressed, s.State)
+
+	// the second finger is never reported as the primary point
+	pxy.touches = []testTouch{{id: 2, pos: p2}}
+	var states []int
+	for ms := int64(3); ms < 6; ms++ {
+		s = tp.onNewFrame(ms, pxy)
+		states = append(states, s.State)
+		assert.Equal(t, p1, s.Pos)
+	}
+	pxy.touches = nil
+	s = tp.onNewFrame(6, pxy)
+	states = append(states, s.State)
+	assert.Equal(t, []int{TPStateReleased, TPStateReleased, TPStateReleased, TPStateNA}, states)
+}
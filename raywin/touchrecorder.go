@@ -0,0 +1,184 @@
+package raywin
+
+// Copyright 2025 Dmitry Spasibenko
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+import (
+	"bufio"
+	"fmt"
+	"github.com/dspasibenko/raywin-go/pkg/golibs/errors"
+	"github.com/dspasibenko/raywin-go/pkg/golibs/xbinary"
+	rl "github.com/gen2brain/raylib-go/raylib"
+	"io"
+	"math"
+	"strings"
+	"sync"
+)
+
+type (
+	// TouchRecorder writes the touchpad state (TPState) of every frame together with the
+	// frame millis. The recording made on the device may be replayed by TouchReplay to
+	// reproduce the user actions exactly. To record the application input, provide
+	// the recorder via Config.TouchRecorder.
+	TouchRecorder struct {
+		lock       sync.Mutex
+		ow         xbinary.ObjectsWriter
+		bw         *bufio.Writer
+		header     bool
+		lastMillis int64
+		err        error
+	}
+
+	// TouchReplay contains the recorded touchpad states. Being provided via Config.TouchReplay,
+	// the states are fed to the components instead of the real touchpad input, and the
+	// display clock (see Millis()) follows the recorded frames millis, so the timing-sensitive
+	// behavior (press delays, scrolling inertia, animations etc.) is reproduced deterministically
+	// independently of the replay speed.
+	TouchReplay struct {
+		states    []TPState
+		idx       int
+		stopAtEnd bool
+	}
+)
+
+const touchRecordingMagic = "RWTR1"
+
+// NewTouchRecorder returns the new TouchRecorder, which writes the records to w
+func NewTouchRecorder(w io.Writer) *TouchRecorder {
+	tr := &TouchRecorder{bw: bufio.NewWriter(w)}
+	tr.ow.Writer = tr.bw
+	return tr
+}
+
+// Flush writes the buffered records to the writer. The records are flushed when a touch
+// is released and when Run() is over.
+func (tr *TouchRecorder) Flush() error {
+	tr.lock.Lock()
+	defer tr.lock.Unlock()
+	tr.flush()
+	return tr.err
+}
+
+// Err returns the first error the records writing failed with, if any
+func (tr *TouchRecorder) Err() error {
+	tr.lock.Lock()
+	defer tr.lock.Unlock()
+	return tr.err
+}
+
+func (tr *TouchRecorder) record(tps TPState) {
+	tr.lock.Lock()
+	defer tr.lock.Unlock()
+	if tr.err != nil {
+		return
+	}
+	if !tr.header {
+		tr.header = true
+		tr.lastMillis = 0
+		tr.ow.WritePureString(touchRecordingMagic)
+	}
+	tr.ow.WriteUint(uint(max(0, tps.Millis-tr.lastMillis)))
+	tr.lastMillis = max(tr.lastMillis, tps.Millis)
+	tr.ow.WriteByteWithSize(byte(tps.State))
+	tr.ow.WriteUint(uint(tps.Sequence))
+	tr.writeVector(tps.Pos)
+	tr.ow.WriteUint(uint(len(tps.Points)))
+	for _, p := range tps.Points {
+		tr.ow.WriteUint32(uint32(p.ID))
+		tr.ow.WriteByteWithSize(byte(p.State))
+		tr.writeVector(p.Pos)
+		tr.writeVector(p.StartPos)
+		tr.ow.WriteUint(uint(max(0, tps.Millis-p.StartMillis)))
+	}
+	if tps.State == TPStateReleased {
+		tr.flush()
+	}
+}
+
+func (tr *TouchRecorder) writeVector(v rl.Vector2) {
+	tr.ow.WriteUint32(math.Float32bits(v.X))
+	tr.ow.WriteUint32(math.Float32bits(v.Y))
+}
+
+func (tr *TouchRecorder) flush() {
+	if err := tr.bw.Flush(); err != nil && tr.err == nil {
+		tr.err = err
+	}
+}
+
+// NewTouchReplay reads the recording written by TouchRecorder. If stopAtEnd is true, Run()
+// returns when all the recorded frames are replayed, otherwise the real touchpad input and
+// the clock are resumed from the last replayed frame.
+func NewTouchReplay(r io.Reader, stopAtEnd bool) (*TouchReplay, error) {
+	buf, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	if !strings.HasPrefix(string(buf), touchRecordingMagic) {
+		return nil, fmt.Errorf("not a touch recording, the header is not found: %w", errors.ErrInvalid)
+	}
+	rd := &drReader{buf: buf[len(touchRecordingMagic):]}
+	tr := &TouchReplay{stopAtEnd: stopAtEnd}
+	millis := int64(0)
+	for len(rd.buf) > 0 && rd.err == nil {
+		millis += int64(rd.uint())
+		tps := TPState{Millis: millis, State: int(rd.byte()), Sequence: int64(rd.uint())}
+		tps.Pos = rl.Vector2{X: rd.float32(), Y: rd.float32()}
+		if n := int(rd.uint()); n > 0 && n <= len(rd.buf) {
+			tps.Points = make([]TPPoint, n)
+			for i := range tps.Points {
+				p := &tps.Points[i]
+				p.ID = int32(rd.uint32())
+				p.State = int(rd.byte())
+				p.Pos = rl.Vector2{X: rd.float32(), Y: rd.float32()}
+				p.StartPos = rl.Vector2{X: rd.float32(), Y: rd.float32()}
+				p.StartMillis = millis - int64(rd.uint())
+			}
+		}
+		if rd.err == nil {
+			tr.states = append(tr.states, tps)
+		}
+	}
+	if rd.err != nil {
+		return tr, fmt.Errorf("the recording is truncated after %d frames: %w", len(tr.states), errors.ErrDataLoss)
+	}
+	return tr, nil
+}
+
+// States returns the recorded touchpad states, one per frame
+func (tr *TouchReplay) States() []TPState {
+	return tr.states
+}
+
+// Done returns whether all the recorded frames are replayed
+func (tr *TouchReplay) Done() bool {
+	return tr.idx >= len(tr.states)
+}
+
+// peek returns the state of the next frame to be replayed, if any
+func (tr *TouchReplay) peek() (TPState, bool) {
+	if tr.Done() {
+		return TPState{}, false
+	}
+	return tr.states[tr.idx], true
+}
+
+// next returns the state of the next frame and moves to the following one
+func (tr *TouchReplay) next() (TPState, bool) {
+	tps, ok := tr.peek()
+	if ok {
+		tr.idx++
+	}
+	return tps, ok
+}
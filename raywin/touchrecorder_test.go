@@ -0,0 +1,107 @@
+package raywin
+
+// Copyright 2025 Dmitry Spasibenko
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+import (
+	"bytes"
+	"context"
+	"github.com/dspasibenko/raywin-go/pkg/golibs/errors"
+	rl "github.com/gen2brain/raylib-go/raylib"
+	"github.com/stretchr/testify/assert"
+	"strings"
+	"testing"
+)
+
+type _touchrecorder_test_comp struct {
+	BaseComponent
+	states []TPState
+	millis []int64
+}
+
+func (tc *_touchrecorder_test_comp) OnTPState(tps TPState) OnTPSResult {
+	tc.states = append(tc.states, tps)
+	return OnTPSResultNA
+}
+
+func (tc *_touchrecorder_test_comp) OnNewFrame(millis int64) {
+	tc.millis = append(tc.millis, millis)
+}
+
+func TestTouchRecorder_RecordAndReplay(t *testing.T) {
+	var buf bytes.Buffer
+	pxy := &testProxy{}
+	d := newDisplay(DefaultDisplayConfig(), pxy)
+	d.tpRecorder = NewTouchRecorder(&buf)
+	var tc _touchrecorder_test_comp
+	assert.Nil(t, tc.Init(&d.root, &tc))
+	tc.SetBounds(rl.RectangleInt32{Width: 100, Height: 100})
+
+	d.formFrame(5)
+	pxy.touches = []testTouch{{id: 3, pos: rl.Vector2{X: 10, Y: 10}}}
+	d.formFrame(21)
+	pxy.touches = []testTouch{{id: 3, pos: rl.Vector2{X: 12, Y: 10}}, {id: 4, pos: rl.Vector2{X: 50, Y: 60}}}
+	d.formFrame(38)
+	pxy.touches = nil
+	d.formFrame(54)
+	d.formFrame(70)
+	assert.Nil(t, d.tpRecorder.Flush())
+	assert.Equal(t, 5, len(tc.states))
+
+	tr, err := NewTouchReplay(bytes.NewReader(buf.Bytes()), true)
+	assert.Nil(t, err)
+	assert.Equal(t, 5, len(tr.States()))
+	assert.False(t, tr.Done())
+
+	d2 := newDisplay(DefaultDisplayConfig(), &testProxy{})
+	d2.tpReplay = tr
+	var tc2 _touchrecorder_test_comp
+	assert.Nil(t, tc2.Init(&d2.root, &tc2))
+	tc2.SetBounds(rl.RectangleInt32{Width: 100, Height: 100})
+	assert.Nil(t, d2.run(context.Background()))
+	assert.True(t, tr.Done())
+	assert.Equal(t, tc.states, tc2.states)
+	assert.Equal(t, []int64{5, 21, 38, 54, 70}, tc2.millis)
+	assert.Equal(t, int64(70), d2.millis.Load())
+	assert.Equal(t, int32(3), d2.tp.primID)
+}
+
+func TestTouchReplay_Errors(t *testing.T) {
+	_, err := NewTouchReplay(strings.NewReader("RWDR1"), true)
+	assert.ErrorIs(t, err, errors.ErrInvalid)
+
+	var buf bytes.Buffer
+	tr := NewTouchRecorder(&buf)
+	tr.record(TPState{Millis: 10})
+	tr.record(TPState{Millis: 20, State: TPStatePressed, Points: []TPPoint{{ID: 1, StartMillis: 20}}})
+	assert.Nil(t, tr.Flush())
+
+	rp, err := NewTouchReplay(bytes.NewReader(buf.Bytes()[:buf.Len()-3]), true)
+	assert.ErrorIs(t, err, errors.ErrDataLoss)
+	assert.Equal(t, []TPState{{Millis: 10}}, rp.States())
+}
+
+func Test_touchPad_setState(t *testing.T) {
+	var tp touchPad
+	tps := TPState{State: TPStateMoving, Pos: rl.Vector2{X: 1, Y: 2}, Millis: 7, Sequence: 3,
+		Points: []TPPoint{{ID: 1, State: TPStateMoving, Pos: rl.Vector2{X: 5}}, {ID: 2, State: TPStateMoving, Pos: rl.Vector2{X: 1, Y: 2}}}}
+	tp.setState(tps)
+	assert.Equal(t, tps, tp.tpState())
+	assert.Equal(t, int32(2), tp.primID)
+
+	// the live input continues from the replayed state
+	tps = tp.onNewFrame(8, &testProxy{touches: []testTouch{{id: 2, pos: rl.Vector2{X: 1, Y: 3}}}})
+	assert.Equal(t, TPStateMoving, tps.State)
+	assert.Equal(t, rl.Vector2{X: 1, Y: 3}, tps.Pos)
+}
@@ -0,0 +1,114 @@
+package raywin
+
+// Copyright 2025 Dmitry Spasibenko
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+import (
+	rl "github.com/gen2brain/raylib-go/raylib"
+	"math"
+)
+
+type (
+	// Length is a physical length, which can be converted to the display pixels by
+	// the display density (pixels per inch). The units Mm, Pt and Dp implement the interface.
+	Length interface {
+		// Pixels returns the length in pixels for the display with the ppi density
+		Pixels(ppi float32) float32
+	}
+
+	// Mm is the length in millimetres
+	Mm float32
+
+	// Pt is the length in typographic points (1/72 of inch)
+	Pt float32
+
+	// Dp is the length in density-independent pixels (1/160 of inch), so 1Dp is
+	// 1 pixel on the display with 160 PPI
+	Dp float32
+)
+
+// Pixels implements Length
+func (m Mm) Pixels(ppi float32) float32 {
+	return float32(m) * ppi / 25.4
+}
+
+// Pixels implements Length
+func (p Pt) Pixels(ppi float32) float32 {
+	return float32(p) * ppi / 72.0
+}
+
+// Pixels implements Length
+func (d Dp) Pixels(ppi float32) float32 {
+	return float32(d) * ppi / 160.0
+}
+
+// Pixels returns the length l in pixels for the display
+func (dc DisplayConfig) Pixels(l Length) int32 {
+	return int32(math.Round(float64(l.Pixels(dc.PPI))))
+}
+
+// MinTouchTargetPixels returns the MinTouchTarget size in pixels for the display
+func (dc DisplayConfig) MinTouchTargetPixels() int32 {
+	return dc.Pixels(dc.MinTouchTarget)
+}
+
+// Px returns the length l in pixels for the display of the default App. If the App
+// is not initialized, the DefaultDisplayConfig() density is used.
+func Px(l Length) int32 {
+	return c.Px(l)
+}
+
+// PxF returns the length l in pixels (not rounded) for the display of the default App.
+// If the App is not initialized, the DefaultDisplayConfig() density is used.
+func PxF(l Length) float32 {
+	return c.PxF(l)
+}
+
+// Px returns the length l in pixels for the App display. If the App is not initialized,
+// the DefaultDisplayConfig() density is used.
+func (c *App) Px(l Length) int32 {
+	return c.displayConfig().Pixels(l)
+}
+
+// PxF returns the length l in pixels (not rounded) for the App display. If the App is not
+// initialized, the DefaultDisplayConfig() density is used.
+func (c *App) PxF(l Length) float32 {
+	return l.Pixels(c.displayConfig().PPI)
+}
+
+// TouchTarget returns the rectangle r extended around its center to the minimum touch
+// target size (see DisplayConfig.MinTouchTarget), if r is smaller than that
+func TouchTarget(r rl.RectangleInt32, minSize int32) rl.RectangleInt32 {
+	if r.Width < minSize {
+		r.X -= (minSize - r.Width) / 2
+		r.Width = minSize
+	}
+	if r.Height < minSize {
+		r.Y -= (minSize - r.Height) / 2
+		r.Height = minSize
+	}
+	return r
+}
+
+func displayConfig() DisplayConfig {
+	return c.displayConfig()
+}
+
+// displayConfig returns the App display config, or the default one if the App is not initialized
+func (c *App) displayConfig() DisplayConfig {
+	if c.disp == nil {
+		return DefaultDisplayConfig()
+	}
+	return c.disp.cfg
+}
@@ -0,0 +1,128 @@
+package raywin
+
+// Copyright 2025 Dmitry Spasibenko
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+import (
+	rl "github.com/gen2brain/raylib-go/raylib"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestUnits(t *testing.T) {
+	dc := DisplayConfig{PPI: 254}
+	assert.Equal(t, int32(100), dc.Pixels(Mm(10)))
+	assert.Equal(t, int32(254), dc.Pixels(Pt(72)))
+	assert.Equal(t, int32(254), dc.Pixels(Dp(160)))
+	dc.PPI = 160
+	assert.Equal(t, int32(10), dc.Pixels(Dp(10)))
+	assert.Equal(t, float32(20), Dp(20).Pixels(160))
+	dc.MinTouchTarget = Mm(25.4)
+	assert.Equal(t, int32(160), dc.MinTouchTargetPixels())
+}
+
+func TestTouchTarget(t *testing.T) {
+	assert.Equal(t, rl.RectangleInt32{X: 5, Y: -5, Width: 20, Height: 20}, TouchTarget(rl.RectangleInt32{X: 10, Y: 0, Width: 10, Height: 10}, 20))
+	assert.Equal(t, rl.RectangleInt32{X: 10, Y: -5, Width: 30, Height: 20}, TouchTarget(rl.RectangleInt32{X: 10, Y: 0, Width: 30, Height: 10}, 20))
+}
+
+func TestAnchorLayout(t *testing.T) {
+	d := newDisplay(DefaultDisplayConfig(), &testProxy{})
+	al, err := NewAnchorLayout(&d.root, rl.RectangleInt32{Width: 500, Height: 300})
+	assert.Nil(t, err)
+	c1 := newTestChild(t, al, 30, 10)
+	c2 := newTestChild(t, al, 40, 20)
+	c3 := newTestChild(t, al, 40, 20)
+	al.SetAnchors(c1, Anchors{Left: Dp(10), Right: Dp(10), Top: Dp(20)})
+	al.SetAnchors(c2, Anchors{Right: Dp(10), Bottom: Dp(10), Width: Dp(100)})
+	c3.SetBounds(rl.RectangleInt32{X: 7, Y: 7, Width: 10, Height: 10})
+
+	dc := DisplayConfig{PPI: 160}
+	al.layout(dc)
+	assert.Equal(t, rl.RectangleInt32{X: 10, Y: 20, Width: 480, Height: 10}, c1.Bounds())
+	assert.Equal(t, rl.RectangleInt32{X: 390, Y: 270, Width: 100, Height: 20}, c2.Bounds())
+	assert.Equal(t, rl.RectangleInt32{X: 7, Y: 7, Width: 10, Height: 10}, c3.Bounds())
+
+	al.SetAnchors(c3, Anchors{Height: Dp(50)})
+	c1.Close()
+	al.layout(dc)
+	assert.Equal(t, rl.RectangleInt32{X: 245, Y: 125, Width: 10, Height: 50}, c3.Bounds())
+	assert.Equal(t, 2, len(al.anchors))
+}
+
+func Test_display_minTouchTarget(t *testing.T) {
+	cfg := DefaultDisplayConfig()
+	cfg.PPI = 254
+	cfg.MinTouchTarget = 6 // 60 pixels
+	d := newDisplay(cfg, &testProxy{})
+	var c1, c2 _display_test_container
+	assert.Nil(t, c1.Init(&d.root, &c1))
+	c1.SetBounds(rl.RectangleInt32{X: 100, Y: 100, Width: 20, Height: 20})
+	assert.Nil(t, c2.Init(&d.root, &c2))
+	c2.SetBounds(rl.RectangleInt32{X: 140, Y: 100, Width: 20, Height: 20})
+	c1.onTPSResult = OnTPSResultStop
+	c2.onTPSResult = OnTPSResultStop
+
+	// the exact hit wins
+	d.tp.pos = rl.Vector2{X: 141, Y: 110}
+	d.walkForTouchPadChildren(&d.root)
+	assert.Equal(t, 0, c1.ontpsstate)
+	assert.Equal(t, 1, c2.ontpsstate)
+
+	// the extended targets, the topmost one wins
+	d.tp.pos = rl.Vector2{X: 130, Y: 85}
+	d.walkForTouchPadChildren(&d.root)
+	assert.Equal(t, 0, c1.ontpsstate)
+	assert.Equal(t, 2, c2.ontpsstate)
+
+	d.tp.pos = rl.Vector2{X: 95, Y: 125}
+	d.walkForTouchPadChildren(&d.root)
+	assert.Equal(t, 1, c1.ontpsstate)
+
+	// too far
+	d.tp.pos = rl.Vector2{X: 70, Y: 110}
+	d.walkForTouchPadChildren(&d.root)
+	assert.Equal(t, 1, c1.ontpsstate)
+	assert.Equal(t, 2, c2.ontpsstate)
+}
+
+func Test_display_minTouchTargetDefault(t *testing.T) {
+	// the touch targets are not extended by default, so the touches hit the same
+	// components as without the setting
+	cfg := DefaultDisplayConfig()
+	assert.Equal(t, int32(0), cfg.MinTouchTargetPixels())
+	d := newDisplay(cfg, &testProxy{})
+	var c1, c2 _display_test_container
+	assert.Nil(t, c1.Init(&d.root, &c1))
+	c1.SetBounds(rl.RectangleInt32{X: 100, Y: 100, Width: 20, Height: 20})
+	assert.Nil(t, c2.Init(&d.root, &c2))
+	c2.SetBounds(rl.RectangleInt32{X: 140, Y: 100, Width: 20, Height: 20})
+	c1.onTPSResult = OnTPSResultStop
+	c2.onTPSResult = OnTPSResultStop
+
+	d.tp.pos = rl.Vector2{X: 141, Y: 110}
+	d.walkForTouchPadChildren(&d.root)
+	d.tp.pos = rl.Vector2{X: 101, Y: 110}
+	d.walkForTouchPadChildren(&d.root)
+	assert.Equal(t, 1, c1.ontpsstate)
+	assert.Equal(t, 1, c2.ontpsstate)
+
+	// the touches next to the small components are not delivered to them
+	for _, p := range []rl.Vector2{{X: 130, Y: 110}, {X: 95, Y: 110}, {X: 110, Y: 125}, {X: 165, Y: 95}} {
+		d.tp.pos = p
+		assert.Equal(t, OnTPSResultNA, d.walkForTouchPadChildren(&d.root))
+	}
+	assert.Equal(t, 1, c1.ontpsstate)
+	assert.Equal(t, 1, c2.ontpsstate)
+}